@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"football-team-management/internal/domain/user"
+	apperrors "football-team-management/internal/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// claimsFromContext returns the token claims stored by middleware.JWTAuth, or nil if there are none
+func claimsFromContext(c *gin.Context) *user.Claims {
+	value, _ := c.Get("claims")
+	claims, _ := value.(*user.Claims)
+	return claims
+}
+
+// authorizeTeams responds with 403 and returns false unless the caller may manage at least one of teams.
+// Admins may manage every team, team managers only the teams assigned to them
+func authorizeTeams(c *gin.Context, teams ...string) bool {
+	claims := claimsFromContext(c)
+	if claims != nil {
+		for _, team := range teams {
+			if claims.CanManageTeam(team) {
+				return true
+			}
+		}
+	}
+	c.Error(apperrors.ErrForbidden.WithMessage("Insufficient permissions for this team"))
+	return false
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"context"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"football-team-management/internal/usecases"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BracketHandler struct {
+	service usecases.BracketService
+}
+
+func NewBracketHandler(service usecases.BracketService) *BracketHandler {
+	return &BracketHandler{service: service}
+}
+
+func (h *BracketHandler) Register(c *gin.Context) {
+	var bracketReq domain.BracketRequest
+	if err := c.ShouldBindJSON(&bracketReq); err != nil {
+		c.Error(apperrors.Invalid(err))
+		return
+	}
+
+	opts, err := bracketReq.ToBracketOptions()
+	if err != nil {
+		c.Error(apperrors.Invalid(err))
+		return
+	}
+
+	bracket, err := h.service.Create(context.Background(), *opts)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, bracket.ToBracketResponse())
+}
+
+func (h *BracketHandler) List(c *gin.Context) {
+	brackets, err := h.service.List(context.Background())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var responses []*domain.BracketResponse
+	for _, bracket := range brackets {
+		responses = append(responses, bracket.ToBracketResponse())
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+func (h *BracketHandler) GetByID(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid bracket id"))
+		return
+	}
+
+	bracket, err := h.service.GetByID(context.Background(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, bracket.ToBracketResponse())
+}
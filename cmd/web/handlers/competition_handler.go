@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"football-team-management/internal/usecases"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CompetitionHandler struct {
+	repo usecases.CompetitionRepository
+}
+
+func NewCompetitionHandler(repo usecases.CompetitionRepository) *CompetitionHandler {
+	return &CompetitionHandler{repo: repo}
+}
+
+func (h *CompetitionHandler) Register(c *gin.Context) {
+	var competition domain.Competition
+	if err := c.ShouldBindJSON(&competition); err != nil {
+		c.Error(apperrors.Invalid(err))
+		return
+	}
+
+	id, err := h.repo.Register(context.Background(), competition)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	competition.ID = id
+	c.JSON(http.StatusCreated, competition)
+}
+
+func (h *CompetitionHandler) Update(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid competition id"))
+		return
+	}
+
+	var competition domain.Competition
+	if err := c.ShouldBindJSON(&competition); err != nil {
+		c.Error(apperrors.Invalid(err))
+		return
+	}
+
+	if err := h.repo.Update(context.Background(), id, competition); err != nil {
+		c.Error(err)
+		return
+	}
+
+	competition.ID = id
+	c.JSON(http.StatusOK, competition)
+}
+
+func (h *CompetitionHandler) Delete(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid competition id"))
+		return
+	}
+
+	if err := h.repo.Delete(context.Background(), id); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "competition deleted"})
+}
+
+func (h *CompetitionHandler) List(c *gin.Context) {
+	competitions, err := h.repo.List(context.Background())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, competitions)
+}
+
+func (h *CompetitionHandler) GetByID(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid competition id"))
+		return
+	}
+
+	competition, err := h.repo.GetByID(context.Background(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, competition)
+}
+
+func (h *CompetitionHandler) Restore(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid competition id"))
+		return
+	}
+
+	if err := h.repo.Restore(context.Background(), id); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "competition restored"})
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"context"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"football-team-management/internal/usecases"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FixtureHandler struct {
+	service usecases.FixtureService
+}
+
+func NewFixtureHandler(service usecases.FixtureService) *FixtureHandler {
+	return &FixtureHandler{service: service}
+}
+
+func (h *FixtureHandler) Generate(c *gin.Context) {
+	var fixtureReq domain.FixtureRequest
+	if err := c.ShouldBindJSON(&fixtureReq); err != nil {
+		c.Error(apperrors.Invalid(err))
+		return
+	}
+
+	opts, err := fixtureReq.ToFixtureOptions()
+	if err != nil {
+		c.Error(apperrors.Invalid(err))
+		return
+	}
+
+	rounds, err := h.service.Generate(context.Background(), *opts, fixtureReq.DryRun)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	status := http.StatusCreated
+	if fixtureReq.DryRun {
+		status = http.StatusOK
+	}
+	c.JSON(status, domain.ToFixtureResponse(rounds, fixtureReq.DryRun))
+}
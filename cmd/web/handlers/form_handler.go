@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"context"
+	apperrors "football-team-management/internal/pkg/errors"
+	"football-team-management/internal/usecases"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FormHandler struct {
+	service usecases.FormService
+}
+
+func NewFormHandler(service usecases.FormService) *FormHandler {
+	return &FormHandler{service: service}
+}
+
+func (h *FormHandler) Form(c *gin.Context) {
+	team := c.Param("team")
+
+	filter, err := parseMatchFilter(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	window, err := optionalIntQuery(c, "window")
+	if err != nil || (window != nil && *window < 1) {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid window, it must be a positive number"))
+		return
+	}
+	if window == nil {
+		window = new(int)
+	}
+
+	form, err := h.service.Form(context.Background(), team, filter, *window)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, form)
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"context"
+	apperrors "football-team-management/internal/pkg/errors"
+	"football-team-management/internal/usecases"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HeadToHeadHandler struct {
+	service usecases.HeadToHeadService
+}
+
+func NewHeadToHeadHandler(service usecases.HeadToHeadService) *HeadToHeadHandler {
+	return &HeadToHeadHandler{service: service}
+}
+
+func (h *HeadToHeadHandler) Compare(c *gin.Context) {
+	team := c.Param("team")
+	opponent := c.Param("opponent")
+	if team == opponent {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("a team cannot be compared with itself"))
+		return
+	}
+
+	last, err := optionalIntQuery(c, "last")
+	if err != nil {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid last"))
+		return
+	}
+	if last == nil {
+		last = new(int)
+	}
+
+	h2h, err := h.service.Compare(context.Background(), team, opponent, *last)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, h2h)
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	apperrors "football-team-management/internal/pkg/errors"
 	"football-team-management/internal/usecases"
 	"net/http"
 
@@ -27,18 +28,15 @@ func NewLoginHandlerImpl(authService usecases.AuthService) *loginHandlerImpl {
 func (l *loginHandlerImpl) Handle(c *gin.Context) {
 	var req LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(apperrors.Invalid(err))
 		return
 	}
 
-	token, err := l.authService.GenerateToken(req.Username, req.Password)
+	tokens, err := l.authService.GenerateToken(req.Username, req.Password)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"token": token,
-		"type":  "Bearer",
-	})
+	c.JSON(http.StatusOK, tokens)
 }
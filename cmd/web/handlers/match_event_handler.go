@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"football-team-management/internal/usecases"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MatchEventHandler struct {
+	repo      usecases.MatchEventRepository
+	matchRepo usecases.MatchRepository
+}
+
+func NewMatchEventHandler(repo usecases.MatchEventRepository, matchRepo usecases.MatchRepository) *MatchEventHandler {
+	return &MatchEventHandler{repo: repo, matchRepo: matchRepo}
+}
+
+func (h *MatchEventHandler) Register(c *gin.Context) {
+	var eventReq domain.MatchEventRequest
+	if err := c.ShouldBindJSON(&eventReq); err != nil {
+		c.Error(apperrors.Invalid(err))
+		return
+	}
+
+	match, err := h.matchRepo.GetByID(context.Background(), eventReq.MatchID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if !authorizeTeams(c, match.HomeTeam, match.AwayTeam) {
+		return
+	}
+
+	event := eventReq.ToMatchEvent()
+	id, err := h.repo.Register(context.Background(), *event)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	saved, err := h.repo.GetByID(context.Background(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := saved.ToMatchEventResponse()
+	c.JSON(http.StatusCreated, response)
+}
+
+func (h *MatchEventHandler) Delete(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid match event id"))
+		return
+	}
+
+	event, err := h.repo.GetByID(context.Background(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	match, err := h.matchRepo.GetByID(context.Background(), event.MatchID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if !authorizeTeams(c, match.HomeTeam, match.AwayTeam) {
+		return
+	}
+
+	if err := h.repo.Delete(context.Background(), id); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "match event deleted"})
+}
+
+// Timeline lists the events of a match in the order they happened
+func (h *MatchEventHandler) Timeline(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid match id"))
+		return
+	}
+
+	if _, err := h.matchRepo.GetByID(context.Background(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	events, err := h.repo.ListByMatch(context.Background(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responses := make([]*domain.MatchEventResponse, 0, len(events))
+	for _, event := range events {
+		responses = append(responses, event.ToMatchEventResponse())
+	}
+	c.JSON(http.StatusOK, responses)
+}
@@ -3,9 +3,11 @@ package handlers
 import (
 	"context"
 	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
 	"football-team-management/internal/usecases"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -20,22 +22,28 @@ func NewMatchHandler(repo usecases.MatchRepository) *MatchHandler {
 
 func (h *MatchHandler) Register(c *gin.Context) {
 	var matchReq domain.MatchRequest
-	if err := c.ShouldBindJSON(&matchReq); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &matchReq); err != nil {
+		c.Error(err)
+		return
+	}
+	if err := matchReq.Validate(); err != nil {
+		c.Error(invalidFields(err))
 		return
 	}
 
 	match, err := matchReq.ToMatch()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid date format. Use YYYY-MM-DD"})
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid date format. Use YYYY-MM-DD"))
 		return
 	}
 
-	if err := h.repo.Register(context.Background(), *match); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	id, err := h.repo.Register(context.Background(), *match)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
+	match.ID = id
 	response := match.ToMatchResponse()
 	c.JSON(http.StatusCreated, response)
 }
@@ -44,24 +52,28 @@ func (h *MatchHandler) Update(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid match id"})
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid match id"))
 		return
 	}
 
 	var matchReq domain.MatchRequest
-	if err := c.ShouldBindJSON(&matchReq); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &matchReq); err != nil {
+		c.Error(err)
+		return
+	}
+	if err := matchReq.Validate(); err != nil {
+		c.Error(invalidFields(err))
 		return
 	}
 
 	match, err := matchReq.ToMatch()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid date format. Use YYYY-MM-DD"})
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid date format. Use YYYY-MM-DD"))
 		return
 	}
 
 	if err := h.repo.Update(context.Background(), id, *match); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -74,57 +86,80 @@ func (h *MatchHandler) Delete(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid match id"})
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid match id"))
 		return
 	}
 
 	if err := h.repo.Delete(context.Background(), id); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "match deleted"})
 }
 
+// List returns a page of the matches, narrowed down by parseMatchFilter and to those of a team with
+// ?team=, optionally only its home or away matches with ?venue=. Admins may add deleted matches with
+// ?include_deleted= or ?only_deleted=
 func (h *MatchHandler) List(c *gin.Context) {
-	matches, err := h.repo.List(context.Background())
+	h.search(c, c.Query("team"))
+}
+
+// ListByTeam returns a page of the matches of the team with the given ID or slug
+func (h *MatchHandler) ListByTeam(c *gin.Context) {
+	h.search(c, c.Param("team"))
+}
+
+func (h *MatchHandler) search(c *gin.Context, team string) {
+	filter, err := parseMatchFilter(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
+		return
+	}
+	page, err := parsePage(c, domain.MatchSortFields)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	var responses []*domain.MatchResponse
-	for _, match := range matches {
-		responses = append(responses, match.ToMatchResponse())
+	query := domain.MatchQuery{MatchFilter: filter, Team: team, Venue: c.Query("venue"), PageRequest: page}
+	if query.Venue != "" && query.Venue != domain.VenueHome && query.Venue != domain.VenueAway {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid venue, use home or away"))
+		return
+	}
+	if query.Venue != "" && team == "" {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("venue needs a team"))
+		return
+	}
+	query.Deleted, err = parseDeleted(c)
+	if err != nil {
+		c.Error(err)
+		return
 	}
-	c.JSON(http.StatusOK, responses)
-}
 
-func (h *MatchHandler) ListByTeam(c *gin.Context) {
-	teamName := c.Param("teamName")
-	matches, err := h.repo.ListByTeam(context.Background(), teamName)
+	matches, err := h.repo.Search(context.Background(), query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	var responses []*domain.MatchResponse
-	for _, match := range matches {
+	responses := make([]*domain.MatchResponse, 0, len(matches.Items))
+	for _, match := range matches.Items {
 		responses = append(responses, match.ToMatchResponse())
 	}
-	c.JSON(http.StatusOK, responses)
+	c.JSON(http.StatusOK, &domain.Page[*domain.MatchResponse]{Items: responses, Total: matches.Total, Limit: matches.Limit, Offset: matches.Offset})
 }
 
 func (h *MatchHandler) GetByID(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid match id"})
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid match id"))
 		return
 	}
 
 	match, err := h.repo.GetByID(context.Background(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -136,13 +171,104 @@ func (h *MatchHandler) Restore(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid match id"})
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid match id"))
 		return
 	}
 
 	if err := h.repo.Restore(context.Background(), id); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "match restored"})
 }
+
+// UpdateStatus moves a match through its lifecycle. Managers of either team may report the status of their matches
+func (h *MatchHandler) UpdateStatus(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid match id"))
+		return
+	}
+
+	var statusReq domain.MatchStatusRequest
+	if err := c.ShouldBindJSON(&statusReq); err != nil {
+		c.Error(apperrors.Invalid(err))
+		return
+	}
+
+	existing, err := h.repo.GetByID(context.Background(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if !authorizeTeams(c, existing.HomeTeam, existing.AwayTeam) {
+		return
+	}
+
+	match, err := h.repo.UpdateStatus(context.Background(), id, statusReq.Status)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := match.ToMatchResponse()
+	c.JSON(http.StatusOK, response)
+}
+
+// parseMatchFilter reads the optional season_id, competition_id, from and to query parameters
+func parseMatchFilter(c *gin.Context) (domain.MatchFilter, error) {
+	var filter domain.MatchFilter
+
+	seasonID, err := optionalIntQuery(c, "season_id")
+	if err != nil {
+		return filter, apperrors.ErrInvalidInput.WithMessage("invalid season id")
+	}
+	filter.SeasonID = seasonID
+
+	competitionID, err := optionalIntQuery(c, "competition_id")
+	if err != nil {
+		return filter, apperrors.ErrInvalidInput.WithMessage("invalid competition id")
+	}
+	filter.CompetitionID = competitionID
+
+	from, err := optionalDateQuery(c, "from")
+	if err != nil {
+		return filter, apperrors.ErrInvalidInput.WithMessage("invalid from date format. Use YYYY-MM-DD")
+	}
+	filter.From = from
+
+	to, err := optionalDateQuery(c, "to")
+	if err != nil {
+		return filter, apperrors.ErrInvalidInput.WithMessage("invalid to date format. Use YYYY-MM-DD")
+	}
+	filter.To = to
+
+	return filter, nil
+}
+
+// optionalIntQuery returns nil when the query parameter is absent
+func optionalIntQuery(c *gin.Context, key string) (*int, error) {
+	value, ok := c.GetQuery(key)
+	if !ok || value == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// optionalDateQuery returns nil when the query parameter is absent
+func optionalDateQuery(c *gin.Context, key string) (*time.Time, error) {
+	value, ok := c.GetQuery(key)
+	if !ok || value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
 	"football-team-management/internal/usecases"
 	"net/http"
 	"strconv"
@@ -11,26 +12,51 @@ import (
 )
 
 type MatchResultHandler struct {
-	repo usecases.MatchResultRepository
+	repo      usecases.MatchResultRepository
+	matchRepo usecases.MatchRepository
 }
 
-func NewMatchResultHandler(repo usecases.MatchResultRepository) *MatchResultHandler {
-	return &MatchResultHandler{repo: repo}
+func NewMatchResultHandler(repo usecases.MatchResultRepository, matchRepo usecases.MatchRepository) *MatchResultHandler {
+	return &MatchResultHandler{repo: repo, matchRepo: matchRepo}
+}
+
+// authorizeMatch responds with an error and returns false unless the caller may manage
+// one of the teams playing in the match
+func (h *MatchResultHandler) authorizeMatch(c *gin.Context, matchID int) bool {
+	match, err := h.matchRepo.GetByID(context.Background(), matchID)
+	if err != nil {
+		c.Error(err)
+		return false
+	}
+	return authorizeTeams(c, match.HomeTeam, match.AwayTeam)
 }
 
 func (h *MatchResultHandler) Register(c *gin.Context) {
 	var resultReq domain.MatchResultRequest
-	if err := c.ShouldBindJSON(&resultReq); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &resultReq); err != nil {
+		c.Error(err)
+		return
+	}
+	if err := resultReq.Validate(); err != nil {
+		c.Error(invalidFields(err))
+		return
+	}
+
+	if !h.authorizeMatch(c, resultReq.MatchID) {
 		return
 	}
 
 	result := resultReq.ToMatchResult()
 	if err := h.repo.Register(context.Background(), *result); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
+	// Reload the result, its goals may have been taken from the match timeline
+	if saved, err := h.repo.GetByMatchID(context.Background(), result.MatchID); err == nil {
+		result = saved
+	}
+
 	response := result.ToMatchResultResponse()
 	c.JSON(http.StatusCreated, response)
 }
@@ -39,22 +65,40 @@ func (h *MatchResultHandler) Update(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid match result id"})
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid match result id"))
 		return
 	}
 
 	var resultReq domain.MatchResultRequest
-	if err := c.ShouldBindJSON(&resultReq); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &resultReq); err != nil {
+		c.Error(err)
+		return
+	}
+	if err := resultReq.Validate(); err != nil {
+		c.Error(invalidFields(err))
+		return
+	}
+
+	existing, err := h.repo.GetByID(context.Background(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if !h.authorizeMatch(c, existing.MatchID) {
 		return
 	}
 
 	result := resultReq.ToMatchResult()
 	if err := h.repo.Update(context.Background(), id, *result); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
+	// Reload the result, its goals may have been taken from the match timeline
+	if saved, err := h.repo.GetByID(context.Background(), id); err == nil {
+		result = saved
+	}
+
 	response := result.ToMatchResultResponse()
 	response.ID = id
 	c.JSON(http.StatusOK, response)
@@ -64,42 +108,54 @@ func (h *MatchResultHandler) Delete(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid match result id"})
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid match result id"))
 		return
 	}
 
 	if err := h.repo.Delete(context.Background(), id); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "match result deleted"})
 }
 
 func (h *MatchResultHandler) List(c *gin.Context) {
-	results, err := h.repo.List(context.Background())
+	page, err := parsePage(c, domain.MatchResultSortFields)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	deleted, err := parseDeleted(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	results, err := h.repo.Search(context.Background(), domain.MatchResultQuery{Deleted: deleted, PageRequest: page})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
-	var responses []*domain.MatchResultResponse
-	for _, result := range results {
+	responses := make([]*domain.MatchResultResponse, 0, len(results.Items))
+	for _, result := range results.Items {
 		responses = append(responses, result.ToMatchResultResponse())
 	}
-	c.JSON(http.StatusOK, responses)
+	c.JSON(http.StatusOK, &domain.Page[*domain.MatchResultResponse]{Items: responses, Total: results.Total, Limit: results.Limit, Offset: results.Offset})
 }
 
 func (h *MatchResultHandler) GetByMatchID(c *gin.Context) {
 	matchIDStr := c.Param("matchID")
 	matchID, err := strconv.Atoi(matchIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid match id"})
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid match id"))
 		return
 	}
 
 	result, err := h.repo.GetByMatchID(context.Background(), matchID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -111,13 +167,13 @@ func (h *MatchResultHandler) GetByID(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid match result id"})
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid match result id"))
 		return
 	}
 
 	result, err := h.repo.GetByID(context.Background(), id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -129,12 +185,12 @@ func (h *MatchResultHandler) Restore(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid match result id"})
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid match result id"))
 		return
 	}
 
 	if err := h.repo.Restore(context.Background(), id); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "match result restored"})
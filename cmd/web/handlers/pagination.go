@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"fmt"
+	"football-team-management/internal/domain"
+	"football-team-management/internal/domain/user"
+	apperrors "football-team-management/internal/pkg/errors"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// parsePage reads the optional limit, offset and sort query parameters. sort must name one of fields,
+// prefixed with "-" to sort descending
+func parsePage(c *gin.Context, fields []string) (domain.PageRequest, error) {
+	var page domain.PageRequest
+
+	limit, err := optionalIntQuery(c, "limit")
+	if err != nil || (limit != nil && (*limit < 1 || *limit > domain.MaxPageLimit)) {
+		return page, apperrors.ErrInvalidInput.WithMessage(fmt.Sprintf("invalid limit, it must be between 1 and %d", domain.MaxPageLimit))
+	}
+	if limit != nil {
+		page.Limit = *limit
+	}
+
+	offset, err := optionalIntQuery(c, "offset")
+	if err != nil || (offset != nil && *offset < 0) {
+		return page, apperrors.ErrInvalidInput.WithMessage("invalid offset, it cannot be negative")
+	}
+	if offset != nil {
+		page.Offset = *offset
+	}
+
+	page.Sort = c.Query("sort")
+	if page.Sort != "" && !domain.ValidSort(page.Sort, fields) {
+		return page, apperrors.ErrInvalidInput.WithMessage(fmt.Sprintf("invalid sort, use one of %s, prefixed with - to sort descending", strings.Join(fields, ", ")))
+	}
+	return page, nil
+}
+
+// parseDeleted reads the optional include_deleted and only_deleted query parameters, which only admins
+// may set. only_deleted wins if both are true
+func parseDeleted(c *gin.Context) (domain.DeletedFilter, error) {
+	var filter domain.DeletedFilter
+	for _, option := range []struct {
+		name   string
+		filter domain.DeletedFilter
+	}{{"include_deleted", domain.IncludeDeleted}, {"only_deleted", domain.OnlyDeleted}} {
+		value := c.Query(option.name)
+		if value == "" {
+			continue
+		}
+		set, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, apperrors.ErrInvalidInput.WithMessage(fmt.Sprintf("invalid %s, use true or false", option.name))
+		}
+		if set {
+			filter = option.filter
+		}
+	}
+
+	if claims := claimsFromContext(c); filter != domain.ExcludeDeleted && (claims == nil || claims.Role != user.RoleAdmin) {
+		return filter, apperrors.ErrForbidden.WithMessage("only admins may list deleted records")
+	}
+	return filter, nil
+}
@@ -3,8 +3,10 @@ package handlers
 import (
 	"context"
 	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
 	"football-team-management/internal/usecases"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -19,73 +21,145 @@ func NewPlayerHandler(repo usecases.PlayerRepository) *PlayerHandler {
 
 func (h *PlayerHandler) Register(c *gin.Context) {
 	var player domain.Player
-	if err := c.ShouldBindJSON(&player); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &player); err != nil {
+		c.Error(err)
 		return
 	}
-	if err := h.repo.Register(context.Background(), player); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := player.Validate(); err != nil {
+		c.Error(invalidFields(err))
 		return
 	}
-	c.JSON(http.StatusCreated, player)
+	if !authorizeTeams(c, player.TeamName) {
+		return
+	}
+	id, err := h.repo.Register(context.Background(), player)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Respond with the ID and slug assigned on registration
+	registered, err := h.repo.GetByKey(context.Background(), strconv.Itoa(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, registered)
 }
 
 func (h *PlayerHandler) Update(c *gin.Context) {
-	name := c.Param("playerName")
+	key := c.Param("player")
 	var player domain.Player
-	if err := c.ShouldBindJSON(&player); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &player); err != nil {
+		c.Error(err)
 		return
 	}
-	if err := h.repo.Update(context.Background(), name, player); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	if err := player.Validate(); err != nil {
+		c.Error(invalidFields(err))
 		return
 	}
-	c.JSON(http.StatusOK, player)
+
+	// The caller must manage the player's team, which only a transfer can change
+	existing, err := h.repo.GetByKey(context.Background(), key)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if !authorizeTeams(c, existing.TeamName) {
+		return
+	}
+	if player.TeamName != existing.TeamName {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("player team cannot be changed here. Use a transfer to move the player to another team"))
+		return
+	}
+
+	if err := h.repo.Update(context.Background(), key, player); err != nil {
+		c.Error(err)
+		return
+	}
+
+	updated, err := h.repo.GetByKey(context.Background(), key)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, updated)
 }
 
 func (h *PlayerHandler) Delete(c *gin.Context) {
-	name := c.Param("playerName")
-	if err := h.repo.Delete(context.Background(), name); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	key := c.Param("player")
+	existing, err := h.repo.GetByKey(context.Background(), key)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if !authorizeTeams(c, existing.TeamName) {
+		return
+	}
+
+	if err := h.repo.Delete(context.Background(), key); err != nil {
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "player deleted"})
 }
 
+// List returns a page of the players, filtered with ?team=, ?position= and ?jersey_number=, and
+// ?include_deleted= or ?only_deleted= for admins
 func (h *PlayerHandler) List(c *gin.Context) {
-	players, err := h.repo.List(context.Background())
+	h.search(c, c.Query("team"))
+}
+
+// ListByTeam returns a page of the players of the team with the given ID or slug
+func (h *PlayerHandler) ListByTeam(c *gin.Context) {
+	h.search(c, c.Param("team"))
+}
+
+func (h *PlayerHandler) search(c *gin.Context, team string) {
+	page, err := parsePage(c, domain.PlayerSortFields)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, players)
-}
 
-func (h *PlayerHandler) ListByTeam(c *gin.Context) {
-	teamName := c.Param("teamName")
-	players, err := h.repo.ListByTeam(context.Background(), teamName)
+	query := domain.PlayerQuery{Team: team, Position: domain.PlayerPosition(c.Query("position")), PageRequest: page}
+	if query.Position != "" && !domain.ValidPosition(query.Position) {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid position " + string(query.Position)))
+		return
+	}
+	query.JerseyNumber, err = optionalIntQuery(c, "jersey_number")
+	if err != nil {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid jersey number"))
+		return
+	}
+	query.Deleted, err = parseDeleted(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	players, err := h.repo.Search(context.Background(), query)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, players)
 }
 
-func (h *PlayerHandler) GetByName(c *gin.Context) {
-	name := c.Param("playerName")
-	player, err := h.repo.GetByName(context.Background(), name)
+func (h *PlayerHandler) GetByKey(c *gin.Context) {
+	key := c.Param("player")
+	player, err := h.repo.GetByKey(context.Background(), key)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, player)
 }
 
 func (h *PlayerHandler) Restore(c *gin.Context) {
-	name := c.Param("playerName")
-	if err := h.repo.Restore(context.Background(), name); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	key := c.Param("player")
+	if err := h.repo.Restore(context.Background(), key); err != nil {
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "player restored"})
@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"football-team-management/cmd/web/middleware"
+	"football-team-management/internal/domain"
+	"football-team-management/internal/domain/user"
+	"football-team-management/internal/usecases"
+	"football-team-management/test"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newPlayerRepo returns an in-memory player repository with the teams Persija and Persib and the given players
+func newPlayerRepo(t *testing.T, players ...domain.Player) *usecases.MemoryPlayerRepo {
+	store := usecases.NewMemoryStore()
+	teams := usecases.NewMemoryTeamRepo(store)
+	for _, name := range []string{"Persija", "Persib"} {
+		_, err := teams.Register(context.Background(), domain.Team{Name: name})
+		require.NoError(t, err)
+	}
+
+	repo := usecases.NewMemoryPlayerRepo(store)
+	for _, player := range players {
+		_, err := repo.Register(context.Background(), player)
+		require.NoError(t, err)
+	}
+	return repo
+}
+
+// teamOf returns the team of the player with the given key, failing the test if there is no such player
+func teamOf(t *testing.T, repo *usecases.MemoryPlayerRepo, key string) string {
+	player, err := repo.GetByKey(context.Background(), key)
+	require.NoError(t, err)
+	return player.TeamName
+}
+
+func routerWithClaims(claims *user.Claims, URI string, handler func(c *gin.Context), method string) *gin.Engine {
+	router := gin.New()
+	router.Use(middleware.ErrorHandler())
+	router.Use(func(c *gin.Context) {
+		c.Set("claims", claims)
+	})
+	router.Handle(method, URI, handler)
+	return router
+}
+
+func TestPlayerHandler_TeamScope(t *testing.T) {
+	manager := &user.Claims{Username: "coach", Role: user.RoleTeamManager, Teams: []string{"Persija"}}
+	playerBody := `{"name":"Andi","height":175,"weight":70,"position":"gelandang","jersey_number":8,"team_name":"%s"}`
+
+	t.Run("Team manager registers player for own team", func(t *testing.T) {
+		handler := NewPlayerHandler(newPlayerRepo(t))
+		router := routerWithClaims(manager, "/api/v1/players", handler.Register, http.MethodPost)
+
+		body := bytes.NewBufferString(fmt.Sprintf(playerBody, "Persija"))
+		response := test.MakeRequest(router, http.MethodPost, "/api/v1/players", body)
+
+		assert.Equal(t, http.StatusCreated, response.Code)
+	})
+
+	t.Run("Team manager cannot register player for another team", func(t *testing.T) {
+		handler := NewPlayerHandler(newPlayerRepo(t))
+		router := routerWithClaims(manager, "/api/v1/players", handler.Register, http.MethodPost)
+
+		body := bytes.NewBufferString(fmt.Sprintf(playerBody, "Persib"))
+		response := test.MakeRequest(router, http.MethodPost, "/api/v1/players", body)
+
+		assert.Equal(t, http.StatusForbidden, response.Code)
+		assert.JSONEq(t, `{"code":"FORBIDDEN","message":"Insufficient permissions for this team"}`, response.Body.String())
+	})
+
+	t.Run("Unknown player is reported with its code", func(t *testing.T) {
+		handler := NewPlayerHandler(newPlayerRepo(t))
+		router := routerWithClaims(manager, "/api/v1/players/:player", handler.Delete, http.MethodDelete)
+
+		response := test.MakeRequest(router, http.MethodDelete, "/api/v1/players/andi", nil)
+
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.JSONEq(t, `{"code":"PLAYER_NOT_FOUND","message":"player not found"}`, response.Body.String())
+	})
+
+	t.Run("Taken jersey number is a conflict", func(t *testing.T) {
+		repo := newPlayerRepo(t, domain.Player{Name: "Budi", JerseyNumber: 8, TeamName: "Persija"})
+		handler := NewPlayerHandler(repo)
+		router := routerWithClaims(manager, "/api/v1/players", handler.Register, http.MethodPost)
+
+		body := bytes.NewBufferString(fmt.Sprintf(playerBody, "Persija"))
+		response := test.MakeRequest(router, http.MethodPost, "/api/v1/players", body)
+
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.JSONEq(t, `{"code":"JERSEY_NUMBER_TAKEN","message":"jersey number already taken in this team"}`, response.Body.String())
+	})
+
+	t.Run("Invalid fields are listed in one response", func(t *testing.T) {
+		handler := NewPlayerHandler(newPlayerRepo(t))
+		router := routerWithClaims(manager, "/api/v1/players", handler.Register, http.MethodPost)
+
+		body := bytes.NewBufferString(`{"name":"Andi","height":175,"weight":70,"position":"striker","jersey_number":0,"team_name":"Persija"}`)
+		response := test.MakeRequest(router, http.MethodPost, "/api/v1/players", body)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.JSONEq(t, `{
+			"code": "VALIDATION_FAILED",
+			"message": "invalid fields: position must be one of penyerang, gelandang, bertahan or penjaga gawang; jersey_number must be between 1 and 99",
+			"details": [
+				{"field": "position", "message": "must be one of penyerang, gelandang, bertahan or penjaga gawang"},
+				{"field": "jersey_number", "message": "must be between 1 and 99"}
+			]
+		}`, response.Body.String())
+	})
+
+	t.Run("A value of the wrong type names its field", func(t *testing.T) {
+		handler := NewPlayerHandler(newPlayerRepo(t))
+		router := routerWithClaims(manager, "/api/v1/players", handler.Register, http.MethodPost)
+
+		body := bytes.NewBufferString(`{"name":"Andi","height":"tall","weight":70,"position":"gelandang","jersey_number":8,"team_name":"Persija"}`)
+		response := test.MakeRequest(router, http.MethodPost, "/api/v1/players", body)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Contains(t, response.Body.String(), `{"field":"height","message":"must be of type int"}`)
+	})
+
+	t.Run("Team manager cannot move player out of another team", func(t *testing.T) {
+		repo := newPlayerRepo(t, domain.Player{Name: "Andi", JerseyNumber: 8, TeamName: "Persib"})
+		handler := NewPlayerHandler(repo)
+		router := routerWithClaims(manager, "/api/v1/players/:player", handler.Update, http.MethodPut)
+
+		body := bytes.NewBufferString(fmt.Sprintf(playerBody, "Persija"))
+		response := test.MakeRequest(router, http.MethodPut, "/api/v1/players/andi", body)
+
+		assert.Equal(t, http.StatusForbidden, response.Code)
+		assert.Equal(t, "Persib", teamOf(t, repo, "andi"))
+	})
+
+	t.Run("Team manager cannot delete player of another team", func(t *testing.T) {
+		repo := newPlayerRepo(t, domain.Player{Name: "Andi", JerseyNumber: 8, TeamName: "Persib"})
+		handler := NewPlayerHandler(repo)
+		router := routerWithClaims(manager, "/api/v1/players/:player", handler.Delete, http.MethodDelete)
+
+		response := test.MakeRequest(router, http.MethodDelete, "/api/v1/players/andi", nil)
+
+		assert.Equal(t, http.StatusForbidden, response.Code)
+		assert.Equal(t, "Persib", teamOf(t, repo, "andi"))
+	})
+
+	t.Run("Admin deletes player of any team", func(t *testing.T) {
+		repo := newPlayerRepo(t, domain.Player{Name: "Andi", JerseyNumber: 8, TeamName: "Persib"})
+		handler := NewPlayerHandler(repo)
+		admin := &user.Claims{Username: "admin", Role: user.RoleAdmin}
+		router := routerWithClaims(admin, "/api/v1/players/:player", handler.Delete, http.MethodDelete)
+
+		response := test.MakeRequest(router, http.MethodDelete, "/api/v1/players/andi", nil)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		_, err := repo.GetByKey(context.Background(), "andi")
+		assert.EqualError(t, err, "player not found")
+	})
+
+	t.Run("Update cannot move a player to another team", func(t *testing.T) {
+		repo := newPlayerRepo(t, domain.Player{Name: "Andi", JerseyNumber: 8, TeamName: "Persib"})
+		handler := NewPlayerHandler(repo)
+		admin := &user.Claims{Username: "admin", Role: user.RoleAdmin}
+		router := routerWithClaims(admin, "/api/v1/players/:player", handler.Update, http.MethodPut)
+
+		body := bytes.NewBufferString(fmt.Sprintf(playerBody, "Persija"))
+		response := test.MakeRequest(router, http.MethodPut, "/api/v1/players/andi", body)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Equal(t, "Persib", teamOf(t, repo, "andi"))
+	})
+
+	t.Run("Update renames a player looked up by ID", func(t *testing.T) {
+		repo := newPlayerRepo(t, domain.Player{Name: "Andi", JerseyNumber: 8, TeamName: "Persija"})
+		handler := NewPlayerHandler(repo)
+		router := routerWithClaims(manager, "/api/v1/players/:player", handler.Update, http.MethodPut)
+
+		body := bytes.NewBufferString(`{"name":"Andi Setiawan","height":175,"weight":70,"position":"gelandang","jersey_number":8,"team_name":"Persija"}`)
+		response := test.MakeRequest(router, http.MethodPut, "/api/v1/players/1", body)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		player, err := repo.GetByKey(context.Background(), "andi")
+		require.NoError(t, err)
+		assert.Equal(t, "Andi Setiawan", player.Name)
+		assert.Equal(t, 1, player.ID)
+	})
+}
+
+func TestPlayerHandler_List(t *testing.T) {
+	repo := newPlayerRepo(t,
+		domain.Player{Name: "Andi", Position: domain.PositionMidfielder, JerseyNumber: 8, TeamName: "Persija"},
+		domain.Player{Name: "Riko", Position: domain.PositionForward, JerseyNumber: 10, TeamName: "Persija"},
+		domain.Player{Name: "Ciro", Position: domain.PositionForward, JerseyNumber: 9, TeamName: "Persib"},
+	)
+	router := test.Router("/api/v1/players", NewPlayerHandler(repo).List, http.MethodGet)
+
+	t.Run("Filters, sorts and pages through the players", func(t *testing.T) {
+		response := test.MakeRequest(router, http.MethodGet, "/api/v1/players?position=penyerang&sort=-jersey_number&limit=1", nil)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Contains(t, response.Body.String(), `"name":"Riko"`)
+		assert.Contains(t, response.Body.String(), `"total":2,"limit":1,"offset":0`)
+	})
+
+	t.Run("Rejects unknown sort fields and limits out of range", func(t *testing.T) {
+		response := test.MakeRequest(router, http.MethodGet, "/api/v1/players?sort=salary", nil)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Contains(t, response.Body.String(), "invalid sort")
+
+		response = test.MakeRequest(router, http.MethodGet, "/api/v1/players?limit=1000", nil)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Contains(t, response.Body.String(), "invalid limit, it must be between 1 and 100")
+	})
+
+	t.Run("Only admins list deleted players", func(t *testing.T) {
+		require.NoError(t, repo.Delete(context.Background(), "ciro"))
+		t.Cleanup(func() { require.NoError(t, repo.Restore(context.Background(), "ciro")) })
+		coach := &user.Claims{Username: "coach", Role: user.RoleTeamManager, Teams: []string{"Persib"}}
+		admin := &user.Claims{Username: "admin", Role: user.RoleAdmin}
+
+		response := test.MakeRequest(routerWithClaims(coach, "/api/v1/players", NewPlayerHandler(repo).List, http.MethodGet), http.MethodGet, "/api/v1/players?only_deleted=true", nil)
+		assert.Equal(t, http.StatusForbidden, response.Code)
+
+		response = test.MakeRequest(routerWithClaims(admin, "/api/v1/players", NewPlayerHandler(repo).List, http.MethodGet), http.MethodGet, "/api/v1/players?only_deleted=true", nil)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Contains(t, response.Body.String(), `"name":"Ciro"`)
+		assert.Contains(t, response.Body.String(), `"total":1`)
+	})
+}
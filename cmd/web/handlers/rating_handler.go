@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"context"
+	"football-team-management/internal/usecases"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RatingHandler struct {
+	service usecases.RatingService
+}
+
+func NewRatingHandler(service usecases.RatingService) *RatingHandler {
+	return &RatingHandler{service: service}
+}
+
+func (h *RatingHandler) List(c *gin.Context) {
+	ratings, err := h.service.Ratings(context.Background())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, ratings)
+}
+
+func (h *RatingHandler) History(c *gin.Context) {
+	team := c.Param("team")
+
+	history, err := h.service.History(context.Background(), team)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, history)
+}
+
+// Recalculate rebuilds all ratings from scratch, e.g. after matches were moved or deleted
+func (h *RatingHandler) Recalculate(c *gin.Context) {
+	if err := h.service.Recalculate(context.Background()); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "ratings recalculated"})
+}
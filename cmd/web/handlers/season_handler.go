@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"context"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"football-team-management/internal/usecases"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SeasonHandler struct {
+	repo usecases.SeasonRepository
+}
+
+func NewSeasonHandler(repo usecases.SeasonRepository) *SeasonHandler {
+	return &SeasonHandler{repo: repo}
+}
+
+func (h *SeasonHandler) Register(c *gin.Context) {
+	var seasonReq domain.SeasonRequest
+	if err := c.ShouldBindJSON(&seasonReq); err != nil {
+		c.Error(apperrors.Invalid(err))
+		return
+	}
+
+	season, err := seasonReq.ToSeason()
+	if err != nil {
+		c.Error(apperrors.Invalid(err))
+		return
+	}
+
+	id, err := h.repo.Register(context.Background(), *season)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	season.ID = id
+	c.JSON(http.StatusCreated, season.ToSeasonResponse())
+}
+
+func (h *SeasonHandler) Update(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid season id"))
+		return
+	}
+
+	var seasonReq domain.SeasonRequest
+	if err := c.ShouldBindJSON(&seasonReq); err != nil {
+		c.Error(apperrors.Invalid(err))
+		return
+	}
+
+	season, err := seasonReq.ToSeason()
+	if err != nil {
+		c.Error(apperrors.Invalid(err))
+		return
+	}
+
+	if err := h.repo.Update(context.Background(), id, *season); err != nil {
+		c.Error(err)
+		return
+	}
+
+	season.ID = id
+	c.JSON(http.StatusOK, season.ToSeasonResponse())
+}
+
+func (h *SeasonHandler) Delete(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid season id"))
+		return
+	}
+
+	if err := h.repo.Delete(context.Background(), id); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "season deleted"})
+}
+
+func (h *SeasonHandler) List(c *gin.Context) {
+	seasons, err := h.repo.List(context.Background())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var responses []*domain.SeasonResponse
+	for _, season := range seasons {
+		responses = append(responses, season.ToSeasonResponse())
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+func (h *SeasonHandler) ListByCompetition(c *gin.Context) {
+	competitionIDStr := c.Param("competitionID")
+	competitionID, err := strconv.Atoi(competitionIDStr)
+	if err != nil {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid competition id"))
+		return
+	}
+
+	seasons, err := h.repo.ListByCompetition(context.Background(), competitionID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var responses []*domain.SeasonResponse
+	for _, season := range seasons {
+		responses = append(responses, season.ToSeasonResponse())
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+func (h *SeasonHandler) GetByID(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid season id"))
+		return
+	}
+
+	season, err := h.repo.GetByID(context.Background(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, season.ToSeasonResponse())
+}
+
+func (h *SeasonHandler) Restore(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid season id"))
+		return
+	}
+
+	if err := h.repo.Restore(context.Background(), id); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "season restored"})
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"context"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"football-team-management/internal/usecases"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StandingsHandler struct {
+	service usecases.StandingsService
+}
+
+func NewStandingsHandler(service usecases.StandingsService) *StandingsHandler {
+	return &StandingsHandler{service: service}
+}
+
+func (h *StandingsHandler) Table(c *gin.Context) {
+	filter, err := parseMatchFilter(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	config := usecases.DefaultStandingsConfig()
+	for key, points := range map[string]*int{
+		"points_per_win":  &config.PointsPerWin,
+		"points_per_draw": &config.PointsPerDraw,
+		"points_per_loss": &config.PointsPerLoss,
+	} {
+		value, ok := c.GetQuery(key)
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			c.Error(apperrors.ErrInvalidInput.WithMessage("invalid " + key))
+			return
+		}
+		*points = n
+	}
+
+	if value, ok := c.GetQuery("tiebreakers"); ok {
+		config.Tiebreakers = nil
+		for _, name := range strings.Split(value, ",") {
+			tiebreaker := domain.Tiebreaker(strings.TrimSpace(name))
+			if tiebreaker == "" {
+				continue
+			}
+			if !domain.ValidTiebreaker(tiebreaker) {
+				c.Error(apperrors.ErrInvalidInput.WithMessage("invalid tiebreaker " + string(tiebreaker)))
+				return
+			}
+			config.Tiebreakers = append(config.Tiebreakers, tiebreaker)
+		}
+	}
+
+	standings, err := h.service.Table(context.Background(), filter, config)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, standings)
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	apperrors "football-team-management/internal/pkg/errors"
+	"football-team-management/internal/usecases"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StatsHandler struct {
+	service usecases.StatsService
+}
+
+func NewStatsHandler(service usecases.StatsService) *StatsHandler {
+	return &StatsHandler{service: service}
+}
+
+func (h *StatsHandler) TopScorers(c *gin.Context) {
+	filter, err := parseMatchFilter(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	limit, err := optionalIntQuery(c, "limit")
+	if err != nil {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid limit"))
+		return
+	}
+	if limit == nil {
+		limit = new(int)
+	}
+
+	scorers, err := h.service.TopScorers(context.Background(), filter, *limit)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, scorers)
+}
+
+func (h *StatsHandler) PlayerStats(c *gin.Context) {
+	playerKey := c.Param("player")
+
+	filter, err := parseMatchFilter(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	stats, err := h.service.PlayerStats(context.Background(), playerKey, filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, stats)
+}
+
+func (h *StatsHandler) TeamGoals(c *gin.Context) {
+	filter, err := parseMatchFilter(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	teamGoals, err := h.service.TeamGoals(context.Background(), filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, teamGoals)
+}
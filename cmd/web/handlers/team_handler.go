@@ -5,6 +5,8 @@ import (
 	"football-team-management/internal/domain"
 	"football-team-management/internal/usecases"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -19,54 +21,99 @@ func NewTeamHandler(repo usecases.TeamRepository) *TeamHandler {
 
 func (h *TeamHandler) Register(c *gin.Context) {
 	var team domain.Team
-	if err := c.ShouldBindJSON(&team); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &team); err != nil {
+		c.Error(err)
 		return
 	}
-	if err := h.repo.Register(context.Background(), team); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := team.Validate(time.Now()); err != nil {
+		c.Error(invalidFields(err))
 		return
 	}
-	c.JSON(http.StatusCreated, team)
+	id, err := h.repo.Register(context.Background(), team)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Respond with the ID and slug assigned on registration
+	registered, err := h.repo.GetByKey(context.Background(), strconv.Itoa(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, registered)
 }
 
 func (h *TeamHandler) Update(c *gin.Context) {
-	name := c.Param("name")
+	key := c.Param("team")
 	var team domain.Team
-	if err := c.ShouldBindJSON(&team); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := bindJSON(c, &team); err != nil {
+		c.Error(err)
 		return
 	}
-	if err := h.repo.Update(context.Background(), name, team); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	if err := team.Validate(time.Now()); err != nil {
+		c.Error(invalidFields(err))
 		return
 	}
-	c.JSON(http.StatusOK, team)
+	if err := h.repo.Update(context.Background(), key, team); err != nil {
+		c.Error(err)
+		return
+	}
+
+	updated, err := h.repo.GetByKey(context.Background(), key)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, updated)
 }
 
 func (h *TeamHandler) Delete(c *gin.Context) {
-	name := c.Param("name")
-	if err := h.repo.Delete(context.Background(), name); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	key := c.Param("team")
+	if err := h.repo.Delete(context.Background(), key); err != nil {
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "team deleted"})
 }
 
+// List returns a page of the teams, only those in a city with ?city=. Admins may list deleted teams too
+// with ?include_deleted=true, or only those with ?only_deleted=true
 func (h *TeamHandler) List(c *gin.Context) {
-	teams, err := h.repo.List(context.Background())
+	page, err := parsePage(c, domain.TeamSortFields)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
+		return
+	}
+	deleted, err := parseDeleted(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	teams, err := h.repo.Search(context.Background(), domain.TeamQuery{City: c.Query("city"), Deleted: deleted, PageRequest: page})
+	if err != nil {
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, teams)
 }
 
 func (h *TeamHandler) Restore(c *gin.Context) {
-	name := c.Param("name")
-	if err := h.repo.Restore(context.Background(), name); err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	key := c.Param("team")
+	if err := h.repo.Restore(context.Background(), key); err != nil {
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "team restored"})
 }
+
+func (h *TeamHandler) GetByKey(c *gin.Context) {
+	key := c.Param("team")
+	team, err := h.repo.GetByKey(context.Background(), key)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, team)
+}
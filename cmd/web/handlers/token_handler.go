@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	apperrors "football-team-management/internal/pkg/errors"
+	"football-team-management/internal/usecases"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type TokenHandler struct {
+	authService usecases.AuthService
+}
+
+func NewTokenHandler(authService usecases.AuthService) *TokenHandler {
+	return &TokenHandler{authService: authService}
+}
+
+func (h *TokenHandler) Refresh(c *gin.Context) {
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(apperrors.Invalid(err))
+		return
+	}
+
+	tokens, err := h.authService.RefreshToken(req.RefreshToken)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+func (h *TokenHandler) Logout(c *gin.Context) {
+	claims := claimsFromContext(c)
+	if claims == nil {
+		c.Error(apperrors.ErrUnauthorized.WithMessage("Invalid token"))
+		return
+	}
+
+	if err := h.authService.Logout(claims); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "logged out"})
+}
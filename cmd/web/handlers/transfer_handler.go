@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"context"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"football-team-management/internal/usecases"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TransferHandler struct {
+	repo       usecases.TransferRepository
+	windowRepo usecases.TransferWindowRepository
+}
+
+func NewTransferHandler(repo usecases.TransferRepository, windowRepo usecases.TransferWindowRepository) *TransferHandler {
+	return &TransferHandler{repo: repo, windowRepo: windowRepo}
+}
+
+func (h *TransferHandler) Register(c *gin.Context) {
+	var transferReq domain.TransferRequest
+	if err := c.ShouldBindJSON(&transferReq); err != nil {
+		c.Error(apperrors.Invalid(err))
+		return
+	}
+
+	transfer, err := transferReq.ToTransfer()
+	if err != nil {
+		c.Error(apperrors.Invalid(err))
+		return
+	}
+
+	registered, err := h.repo.Register(context.Background(), *transfer)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, registered.ToTransferResponse())
+}
+
+// List returns all transfers, or those of one team with ?team= holding its ID or slug
+func (h *TransferHandler) List(c *gin.Context) {
+	var transfers []domain.Transfer
+	var err error
+	if team := c.Query("team"); team != "" {
+		transfers, err = h.repo.ListByTeam(context.Background(), team)
+	} else {
+		transfers, err = h.repo.List(context.Background())
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var responses []*domain.TransferResponse
+	for _, transfer := range transfers {
+		responses = append(responses, transfer.ToTransferResponse())
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+func (h *TransferHandler) GetByID(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid transfer id"))
+		return
+	}
+
+	transfer, err := h.repo.GetByID(context.Background(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, transfer.ToTransferResponse())
+}
+
+// Contracts returns the contract history of a player
+func (h *TransferHandler) Contracts(c *gin.Context) {
+	key := c.Param("player")
+	contracts, err := h.repo.ListContracts(context.Background(), key)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	responses := []*domain.ContractResponse{}
+	for _, contract := range contracts {
+		responses = append(responses, contract.ToContractResponse())
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+func (h *TransferHandler) RegisterWindow(c *gin.Context) {
+	var windowReq domain.TransferWindowRequest
+	if err := c.ShouldBindJSON(&windowReq); err != nil {
+		c.Error(apperrors.Invalid(err))
+		return
+	}
+
+	window, err := windowReq.ToTransferWindow()
+	if err != nil {
+		c.Error(apperrors.Invalid(err))
+		return
+	}
+
+	id, err := h.windowRepo.Register(context.Background(), *window)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	window.ID = id
+	c.JSON(http.StatusCreated, window.ToTransferWindowResponse())
+}
+
+func (h *TransferHandler) DeleteWindow(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid transfer window id"))
+		return
+	}
+
+	if err := h.windowRepo.Delete(context.Background(), id); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "transfer window deleted"})
+}
+
+// ListWindows returns all transfer windows, or those of one season with ?season_id=
+func (h *TransferHandler) ListWindows(c *gin.Context) {
+	seasonID, err := optionalIntQuery(c, "season_id")
+	if err != nil {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid season id"))
+		return
+	}
+
+	windows, err := h.windowRepo.List(context.Background(), seasonID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var responses []*domain.TransferWindowResponse
+	for _, window := range windows {
+		responses = append(responses, window.ToTransferWindowResponse())
+	}
+	c.JSON(http.StatusOK, responses)
+}
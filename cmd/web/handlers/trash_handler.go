@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"football-team-management/internal/usecases"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrashHandler struct {
+	repo usecases.TrashRepository
+}
+
+func NewTrashHandler(repo usecases.TrashRepository) *TrashHandler {
+	return &TrashHandler{repo: repo}
+}
+
+// List returns a page of the soft-deleted teams, players, matches and results, only those of one kind with ?type=
+func (h *TrashHandler) List(c *gin.Context) {
+	page, err := parsePage(c, domain.TrashSortFields)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	query := domain.TrashQuery{Type: c.Query("type"), PageRequest: page}
+	if query.Type != "" && !domain.ValidTrashType(query.Type) {
+		c.Error(invalidTrashType(query.Type))
+		return
+	}
+
+	items, err := h.repo.List(context.Background(), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, items)
+}
+
+// Purge permanently removes a soft-deleted record, it cannot be restored afterwards
+func (h *TrashHandler) Purge(c *gin.Context) {
+	itemType := c.Param("type")
+	if !domain.ValidTrashType(itemType) {
+		c.Error(invalidTrashType(itemType))
+		return
+	}
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid id"))
+		return
+	}
+
+	if err := h.repo.Purge(context.Background(), itemType, id); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": itemType + " purged"})
+}
+
+func invalidTrashType(itemType string) error {
+	return apperrors.ErrInvalidInput.WithMessage("invalid type " + itemType + ", use team, player, match or match_result")
+}
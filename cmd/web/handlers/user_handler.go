@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"context"
+	"football-team-management/internal/domain/user"
+	apperrors "football-team-management/internal/pkg/errors"
+	"football-team-management/internal/usecases"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserHandler struct {
+	repo        usecases.UserRepository
+	authService usecases.AuthService
+}
+
+func NewUserHandler(repo usecases.UserRepository, authService usecases.AuthService) *UserHandler {
+	return &UserHandler{repo: repo, authService: authService}
+}
+
+func (h *UserHandler) Register(c *gin.Context) {
+	var userReq user.UserRequest
+	if err := c.ShouldBindJSON(&userReq); err != nil {
+		c.Error(apperrors.Invalid(err))
+		return
+	}
+
+	if !user.ValidRole(userReq.Role) {
+		c.Error(apperrors.ErrInvalidInput.WithMessage("invalid role"))
+		return
+	}
+
+	hash, err := usecases.HashPassword(userReq.Password)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	u := user.User{
+		Username: userReq.Username,
+		Password: hash,
+		Role:     userReq.Role,
+		Teams:    userReq.Teams,
+	}
+	if err := h.repo.Register(context.Background(), u); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, u.ToUserResponse())
+}
+
+func (h *UserHandler) List(c *gin.Context) {
+	users, err := h.repo.List(context.Background())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var responses []*user.UserResponse
+	for _, u := range users {
+		responses = append(responses, u.ToUserResponse())
+	}
+	c.JSON(http.StatusOK, responses)
+}
+
+func (h *UserHandler) SetTeams(c *gin.Context) {
+	username := c.Param("username")
+	var teamsReq user.UserTeamsRequest
+	if err := c.ShouldBindJSON(&teamsReq); err != nil {
+		c.Error(apperrors.Invalid(err))
+		return
+	}
+
+	if err := h.repo.SetTeams(context.Background(), username, teamsReq.Teams); err != nil {
+		c.Error(err)
+		return
+	}
+	// Existing tokens still carry the old team list, so force a fresh login
+	if err := h.authService.RevokeUserSessions(username); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "user teams updated"})
+}
+
+func (h *UserHandler) Disable(c *gin.Context) {
+	username := c.Param("username")
+	if err := h.repo.SetDisabled(context.Background(), username, true); err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.authService.RevokeUserSessions(username); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "user disabled"})
+}
+
+func (h *UserHandler) Enable(c *gin.Context) {
+	username := c.Param("username")
+	if err := h.repo.SetDisabled(context.Background(), username, false); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "user enabled"})
+}
+
+func (h *UserHandler) Delete(c *gin.Context) {
+	username := c.Param("username")
+	if err := h.repo.Delete(context.Background(), username); err != nil {
+		c.Error(err)
+		return
+	}
+	if err := h.authService.RevokeUserSessions(username); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "user deleted"})
+}
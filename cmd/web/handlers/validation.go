@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// bindJSON decodes the request body into obj. A value of the wrong type is reported as a field error,
+// anything else that keeps the body from being read as INVALID_INPUT
+func bindJSON(c *gin.Context, obj any) error {
+	err := c.ShouldBindJSON(obj)
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return invalidFields(&domain.ValidationError{Fields: []domain.FieldError{
+			{Field: typeErr.Field, Message: "must be of type " + typeErr.Type.String()},
+		}})
+	}
+	return apperrors.Invalid(err)
+}
+
+// invalidFields turns a *domain.ValidationError into a VALIDATION_FAILED error listing the rejected
+// fields as details. Other errors are treated like apperrors.Invalid does
+func invalidFields(err error) error {
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		return apperrors.ErrValidation.WithMessage(err.Error()).WithDetails(validationErr.Fields).Wrap(err)
+	}
+	return apperrors.Invalid(err)
+}
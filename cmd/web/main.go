@@ -2,8 +2,10 @@ package main
 
 import (
 	"context"
+	"errors"
 	"football-team-management/cmd/web/handlers"
 	"football-team-management/cmd/web/middleware"
+	apperrors "football-team-management/internal/pkg/errors"
 	"football-team-management/internal/usecases"
 	"log"
 	"os"
@@ -13,90 +15,242 @@ import (
 )
 
 func main() {
-	getUser := usecases.NewGetUserImpl()
+	// STORAGE=memory runs the API without a database, everything is lost when the server stops
+	var repos *usecases.Repositories
+	switch storage := os.Getenv("STORAGE"); storage {
+	case "", "postgres":
+		dbURL := os.Getenv("DATABASE_URL")
+		if dbURL == "" {
+			log.Fatal("DATABASE_URL environment variable is required")
+		}
+
+		pool, err := pgxpool.New(context.Background(), dbURL)
+		if err != nil {
+			log.Fatalf("Failed to create connection pool: %v", err)
+		}
+
+		if err := pool.Ping(context.Background()); err != nil {
+			log.Fatalf("Failed to ping database: %v", err)
+		}
+
+		// `migrate up|down|status` manages the schema and exits instead of starting the server
+		if len(os.Args) > 1 && os.Args[1] == "migrate" {
+			runMigrate(pool, os.Args[2:])
+			return
+		}
+		if os.Getenv("MIGRATE_ON_STARTUP") == "true" {
+			migrateOnStartup(pool)
+		}
+		repos = usecases.NewPostgresRepositories(pool)
+
+	case "memory":
+		if len(os.Args) > 1 && os.Args[1] == "migrate" {
+			log.Fatal("migrate needs STORAGE=postgres")
+		}
+		log.Println("Using in-memory storage, data is lost when the server stops")
+		repos = usecases.NewMemoryRepositories()
+
+	default:
+		log.Fatalf("Unknown STORAGE %q. Use postgres or memory", storage)
+	}
+
+	userRepo := repos.Users
+	sessionRepo := repos.Sessions
+
+	// Seed the first admin account so a fresh database can be logged in to
+	adminUsername := os.Getenv("ADMIN_USERNAME")
+	adminPassword := os.Getenv("ADMIN_PASSWORD")
+	if adminUsername != "" && adminPassword != "" {
+		err := usecases.BootstrapAdmin(context.Background(), userRepo, adminUsername, adminPassword)
+		if errors.Is(err, apperrors.ErrUserAlreadyExists) {
+			log.Printf("Skipping admin bootstrap: %v. Set ADMIN_USERNAME to another username to create a new admin", err)
+		} else if err != nil {
+			log.Fatalf("Failed to bootstrap admin user: %v", err)
+		}
+	}
+
+	getUser := usecases.NewGetUserImpl(userRepo)
 
 	// Initialize auth service with JWT secret
 	jwtSecret := os.Getenv("JWT_SECRET")
 	if jwtSecret == "" {
 		jwtSecret = "unset-secret"
 	}
-	authService := usecases.NewAuthService(jwtSecret, getUser)
+	authService := usecases.NewAuthService(jwtSecret, getUser, sessionRepo)
 
 	pingHandler := handlers.NewPingHandlerImpl()
 	userLoginHandler := handlers.NewLoginHandlerImpl(authService)
+	tokenHandler := handlers.NewTokenHandler(authService)
+	userHandler := handlers.NewUserHandler(userRepo, authService)
 
-	var teamRepo usecases.TeamRepository
-	dbURL := os.Getenv("DATABASE_URL")
-	if dbURL == "" {
-		log.Fatal("DATABASE_URL environment variable is required")
-	}
+	teamRepo := repos.Teams
+	teamHandler := handlers.NewTeamHandler(teamRepo)
 
-	pool, err := pgxpool.New(context.Background(), dbURL)
-	if err != nil {
-		log.Fatalf("Failed to create connection pool: %v", err)
-	}
+	playerRepo := repos.Players
+	playerHandler := handlers.NewPlayerHandler(playerRepo)
 
-	if err := pool.Ping(context.Background()); err != nil {
-		log.Fatalf("Failed to ping database: %v", err)
-	}
+	transferRepo := repos.Transfers
+	transferWindowRepo := repos.TransferWindows
+	transferHandler := handlers.NewTransferHandler(transferRepo, transferWindowRepo)
 
-	teamRepo = usecases.NewPostgresTeamRepo(pool)
-	teamHandler := handlers.NewTeamHandler(teamRepo)
+	competitionRepo := repos.Competitions
+	competitionHandler := handlers.NewCompetitionHandler(competitionRepo)
 
-	playerRepo := usecases.NewPostgresPlayerRepo(pool)
-	playerHandler := handlers.NewPlayerHandler(playerRepo)
+	seasonRepo := repos.Seasons
+	seasonHandler := handlers.NewSeasonHandler(seasonRepo)
+
+	matchRepo := repos.Matches
+
+	fixtureService := usecases.NewFixtureService(teamRepo, matchRepo)
+	fixtureHandler := handlers.NewFixtureHandler(fixtureService)
+
+	matchResultRepo := repos.MatchResults
+
+	bracketRepo := repos.Brackets
+	bracketService := usecases.NewBracketService(bracketRepo, teamRepo, matchRepo, matchResultRepo)
+	bracketHandler := handlers.NewBracketHandler(bracketService)
+
+	ratingService := usecases.NewRatingService(repos.Ratings, teamRepo, matchRepo, matchResultRepo, usecases.DefaultRatingConfig())
+	ratingHandler := handlers.NewRatingHandler(ratingService)
+
+	// Cup brackets advance winners and ratings are replayed whenever a result changes or a match finishes, is moved, deleted or restored
+	observedMatchRepo := usecases.NewObservedMatchRepo(matchRepo, bracketService, ratingService)
+	matchHandler := handlers.NewMatchHandler(observedMatchRepo)
+	observedMatchResultRepo := usecases.NewObservedMatchResultRepo(matchResultRepo, bracketService, ratingService)
+	matchResultHandler := handlers.NewMatchResultHandler(observedMatchResultRepo, matchRepo)
+	matchEventRepo := usecases.NewObservedMatchEventRepo(repos.MatchEvents, bracketService, ratingService)
+	matchEventHandler := handlers.NewMatchEventHandler(matchEventRepo, matchRepo)
+
+	standingsService := usecases.NewStandingsService(matchRepo, matchResultRepo)
+	standingsHandler := handlers.NewStandingsHandler(standingsService)
+
+	statsService := usecases.NewStatsService(matchRepo, matchEventRepo, playerRepo)
+	statsHandler := handlers.NewStatsHandler(statsService)
+
+	headToHeadService := usecases.NewHeadToHeadService(teamRepo, matchResultRepo)
+	headToHeadHandler := handlers.NewHeadToHeadHandler(headToHeadService)
 
-	matchRepo := usecases.NewPostgresMatchRepo(pool)
-	matchHandler := handlers.NewMatchHandler(matchRepo)
+	formService := usecases.NewFormService(teamRepo, matchRepo, matchResultRepo)
+	formHandler := handlers.NewFormHandler(formService)
 
-	matchResultRepo := usecases.NewPostgresMatchResultRepo(pool)
-	matchResultHandler := handlers.NewMatchResultHandler(matchResultRepo)
+	trashHandler := handlers.NewTrashHandler(repos.Trash)
+	startTrashRetention(repos.Trash)
 
 	router := gin.Default()
+	router.Use(middleware.ErrorHandler())
 	api := router.Group("/api")
 	{
 		api.GET("/ping", pingHandler.Handle)
 		v1 := api.Group("/v1")
 		{
 			v1.POST("/login", userLoginHandler.Handle)
+			v1.POST("/token/refresh", tokenHandler.Refresh)
 
 			// Protected routes - require JWT authentication
 			protected := v1.Group("/")
 			protected.Use(middleware.JWTAuth(authService))
 			{
+				protected.POST("/logout", tokenHandler.Logout)
+
+				// User management endpoints - require admin role
+				protected.POST("/users", middleware.RequireRole("admin"), userHandler.Register)
+				protected.GET("/users", middleware.RequireRole("admin"), userHandler.List)
+				protected.PUT("/users/:username/teams", middleware.RequireRole("admin"), userHandler.SetTeams)
+				protected.PATCH("/users/:username/disable", middleware.RequireRole("admin"), userHandler.Disable)
+				protected.PATCH("/users/:username/enable", middleware.RequireRole("admin"), userHandler.Enable)
+				protected.DELETE("/users/:username", middleware.RequireRole("admin"), userHandler.Delete)
+
 				// Team management endpoints - require admin role
 				protected.POST("/teams", middleware.RequireRole("admin"), teamHandler.Register)
-				protected.PUT("/teams/:name", middleware.RequireRole("admin"), teamHandler.Update)
-				protected.DELETE("/teams/:name", middleware.RequireRole("admin"), teamHandler.Delete)
+				protected.PUT("/teams/:team", middleware.RequireRole("admin"), teamHandler.Update)
+				protected.DELETE("/teams/:team", middleware.RequireRole("admin"), teamHandler.Delete)
 				protected.GET("/teams", teamHandler.List)
-				protected.PATCH("/teams/:name/restore", middleware.RequireRole("admin"), teamHandler.Restore)
+				protected.PATCH("/teams/:team/restore", middleware.RequireRole("admin"), teamHandler.Restore)
+				protected.GET("/team/:team", teamHandler.GetByKey)
 
-				// Player management endpoints - require admin role
-				protected.POST("/players", middleware.RequireRole("admin"), playerHandler.Register)
-				protected.PUT("/players/:playerName", middleware.RequireRole("admin"), playerHandler.Update)
-				protected.DELETE("/players/:playerName", middleware.RequireRole("admin"), playerHandler.Delete)
+				// Player management endpoints - require admin role, team managers may manage their own squad
+				protected.POST("/players", middleware.RequireRole("admin", "team_manager"), playerHandler.Register)
+				protected.PUT("/players/:player", middleware.RequireRole("admin", "team_manager"), playerHandler.Update)
+				protected.DELETE("/players/:player", middleware.RequireRole("admin", "team_manager"), playerHandler.Delete)
 				protected.GET("/players", playerHandler.List)
-				protected.GET("/players/team/:teamName", playerHandler.ListByTeam)
-				protected.PATCH("/players/:playerName/restore", middleware.RequireRole("admin"), playerHandler.Restore)
-				protected.GET("/player/:playerName", playerHandler.GetByName)
+				protected.GET("/players/team/:team", playerHandler.ListByTeam)
+				protected.PATCH("/players/:player/restore", middleware.RequireRole("admin"), playerHandler.Restore)
+				protected.GET("/player/:player", playerHandler.GetByKey)
+
+				// Transfer endpoints - moving players between teams and opening transfer windows require admin role
+				protected.POST("/transfers", middleware.RequireRole("admin"), transferHandler.Register)
+				protected.GET("/transfers", transferHandler.List)
+				protected.GET("/transfer/:id", transferHandler.GetByID)
+				protected.GET("/player/:player/contracts", transferHandler.Contracts)
+				protected.POST("/transfer-windows", middleware.RequireRole("admin"), transferHandler.RegisterWindow)
+				protected.DELETE("/transfer-windows/:id", middleware.RequireRole("admin"), transferHandler.DeleteWindow)
+				protected.GET("/transfer-windows", transferHandler.ListWindows)
+
+				// Competition management endpoints - require admin role
+				protected.POST("/competitions", middleware.RequireRole("admin"), competitionHandler.Register)
+				protected.PUT("/competitions/:id", middleware.RequireRole("admin"), competitionHandler.Update)
+				protected.DELETE("/competitions/:id", middleware.RequireRole("admin"), competitionHandler.Delete)
+				protected.GET("/competitions", competitionHandler.List)
+				protected.PATCH("/competitions/:id/restore", middleware.RequireRole("admin"), competitionHandler.Restore)
+				protected.GET("/competition/:id", competitionHandler.GetByID)
+
+				// Season management endpoints - require admin role
+				protected.POST("/seasons", middleware.RequireRole("admin"), seasonHandler.Register)
+				protected.PUT("/seasons/:id", middleware.RequireRole("admin"), seasonHandler.Update)
+				protected.DELETE("/seasons/:id", middleware.RequireRole("admin"), seasonHandler.Delete)
+				protected.GET("/seasons", seasonHandler.List)
+				protected.GET("/seasons/competition/:competitionID", seasonHandler.ListByCompetition)
+				protected.PATCH("/seasons/:id/restore", middleware.RequireRole("admin"), seasonHandler.Restore)
+				protected.GET("/season/:id", seasonHandler.GetByID)
 
 				// Match management endpoints - require admin role
 				protected.POST("/matches", middleware.RequireRole("admin"), matchHandler.Register)
 				protected.PUT("/matches/:id", middleware.RequireRole("admin"), matchHandler.Update)
 				protected.DELETE("/matches/:id", middleware.RequireRole("admin"), matchHandler.Delete)
 				protected.GET("/matches", matchHandler.List)
-				protected.GET("/matches/team/:teamName", matchHandler.ListByTeam)
+				protected.GET("/matches/team/:team", matchHandler.ListByTeam)
 				protected.PATCH("/matches/:id/restore", middleware.RequireRole("admin"), matchHandler.Restore)
+				protected.PATCH("/matches/:id/status", middleware.RequireRole("admin", "team_manager"), matchHandler.UpdateStatus)
 				protected.GET("/match/:id", matchHandler.GetByID)
+				protected.POST("/fixtures/generate", middleware.RequireRole("admin"), fixtureHandler.Generate)
+
+				// Knockout bracket endpoints - drawing a bracket requires admin role
+				protected.POST("/brackets", middleware.RequireRole("admin"), bracketHandler.Register)
+				protected.GET("/brackets", bracketHandler.List)
+				protected.GET("/bracket/:id", bracketHandler.GetByID)
 
-				// Match result management endpoints - require admin role
-				protected.POST("/match-results", middleware.RequireRole("admin"), matchResultHandler.Register)
-				protected.PUT("/match-results/:id", middleware.RequireRole("admin"), matchResultHandler.Update)
+				// Match result management endpoints - require admin role, team managers may submit results for their matches
+				protected.POST("/match-results", middleware.RequireRole("admin", "team_manager"), matchResultHandler.Register)
+				protected.PUT("/match-results/:id", middleware.RequireRole("admin", "team_manager"), matchResultHandler.Update)
 				protected.DELETE("/match-results/:id", middleware.RequireRole("admin"), matchResultHandler.Delete)
 				protected.GET("/match-results", matchResultHandler.List)
 				protected.GET("/match-results/match/:matchID", matchResultHandler.GetByMatchID)
 				protected.PATCH("/match-results/:id/restore", middleware.RequireRole("admin"), matchResultHandler.Restore)
 				protected.GET("/match-result/:id", matchResultHandler.GetByID)
+
+				// Match event endpoints - require admin role, team managers may record events of their matches
+				protected.POST("/match-events", middleware.RequireRole("admin", "team_manager"), matchEventHandler.Register)
+				protected.DELETE("/match-events/:id", middleware.RequireRole("admin", "team_manager"), matchEventHandler.Delete)
+				protected.GET("/match/:id/events", matchEventHandler.Timeline)
+
+				// League table endpoints
+				protected.GET("/standings", standingsHandler.Table)
+
+				// Statistics endpoints
+				protected.GET("/stats/top-scorers", statsHandler.TopScorers)
+				protected.GET("/stats/team-goals", statsHandler.TeamGoals)
+				protected.GET("/player/:player/stats", statsHandler.PlayerStats)
+				protected.GET("/teams/:team/head-to-head/:opponent", headToHeadHandler.Compare)
+				protected.GET("/teams/:team/form", formHandler.Form)
+
+				// Team rating endpoints - a full recalculation requires admin role
+				protected.GET("/ratings", ratingHandler.List)
+				protected.POST("/ratings/recalculate", middleware.RequireRole("admin"), ratingHandler.Recalculate)
+				protected.GET("/teams/:team/ratings", ratingHandler.History)
+
+				// Trash endpoints - require admin role, purging cannot be undone
+				protected.GET("/trash", middleware.RequireRole("admin"), trashHandler.List)
+				protected.DELETE("/trash/:type/:id", middleware.RequireRole("admin"), trashHandler.Purge)
 			}
 		}
 	}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"errors"
+	apperrors "football-team-management/internal/pkg/errors"
+	"log"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorHandler renders the last error attached with c.Error as {code, message, details} with the status of
+// its AppError. Any other error is logged and reported as an internal server error, so e.g. a database
+// outage is never mistaken for a missing record or leaks into the response
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		err := c.Errors.Last().Err
+
+		var appErr *apperrors.AppError
+		if !errors.As(err, &appErr) {
+			log.Printf("%s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+			appErr = apperrors.ErrInternalServer
+		} else if appErr.Err != nil && appErr.HTTPStatus >= 500 {
+			log.Printf("%s %s: %v", c.Request.Method, c.Request.URL.Path, appErr.Err)
+		}
+		c.JSON(appErr.HTTPStatus, appErr)
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"errors"
+	apperrors "football-team-management/internal/pkg/errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveError(err error) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.Use(ErrorHandler())
+	router.GET("/", func(c *gin.Context) {
+		c.Error(err)
+	})
+
+	response := httptest.NewRecorder()
+	request, _ := http.NewRequest(http.MethodGet, "/", nil)
+	router.ServeHTTP(response, request)
+	return response
+}
+
+func TestErrorHandler(t *testing.T) {
+	t.Run("AppErrors are rendered with their status", func(t *testing.T) {
+		response := serveError(apperrors.ErrJerseyNumberTaken.WithDetails("players_team_id_jersey_number_key"))
+
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.JSONEq(t, `{"code":"JERSEY_NUMBER_TAKEN","message":"jersey number already taken in this team","details":"players_team_id_jersey_number_key"}`, response.Body.String())
+	})
+
+	t.Run("Wrapped internal errors keep their cause out of the response", func(t *testing.T) {
+		response := serveError(apperrors.WrapError(errors.New(`ERROR: relation "teams" does not exist (SQLSTATE 42P01)`), "failed to list teams"))
+
+		assert.Equal(t, http.StatusInternalServerError, response.Code)
+		assert.JSONEq(t, `{"code":"WRAPPED_ERROR","message":"failed to list teams"}`, response.Body.String())
+	})
+
+	t.Run("Other errors are hidden behind an internal server error", func(t *testing.T) {
+		response := serveError(errors.New("connection refused"))
+
+		assert.Equal(t, http.StatusInternalServerError, response.Code)
+		assert.JSONEq(t, `{"code":"INTERNAL_SERVER_ERROR","message":"internal server error"}`, response.Body.String())
+	})
+
+	t.Run("The underlying error never reaches the client", func(t *testing.T) {
+		response := serveError(apperrors.ErrDuplicate.Wrap(errors.New("duplicate key value violates unique constraint")))
+
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.JSONEq(t, `{"code":"DUPLICATE_RECORD","message":"record already exists"}`, response.Body.String())
+	})
+}
@@ -1,9 +1,10 @@
 package middleware
 
 import (
-	"net/http"
+	"errors"
 	"strings"
 
+	apperrors "football-team-management/internal/pkg/errors"
 	"football-team-management/internal/usecases"
 
 	"github.com/gin-gonic/gin"
@@ -13,14 +14,14 @@ func JWTAuth(authService usecases.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
+			c.Error(apperrors.ErrUnauthorized.WithMessage("Authorization header required"))
 			c.Abort()
 			return
 		}
 
 		// Check if the header starts with "Bearer "
 		if !strings.HasPrefix(authHeader, "Bearer ") {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
+			c.Error(apperrors.ErrUnauthorized.WithMessage("Invalid authorization header format"))
 			c.Abort()
 			return
 		}
@@ -28,10 +29,16 @@ func JWTAuth(authService usecases.AuthService) gin.HandlerFunc {
 		// Extract the token
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 
-		// Validate the token
+		// Validate the token. Only a bad token or session is the client's fault, a failing session lookup
+		// is passed on as is, so an outage shows as a server error instead of logging everyone out
 		claims, err := authService.ValidateToken(tokenString)
+		if errors.Is(err, apperrors.ErrUnauthorized) {
+			c.Error(apperrors.ErrUnauthorized.WithMessage("Invalid token").Wrap(err))
+			c.Abort()
+			return
+		}
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			c.Error(err)
 			c.Abort()
 			return
 		}
@@ -39,25 +46,29 @@ func JWTAuth(authService usecases.AuthService) gin.HandlerFunc {
 		// Set user info in context
 		c.Set("username", claims.Username)
 		c.Set("role", claims.Role)
+		c.Set("claims", claims)
 		c.Next()
 	}
 }
 
-func RequireRole(requiredRole string) gin.HandlerFunc {
+// RequireRole only lets requests through whose token carries one of the allowed roles
+func RequireRole(allowedRoles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		role, exists := c.Get("role")
 		if !exists {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Role not found in context"})
+			c.Error(apperrors.ErrForbidden.WithMessage("Role not found in context"))
 			c.Abort()
 			return
 		}
 
-		if role != requiredRole {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
-			c.Abort()
-			return
+		for _, allowedRole := range allowedRoles {
+			if role == allowedRole {
+				c.Next()
+				return
+			}
 		}
 
-		c.Next()
+		c.Error(apperrors.ErrForbidden.WithMessage("Insufficient permissions"))
+		c.Abort()
 	}
 }
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"errors"
+	"football-team-management/internal/domain/user"
+	apperrors "football-team-management/internal/pkg/errors"
+	"football-team-management/internal/usecases"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// validatingAuthService answers every ValidateToken with err
+type validatingAuthService struct {
+	usecases.AuthService
+	err error
+}
+
+func (s validatingAuthService) ValidateToken(tokenString string) (*user.Claims, error) {
+	return nil, s.err
+}
+
+func serveWithToken(err error) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.Use(ErrorHandler())
+	router.GET("/", JWTAuth(validatingAuthService{err: err}), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	response := httptest.NewRecorder()
+	request, _ := http.NewRequest(http.MethodGet, "/", nil)
+	request.Header.Set("Authorization", "Bearer token")
+	router.ServeHTTP(response, request)
+	return response
+}
+
+func TestJWTAuth(t *testing.T) {
+	t.Run("Bad tokens are unauthorized", func(t *testing.T) {
+		response := serveWithToken(apperrors.ErrUnauthorized.WithMessage("token has been revoked"))
+
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+		assert.JSONEq(t, `{"code":"UNAUTHORIZED","message":"Invalid token"}`, response.Body.String())
+	})
+
+	t.Run("A failing session lookup is a server error", func(t *testing.T) {
+		response := serveWithToken(errors.New("connection refused"))
+
+		assert.Equal(t, http.StatusInternalServerError, response.Code)
+		assert.JSONEq(t, `{"code":"INTERNAL_SERVER_ERROR","message":"internal server error"}`, response.Body.String())
+	})
+}
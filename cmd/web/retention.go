@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"football-team-management/internal/usecases"
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// retentionInterval is how often the retention job looks for expired records
+const retentionInterval = 24 * time.Hour
+
+// startTrashRetention purges records soft-deleted more than TRASH_RETENTION_DAYS days ago, once on
+// startup and then every retentionInterval. Nothing is purged automatically if the variable is not set
+func startTrashRetention(trash usecases.TrashRepository) {
+	value := os.Getenv("TRASH_RETENTION_DAYS")
+	if value == "" {
+		return
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 1 {
+		log.Fatalf("TRASH_RETENTION_DAYS must be a positive number of days, got %q", value)
+	}
+
+	go func() {
+		for {
+			cutoff := time.Now().AddDate(0, 0, -days)
+			report, err := trash.PurgeDeletedBefore(context.Background(), cutoff)
+			if err != nil {
+				log.Printf("Trash retention failed: %v", err)
+			} else {
+				log.Printf("Trash retention purged %v, kept %v still referred to", report.Purged, report.Kept)
+			}
+			time.Sleep(retentionInterval)
+		}
+	}()
+}
@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Bracket represents a knockout cup draw
+// Fields: name, optional season, size (entrants rounded up to a power of two), schedule of the rounds
+// Ties of round N feed round N+1: the winners of positions 2k and 2k+1 meet at position k
+
+type Bracket struct {
+	ID           int          `json:"id"`
+	Name         string       `json:"name"`
+	SeasonID     *int         `json:"season_id,omitempty"`
+	Size         int          `json:"size"`
+	StartDate    time.Time    `json:"start_date"`
+	IntervalDays int          `json:"interval_days"`
+	KickOffTime  string       `json:"kick_off_time"`
+	Ties         []BracketTie `json:"ties,omitempty"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+	DeletedAt    *time.Time   `json:"deleted_at,omitempty"`
+}
+
+// BracketTie is one pairing of a bracket. A bye tie has a single team that advances without playing
+type BracketTie struct {
+	ID        int     `json:"id"`
+	BracketID int     `json:"bracket_id"`
+	Round     int     `json:"round"`    // 1 is the first round, the last round is the final
+	Position  int     `json:"position"` // 0-based position within the round
+	HomeTeam  *string `json:"home_team,omitempty"`
+	AwayTeam  *string `json:"away_team,omitempty"`
+	HomeSeed  *int    `json:"home_seed,omitempty"`
+	AwaySeed  *int    `json:"away_seed,omitempty"`
+	MatchID   *int    `json:"match_id,omitempty"`
+	Winner    *string `json:"winner,omitempty"`
+	Bye       bool    `json:"bye"`
+}
+
+// Rounds returns the number of rounds needed to get from Size entrants to a single winner
+func (b *Bracket) Rounds() int {
+	rounds := 0
+	for size := b.Size; size > 1; size /= 2 {
+		rounds++
+	}
+	return rounds
+}
+
+// RoundDate returns the date the matches of a round are played on
+func (b *Bracket) RoundDate(round int) time.Time {
+	return b.StartDate.AddDate(0, 0, (round-1)*b.IntervalDays)
+}
+
+// Tie returns the tie at the given round and position, or nil if there is none
+func (b *Bracket) Tie(round, position int) *BracketTie {
+	for i := range b.Ties {
+		if b.Ties[i].Round == round && b.Ties[i].Position == position {
+			return &b.Ties[i]
+		}
+	}
+	return nil
+}
+
+// RoundName returns the usual name of a round, e.g. "Final" or "Round of 16"
+func RoundName(round, rounds int) string {
+	switch rounds - round {
+	case 0:
+		return "Final"
+	case 1:
+		return "Semi-final"
+	case 2:
+		return "Quarter-final"
+	}
+	return fmt.Sprintf("Round of %d", 1<<(rounds-round+1))
+}
+
+// BracketRequest represents the request structure for drawing a bracket
+type BracketRequest struct {
+	Name         string   `json:"name" binding:"required"`
+	Teams        []string `json:"teams" binding:"required"` // In seed order when seeded, best team first
+	Seeded       bool     `json:"seeded"`                   // Otherwise the pairings are drawn at random
+	SeasonID     *int     `json:"season_id,omitempty"`
+	StartDate    string   `json:"start_date" binding:"required"`    // Format: "YYYY-MM-DD", date of the first round
+	IntervalDays int      `json:"interval_days" binding:"required"` // Days between rounds
+	KickOffTime  string   `json:"kick_off_time" binding:"required"` // Format: "HH:MM"
+}
+
+// BracketOptions holds the parsed parameters of a bracket draw
+type BracketOptions struct {
+	Name         string
+	Teams        []string
+	Seeded       bool
+	SeasonID     *int
+	StartDate    time.Time
+	IntervalDays int
+	KickOffTime  string
+}
+
+// ToBracketOptions converts BracketRequest to BracketOptions
+func (br *BracketRequest) ToBracketOptions() (*BracketOptions, error) {
+	startDate, err := time.Parse("2006-01-02", br.StartDate)
+	if err != nil {
+		return nil, errors.New("invalid start date format. Use YYYY-MM-DD")
+	}
+
+	return &BracketOptions{
+		Name:         br.Name,
+		Teams:        br.Teams,
+		Seeded:       br.Seeded,
+		SeasonID:     br.SeasonID,
+		StartDate:    startDate,
+		IntervalDays: br.IntervalDays,
+		KickOffTime:  br.KickOffTime,
+	}, nil
+}
+
+// BracketNode is a tie in the bracket tree, linked to the two ties its teams come from
+type BracketNode struct {
+	BracketTie
+	RoundName string       `json:"round_name"`
+	HomeFrom  *BracketNode `json:"home_from,omitempty"`
+	AwayFrom  *BracketNode `json:"away_from,omitempty"`
+}
+
+// BracketResponse represents the response structure for brackets, with the final at the root of the tree
+type BracketResponse struct {
+	ID           int          `json:"id"`
+	Name         string       `json:"name"`
+	SeasonID     *int         `json:"season_id,omitempty"`
+	Size         int          `json:"size"`
+	Rounds       int          `json:"rounds"`
+	StartDate    string       `json:"start_date"` // Format: "YYYY-MM-DD"
+	IntervalDays int          `json:"interval_days"`
+	KickOffTime  string       `json:"kick_off_time"`
+	Champion     *string      `json:"champion,omitempty"`
+	Final        *BracketNode `json:"final,omitempty"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+// ToBracketResponse converts Bracket domain model to BracketResponse
+func (b *Bracket) ToBracketResponse() *BracketResponse {
+	rounds := b.Rounds()
+	response := &BracketResponse{
+		ID:           b.ID,
+		Name:         b.Name,
+		SeasonID:     b.SeasonID,
+		Size:         b.Size,
+		Rounds:       rounds,
+		StartDate:    b.StartDate.Format("2006-01-02"),
+		IntervalDays: b.IntervalDays,
+		KickOffTime:  b.KickOffTime,
+		CreatedAt:    b.CreatedAt,
+		UpdatedAt:    b.UpdatedAt,
+	}
+
+	var node func(round, position int) *BracketNode
+	node = func(round, position int) *BracketNode {
+		tie := b.Tie(round, position)
+		if tie == nil {
+			return nil
+		}
+		n := &BracketNode{BracketTie: *tie, RoundName: RoundName(round, rounds)}
+		if round > 1 {
+			n.HomeFrom = node(round-1, position*2)
+			n.AwayFrom = node(round-1, position*2+1)
+		}
+		return n
+	}
+	response.Final = node(rounds, 0)
+	if response.Final != nil {
+		response.Champion = response.Final.Winner
+	}
+	return response
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// Competition represents a league or cup that seasons and fixtures belong to
+// Fields: name, type
+// All fields are required for registration
+
+type CompetitionType string
+
+const (
+	CompetitionLeague CompetitionType = "league"
+	CompetitionCup    CompetitionType = "cup"
+)
+
+type Competition struct {
+	ID        int             `json:"id"`
+	Name      string          `json:"name" binding:"required"`
+	Type      CompetitionType `json:"type" binding:"required"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	DeletedAt *time.Time      `json:"deleted_at,omitempty"`
+}
+
+// ValidCompetitionType reports whether t is a supported competition type
+func ValidCompetitionType(t CompetitionType) bool {
+	return t == CompetitionLeague || t == CompetitionCup
+}
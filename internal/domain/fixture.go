@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// FixtureRequest represents the request structure for generating a round-robin schedule
+// Every team plays every other team once, or twice with home and away swapped for a double round robin
+
+type FixtureRequest struct {
+	Teams        []string `json:"teams" binding:"required"`
+	StartDate    string   `json:"start_date" binding:"required"`     // Format: "YYYY-MM-DD", date of the first match day
+	IntervalDays int      `json:"interval_days" binding:"required"`  // Days between consecutive match days
+	KickOffTimes []string `json:"kick_off_times" binding:"required"` // Format: "HH:MM", cycled through within a match day
+	DoubleRound  bool     `json:"double_round"`
+	SeasonID     *int     `json:"season_id,omitempty"`
+	DryRun       bool     `json:"dry_run"` // Return the proposed fixtures without saving them
+}
+
+// FixtureOptions holds the parsed parameters of a fixture generation
+type FixtureOptions struct {
+	Teams        []string
+	StartDate    time.Time
+	IntervalDays int
+	KickOffTimes []string
+	DoubleRound  bool
+	SeasonID     *int
+}
+
+// ToFixtureOptions converts FixtureRequest to FixtureOptions
+func (fr *FixtureRequest) ToFixtureOptions() (*FixtureOptions, error) {
+	startDate, err := time.Parse("2006-01-02", fr.StartDate)
+	if err != nil {
+		return nil, errors.New("invalid start date format. Use YYYY-MM-DD")
+	}
+
+	return &FixtureOptions{
+		Teams:        fr.Teams,
+		StartDate:    startDate,
+		IntervalDays: fr.IntervalDays,
+		KickOffTimes: fr.KickOffTimes,
+		DoubleRound:  fr.DoubleRound,
+		SeasonID:     fr.SeasonID,
+	}, nil
+}
+
+// FixtureRound is one match day of a generated schedule
+type FixtureRound struct {
+	Round   int              `json:"round"`
+	Date    string           `json:"date"` // Format: "YYYY-MM-DD"
+	Matches []*MatchResponse `json:"matches"`
+}
+
+// FixtureResponse represents the response structure for generated schedules
+type FixtureResponse struct {
+	DryRun bool           `json:"dry_run"`
+	Rounds []FixtureRound `json:"rounds"`
+}
+
+// ToFixtureResponse converts generated match days to FixtureResponse
+func ToFixtureResponse(rounds [][]Match, dryRun bool) *FixtureResponse {
+	response := &FixtureResponse{DryRun: dryRun, Rounds: make([]FixtureRound, 0, len(rounds))}
+	for i, matches := range rounds {
+		round := FixtureRound{Round: i + 1}
+		for _, match := range matches {
+			round.Date = match.MatchDate.Format("2006-01-02")
+			round.Matches = append(round.Matches, match.ToMatchResponse())
+		}
+		response.Rounds = append(response.Rounds, round)
+	}
+	return response
+}
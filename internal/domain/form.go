@@ -0,0 +1,45 @@
+package domain
+
+// TeamForm is a team's recent form: the results of its last matches, its home and away record and
+// clean sheets over those matches, and its current streaks counted back over all its matches
+
+type TeamForm struct {
+	Team        string      `json:"team"`
+	Window      int         `json:"window"` // Number of most recent matches the form covers
+	Form        []string    `json:"form"`   // "W", "D" or "L", most recent first
+	Matches     []FormMatch `json:"matches"`
+	Home        FormRecord  `json:"home"`
+	Away        FormRecord  `json:"away"`
+	CleanSheets int         `json:"clean_sheets"`
+	Streaks     FormStreaks `json:"streaks"`
+}
+
+// FormMatch is one finished match of the team with its outcome
+type FormMatch struct {
+	MatchID      int    `json:"match_id"`
+	MatchDate    string `json:"match_date"` // Format: "YYYY-MM-DD"
+	Opponent     string `json:"opponent"`
+	Venue        string `json:"venue"` // "home" or "away"
+	GoalsFor     int    `json:"goals_for"`
+	GoalsAgainst int    `json:"goals_against"`
+	Outcome      string `json:"outcome"` // "W", "D" or "L"
+}
+
+// FormRecord is the win, draw and loss record of a team
+type FormRecord struct {
+	Played       int `json:"played"`
+	Won          int `json:"won"`
+	Drawn        int `json:"drawn"`
+	Lost         int `json:"lost"`
+	GoalsFor     int `json:"goals_for"`
+	GoalsAgainst int `json:"goals_against"`
+}
+
+// FormStreaks are the runs of matches up to and including the team's latest match
+type FormStreaks struct {
+	Current  string `json:"current,omitempty"` // Outcome of the latest match, the run of which Length counts
+	Length   int    `json:"length"`
+	Unbeaten int    `json:"unbeaten"` // Matches without a loss
+	Winless  int    `json:"winless"`  // Matches without a win
+	Scoring  int    `json:"scoring"`  // Matches with at least one goal
+}
@@ -0,0 +1,32 @@
+package domain
+
+// HeadToHead summarises all finished matches between two teams from the point of view of Team
+// A match decided on penalties counts as a draw, its shootout winner is shown on the match
+
+type HeadToHead struct {
+	Team          string            `json:"team"`
+	Opponent      string            `json:"opponent"`
+	Played        int               `json:"played"`
+	Wins          int               `json:"wins"`
+	Draws         int               `json:"draws"`
+	Losses        int               `json:"losses"`
+	GoalsFor      int               `json:"goals_for"`
+	GoalsAgainst  int               `json:"goals_against"`
+	BiggestWin    *HeadToHeadMatch  `json:"biggest_win,omitempty"`    // Team's win by the largest margin, the most recent one on equal margins
+	BiggestDefeat *HeadToHeadMatch  `json:"biggest_defeat,omitempty"` // Opponent's win by the largest margin
+	Form          []string          `json:"form"`                     // "W", "D" or "L" for the last matches, most recent first
+	Matches       []HeadToHeadMatch `json:"matches"`                  // Most recent first
+}
+
+// HeadToHeadMatch is a finished match with its final score
+type HeadToHeadMatch struct {
+	MatchID       int    `json:"match_id"`
+	MatchDate     string `json:"match_date"` // Format: "YYYY-MM-DD"
+	HomeTeam      string `json:"home_team"`
+	AwayTeam      string `json:"away_team"`
+	HomeScore     int    `json:"home_score"`
+	AwayScore     int    `json:"away_score"`
+	HomePenalties *int   `json:"home_penalties,omitempty"`
+	AwayPenalties *int   `json:"away_penalties,omitempty"`
+	Winner        string `json:"winner,omitempty"` // Name of the winning team, including on penalties, empty for a draw
+}
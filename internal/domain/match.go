@@ -1,30 +1,112 @@
 package domain
 
 import (
+	"fmt"
 	"time"
 )
 
 // Match represents a football match schedule between two teams
-// Fields: match date, match time, home team, away team
-// All fields are required for registration
+// Fields: match date, match time, home team, away team, optional season
+// All fields except the season are required for registration
 
 type Match struct {
-	ID        int        `json:"id"`
-	MatchDate time.Time  `json:"match_date" binding:"required"`
-	MatchTime string     `json:"match_time" binding:"required"` // Format: "HH:MM"
-	HomeTeam  string     `json:"home_team" binding:"required"`
-	AwayTeam  string     `json:"away_team" binding:"required"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
-	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	ID        int         `json:"id"`
+	MatchDate time.Time   `json:"match_date" binding:"required"`
+	MatchTime string      `json:"match_time" binding:"required"` // Format: "HH:MM"
+	HomeTeam  string      `json:"home_team" binding:"required"`
+	AwayTeam  string      `json:"away_team" binding:"required"`
+	SeasonID  *int        `json:"season_id,omitempty"`
+	Status    MatchStatus `json:"status"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	DeletedAt *time.Time  `json:"deleted_at,omitempty"`
+}
+
+// MatchStatus is the stage of a match's lifecycle
+type MatchStatus string
+
+const (
+	MatchScheduled MatchStatus = "scheduled"
+	MatchLive      MatchStatus = "live"
+	MatchFinished  MatchStatus = "finished"
+	MatchPostponed MatchStatus = "postponed"
+	MatchAbandoned MatchStatus = "abandoned"
+	MatchCancelled MatchStatus = "cancelled"
+)
+
+// matchTransitions lists the statuses a match may move to from each status.
+// Finished and cancelled matches are final, an abandoned match may be replayed
+var matchTransitions = map[MatchStatus][]MatchStatus{
+	MatchScheduled: {MatchLive, MatchPostponed, MatchCancelled},
+	MatchLive:      {MatchFinished, MatchAbandoned},
+	MatchPostponed: {MatchScheduled, MatchCancelled},
+	MatchAbandoned: {MatchScheduled, MatchCancelled},
+}
+
+// ValidMatchStatus reports whether status is one of the known match statuses
+func ValidMatchStatus(status MatchStatus) bool {
+	switch status {
+	case MatchScheduled, MatchLive, MatchFinished, MatchPostponed, MatchAbandoned, MatchCancelled:
+		return true
+	}
+	return false
+}
+
+// AcceptsResult reports whether a result may be recorded for a match with this status
+func (s MatchStatus) AcceptsResult() bool {
+	return s == MatchLive || s == MatchFinished
+}
+
+// TransitionTo moves the match to status if its current status allows it. A match cannot
+// kick off before the day it is scheduled for
+func (m *Match) TransitionTo(status MatchStatus, now time.Time) error {
+	if !ValidMatchStatus(status) {
+		return fmt.Errorf("invalid match status %q", status)
+	}
+
+	allowed := false
+	for _, next := range matchTransitions[m.Status] {
+		if next == status {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("cannot change match status from %s to %s", m.Status, status)
+	}
+
+	// Compare calendar days, match dates carry no time of day
+	if status == MatchLive && m.MatchDate.Format("2006-01-02") > now.Format("2006-01-02") {
+		return fmt.Errorf("match cannot start before its date %s", m.MatchDate.Format("2006-01-02"))
+	}
+
+	m.Status = status
+	return nil
+}
+
+// PlayedBefore reports whether the match kicks off before other. Matches at the same date and
+// time are ordered by ID, so the order is the same every time it is computed
+func (m *Match) PlayedBefore(other Match) bool {
+	if !m.MatchDate.Equal(other.MatchDate) {
+		return m.MatchDate.Before(other.MatchDate)
+	}
+	if m.MatchTime != other.MatchTime {
+		return m.MatchTime < other.MatchTime
+	}
+	return m.ID < other.ID
+}
+
+// MatchStatusRequest represents the request structure for changing a match's status
+type MatchStatusRequest struct {
+	Status MatchStatus `json:"status" binding:"required"`
 }
 
 // MatchRequest represents the request structure for creating/updating matches
 type MatchRequest struct {
-	MatchDate string `json:"match_date" binding:"required"` // Format: "YYYY-MM-DD"
-	MatchTime string `json:"match_time" binding:"required"` // Format: "HH:MM"
-	HomeTeam  string `json:"home_team" binding:"required"`
-	AwayTeam  string `json:"away_team" binding:"required"`
+	MatchDate string `json:"match_date"` // Format: "YYYY-MM-DD"
+	MatchTime string `json:"match_time"` // Format: "HH:MM"
+	HomeTeam  string `json:"home_team"`
+	AwayTeam  string `json:"away_team"`
+	SeasonID  *int   `json:"season_id,omitempty"`
 }
 
 // ToMatch converts MatchRequest to Match domain model
@@ -40,19 +122,23 @@ func (mr *MatchRequest) ToMatch() (*Match, error) {
 		MatchTime: mr.MatchTime,
 		HomeTeam:  mr.HomeTeam,
 		AwayTeam:  mr.AwayTeam,
+		SeasonID:  mr.SeasonID,
+		Status:    MatchScheduled,
 	}, nil
 }
 
 // MatchResponse represents the response structure for matches
 type MatchResponse struct {
-	ID        int        `json:"id"`
-	MatchDate string     `json:"match_date"` // Format: "YYYY-MM-DD"
-	MatchTime string     `json:"match_time"` // Format: "HH:MM"
-	HomeTeam  string     `json:"home_team"`
-	AwayTeam  string     `json:"away_team"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
-	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	ID        int         `json:"id"`
+	MatchDate string      `json:"match_date"` // Format: "YYYY-MM-DD"
+	MatchTime string      `json:"match_time"` // Format: "HH:MM"
+	HomeTeam  string      `json:"home_team"`
+	AwayTeam  string      `json:"away_team"`
+	SeasonID  *int        `json:"season_id,omitempty"`
+	Status    MatchStatus `json:"status"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	DeletedAt *time.Time  `json:"deleted_at,omitempty"`
 }
 
 // ToMatchResponse converts Match domain model to MatchResponse
@@ -63,8 +149,18 @@ func (m *Match) ToMatchResponse() *MatchResponse {
 		MatchTime: m.MatchTime,
 		HomeTeam:  m.HomeTeam,
 		AwayTeam:  m.AwayTeam,
+		SeasonID:  m.SeasonID,
+		Status:    m.Status,
 		CreatedAt: m.CreatedAt,
 		UpdatedAt: m.UpdatedAt,
 		DeletedAt: m.DeletedAt,
 	}
 }
+
+// MatchFilter narrows down match listings, nil fields are not filtered on
+type MatchFilter struct {
+	SeasonID      *int
+	CompetitionID *int
+	From          *time.Time // Earliest match date, inclusive
+	To            *time.Time // Latest match date, inclusive
+}
@@ -0,0 +1,200 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MatchEvent is one entry of a match timeline: a goal, a booking or a substitution
+// Fields: match ID, event type, time of play, team, player, and per type the goal type,
+// assisting player or player coming on
+
+// Types of match events
+const (
+	EventGoal         = "goal"
+	EventYellowCard   = "yellow_card"
+	EventRedCard      = "red_card"
+	EventSubstitution = "substitution"
+)
+
+// Ways a goal can be scored
+const (
+	GoalOpenPlay = "open_play"
+	GoalPenalty  = "penalty"
+	GoalOwnGoal  = "own_goal"
+)
+
+// ValidEventType reports whether eventType is one of the known match event types
+func ValidEventType(eventType string) bool {
+	switch eventType {
+	case EventGoal, EventYellowCard, EventRedCard, EventSubstitution:
+		return true
+	}
+	return false
+}
+
+// ValidGoalType reports whether goalType is one of the known ways to score
+func ValidGoalType(goalType string) bool {
+	switch goalType {
+	case GoalOpenPlay, GoalPenalty, GoalOwnGoal:
+		return true
+	}
+	return false
+}
+
+type MatchEvent struct {
+	ID        int        `json:"id"`
+	MatchID   int        `json:"match_id"`
+	Type      string     `json:"type"`       // goal, yellow_card, red_card or substitution
+	EventTime string     `json:"event_time"` // Format: "MM:SS" or "HH:MM:SS"
+	Period    string     `json:"period"`     // Derived from the event time when omitted
+	Team      string     `json:"team"`       // "home" or "away"; for goals the side credited with the goal, so an own goal counts for the opponents
+	Player    string     `json:"player"`     // Scorer, booked player, or player coming off
+	GoalType  string     `json:"goal_type,omitempty"`
+	Assist    string     `json:"assist,omitempty"`    // Goals only
+	PlayerIn  string     `json:"player_in,omitempty"` // Substitutions only
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// Seconds returns the time of play of the event in seconds since kick-off
+func (e *MatchEvent) Seconds() (int, error) {
+	seconds, err := matchClock(e.EventTime)
+	if err != nil {
+		return 0, fmt.Errorf("invalid event time %q. Use MM:SS or HH:MM:SS", e.EventTime)
+	}
+	return seconds, nil
+}
+
+// Validate checks the event and fills in the defaults for its period and goal type
+func (e *MatchEvent) Validate() error {
+	if !ValidEventType(e.Type) {
+		return fmt.Errorf("invalid event type %q. Use goal, yellow_card, red_card or substitution", e.Type)
+	}
+	if e.Team != "home" && e.Team != "away" {
+		return fmt.Errorf("invalid team %q. Use home or away", e.Team)
+	}
+	if e.Player == "" {
+		return errors.New("player is required")
+	}
+
+	seconds, err := e.Seconds()
+	if err != nil {
+		return err
+	}
+	if e.Period == "" {
+		e.Period = periodOfMinute(seconds / 60)
+	}
+	if !ValidPeriod(e.Period) {
+		return fmt.Errorf("invalid period %q. Use first_half, second_half or extra_time", e.Period)
+	}
+
+	if e.Type == EventGoal {
+		if e.GoalType == "" {
+			e.GoalType = GoalOpenPlay
+		}
+		if !ValidGoalType(e.GoalType) {
+			return fmt.Errorf("invalid goal type %q. Use open_play, penalty or own_goal", e.GoalType)
+		}
+		if e.GoalType == GoalOwnGoal && e.Assist != "" {
+			return errors.New("an own goal cannot have an assist")
+		}
+	} else if e.GoalType != "" || e.Assist != "" {
+		return errors.New("goal type and assist are only allowed for goals")
+	}
+
+	if e.Type == EventSubstitution {
+		if e.PlayerIn == "" {
+			return errors.New("player coming on is required for a substitution")
+		}
+	} else if e.PlayerIn != "" {
+		return errors.New("player coming on is only allowed for substitutions")
+	}
+	return nil
+}
+
+// ToGoal converts a goal event to the goal of a match result
+func (e *MatchEvent) ToGoal() Goal {
+	return Goal{
+		ID:        e.ID,
+		MatchID:   e.MatchID,
+		Scorer:    e.Player,
+		GoalTime:  e.EventTime,
+		Team:      e.Team,
+		Period:    e.Period,
+		Type:      e.GoalType,
+		Assist:    e.Assist,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+		DeletedAt: e.DeletedAt,
+	}
+}
+
+// MatchEventRequest represents the request structure for recording match events
+type MatchEventRequest struct {
+	MatchID   int    `json:"match_id" binding:"required"`
+	Type      string `json:"type" binding:"required"`
+	EventTime string `json:"event_time" binding:"required"` // Format: "MM:SS" or "HH:MM:SS"
+	Period    string `json:"period,omitempty"`
+	Team      string `json:"team" binding:"required"`
+	Player    string `json:"player" binding:"required"`
+	GoalType  string `json:"goal_type,omitempty"`
+	Assist    string `json:"assist,omitempty"`
+	PlayerIn  string `json:"player_in,omitempty"`
+}
+
+// ToMatchEvent converts MatchEventRequest to MatchEvent domain model
+func (er *MatchEventRequest) ToMatchEvent() *MatchEvent {
+	return &MatchEvent{
+		MatchID:   er.MatchID,
+		Type:      er.Type,
+		EventTime: er.EventTime,
+		Period:    er.Period,
+		Team:      er.Team,
+		Player:    er.Player,
+		GoalType:  er.GoalType,
+		Assist:    er.Assist,
+		PlayerIn:  er.PlayerIn,
+	}
+}
+
+// MatchEventResponse represents the response structure for match events
+type MatchEventResponse struct {
+	ID        int        `json:"id"`
+	MatchID   int        `json:"match_id"`
+	Type      string     `json:"type"`
+	EventTime string     `json:"event_time"`
+	Minute    int        `json:"minute"` // Minute of play, counting from zero
+	Period    string     `json:"period"`
+	Team      string     `json:"team"`
+	Player    string     `json:"player"`
+	GoalType  string     `json:"goal_type,omitempty"`
+	Assist    string     `json:"assist,omitempty"`
+	PlayerIn  string     `json:"player_in,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// ToMatchEventResponse converts MatchEvent domain model to MatchEventResponse
+func (e *MatchEvent) ToMatchEventResponse() *MatchEventResponse {
+	seconds, _ := e.Seconds()
+	return &MatchEventResponse{
+		ID:        e.ID,
+		MatchID:   e.MatchID,
+		Type:      e.Type,
+		EventTime: e.EventTime,
+		Minute:    seconds / 60,
+		Period:    e.Period,
+		Team:      e.Team,
+		Player:    e.Player,
+		GoalType:  e.GoalType,
+		Assist:    e.Assist,
+		PlayerIn:  e.PlayerIn,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+		DeletedAt: e.DeletedAt,
+	}
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchEventValidate(t *testing.T) {
+	t.Run("Goals default to open play with a derived period", func(t *testing.T) {
+		event := MatchEvent{Type: EventGoal, EventTime: "01:32:10", Team: "home", Player: "A", Assist: "B"}
+		require.NoError(t, event.Validate())
+		assert.Equal(t, GoalOpenPlay, event.GoalType)
+		assert.Equal(t, PeriodExtraTime, event.Period)
+		assert.Equal(t, 92, event.ToMatchEventResponse().Minute)
+	})
+
+	t.Run("Own goals cannot have an assist", func(t *testing.T) {
+		event := MatchEvent{Type: EventGoal, GoalType: GoalOwnGoal, EventTime: "10:00", Team: "away", Player: "A", Assist: "B"}
+		assert.Error(t, event.Validate())
+	})
+
+	t.Run("Substitutions need the player coming on", func(t *testing.T) {
+		event := MatchEvent{Type: EventSubstitution, EventTime: "60:00", Team: "home", Player: "A"}
+		assert.Error(t, event.Validate())
+
+		event.PlayerIn = "B"
+		assert.NoError(t, event.Validate())
+	})
+
+	t.Run("Goal details only on goals", func(t *testing.T) {
+		event := MatchEvent{Type: EventYellowCard, EventTime: "60:00", Team: "home", Player: "A", GoalType: GoalPenalty}
+		assert.Error(t, event.Validate())
+
+		event = MatchEvent{Type: EventRedCard, EventTime: "60:00", Team: "home", Player: "A", PlayerIn: "B"}
+		assert.Error(t, event.Validate())
+	})
+
+	t.Run("Rejects unknown types, teams and times", func(t *testing.T) {
+		assert.Error(t, (&MatchEvent{Type: "corner", EventTime: "10:00", Team: "home", Player: "A"}).Validate())
+		assert.Error(t, (&MatchEvent{Type: EventGoal, EventTime: "10:00", Team: "Persija", Player: "A"}).Validate())
+		assert.Error(t, (&MatchEvent{Type: EventGoal, EventTime: "ten", Team: "home", Player: "A"}).Validate())
+	})
+
+	t.Run("Goals convert to and from result goals", func(t *testing.T) {
+		goal := Goal{Scorer: "A", GoalTime: "12:00", Team: "home", Period: PeriodFirstHalf, Type: GoalPenalty}
+		event := goal.ToMatchEvent(7)
+		assert.Equal(t, EventGoal, event.Type)
+		assert.Equal(t, 7, event.MatchID)
+		assert.Equal(t, GoalPenalty, event.GoalType)
+
+		back := event.ToGoal()
+		assert.Equal(t, goal.Scorer, back.Scorer)
+		assert.Equal(t, goal.Type, back.Type)
+	})
+}
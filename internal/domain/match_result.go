@@ -1,39 +1,296 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // MatchResult represents the result of a completed football match
 // Fields: match ID, home score, away score, goals details
 // All fields are required for reporting
 
+// Periods of play a goal or other match event can happen in
+const (
+	PeriodFirstHalf  = "first_half"
+	PeriodSecondHalf = "second_half"
+	PeriodExtraTime  = "extra_time"
+)
+
+// ValidPeriod reports whether period is one of the known periods of play
+func ValidPeriod(period string) bool {
+	switch period {
+	case PeriodFirstHalf, PeriodSecondHalf, PeriodExtraTime:
+		return true
+	}
+	return false
+}
+
 type Goal struct {
 	ID        int        `json:"id"`
 	MatchID   int        `json:"match_id"`
 	Scorer    string     `json:"scorer" binding:"required"`    // Player name who scored
 	GoalTime  string     `json:"goal_time" binding:"required"` // Format: "MM:SS" or "HH:MM:SS"
 	Team      string     `json:"team" binding:"required"`      // Team that scored
+	Period    string     `json:"period"`                       // Derived from the goal time when omitted
+	Type      string     `json:"type"`                         // open_play, penalty or own_goal, defaults to open_play
+	Assist    string     `json:"assist,omitempty"`             // Player name who provided the assist
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
-type MatchResult struct {
+// Minute returns the minute of play the goal was scored in, counting from zero
+func (g *Goal) Minute() (int, error) {
+	seconds, err := matchClock(g.GoalTime)
+	if err != nil {
+		return 0, fmt.Errorf("invalid goal time %q. Use MM:SS or HH:MM:SS", g.GoalTime)
+	}
+	return seconds / 60, nil
+}
+
+// ToMatchEvent converts a goal of a result to the goal event of the match timeline
+func (g *Goal) ToMatchEvent(matchID int) MatchEvent {
+	return MatchEvent{
+		ID:        g.ID,
+		MatchID:   matchID,
+		Type:      EventGoal,
+		EventTime: g.GoalTime,
+		Period:    g.Period,
+		Team:      g.Team,
+		Player:    g.Scorer,
+		GoalType:  g.Type,
+		Assist:    g.Assist,
+		CreatedAt: g.CreatedAt,
+		UpdatedAt: g.UpdatedAt,
+		DeletedAt: g.DeletedAt,
+	}
+}
+
+// matchClock converts a time of play in "MM:SS" or "HH:MM:SS" format to seconds since kick-off
+func matchClock(value string) (int, error) {
+	parts := strings.Split(value, ":")
+	values := make([]int, len(parts))
+	for i, part := range parts {
+		v, err := strconv.Atoi(part)
+		if err != nil || v < 0 {
+			return 0, errors.New("invalid time of play")
+		}
+		values[i] = v
+	}
+
+	switch len(values) {
+	case 2:
+		return values[0]*60 + values[1], nil
+	case 3:
+		return values[0]*3600 + values[1]*60 + values[2], nil
+	}
+	return 0, errors.New("invalid time of play")
+}
+
+// ShootoutKick is one penalty taken in a shootout
+type ShootoutKick struct {
 	ID        int        `json:"id"`
-	MatchID   int        `json:"match_id" binding:"required"`
-	HomeScore int        `json:"home_score" binding:"required"`
-	AwayScore int        `json:"away_score" binding:"required"`
-	Goals     []Goal     `json:"goals,omitempty"`
+	MatchID   int        `json:"match_id"`
+	Order     int        `json:"order" binding:"required"` // 1 for the first kick of the shootout
+	Team      string     `json:"team" binding:"required"`  // "home" or "away"
+	Taker     string     `json:"taker" binding:"required"` // Player name who took the kick
+	Scored    bool       `json:"scored"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
+type MatchResult struct {
+	ID        int            `json:"id"`
+	MatchID   int            `json:"match_id" binding:"required"`
+	HomeScore int            `json:"home_score"`
+	AwayScore int            `json:"away_score"`
+	Goals     []Goal         `json:"goals,omitempty"`
+	Shootout  []ShootoutKick `json:"shootout,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt *time.Time     `json:"deleted_at,omitempty"`
+}
+
+// Winner returns "home" or "away" for the side that won the match, including on penalties,
+// or an empty string for a draw
+func (mr *MatchResult) Winner() string {
+	switch {
+	case mr.HomeScore > mr.AwayScore:
+		return "home"
+	case mr.AwayScore > mr.HomeScore:
+		return "away"
+	}
+
+	home, away := mr.ShootoutScore()
+	switch {
+	case home > away:
+		return "home"
+	case away > home:
+		return "away"
+	}
+	return ""
+}
+
+// ShootoutScore returns the number of penalties scored by each side in the shootout
+func (mr *MatchResult) ShootoutScore() (home, away int) {
+	for _, kick := range mr.Shootout {
+		if !kick.Scored {
+			continue
+		}
+		if kick.Team == "home" {
+			home++
+		} else if kick.Team == "away" {
+			away++
+		}
+	}
+	return home, away
+}
+
+// Validate checks that the goals add up to the score, that every goal has a valid period
+// and that a shootout is only recorded for a level score and is won by one side
+func (mr *MatchResult) Validate() error {
+	homeGoals := 0
+	awayGoals := 0
+	for i := range mr.Goals {
+		goal := &mr.Goals[i]
+		if goal.Team == "home" {
+			homeGoals++
+		} else if goal.Team == "away" {
+			awayGoals++
+		}
+
+		if goal.Period == "" {
+			minute, err := goal.Minute()
+			if err != nil {
+				return err
+			}
+			goal.Period = periodOfMinute(minute)
+		}
+		if !ValidPeriod(goal.Period) {
+			return fmt.Errorf("invalid period %q for goal by %s. Use first_half, second_half or extra_time", goal.Period, goal.Scorer)
+		}
+
+		if goal.Type == "" {
+			goal.Type = GoalOpenPlay
+		}
+		if !ValidGoalType(goal.Type) {
+			return fmt.Errorf("invalid type %q for goal by %s. Use open_play, penalty or own_goal", goal.Type, goal.Scorer)
+		}
+		if goal.Type == GoalOwnGoal && goal.Assist != "" {
+			return fmt.Errorf("own goal by %s cannot have an assist", goal.Scorer)
+		}
+	}
+
+	if homeGoals != mr.HomeScore {
+		return errors.New("home score does not match number of home goals")
+	}
+	if awayGoals != mr.AwayScore {
+		return errors.New("away score does not match number of away goals")
+	}
+
+	if len(mr.Shootout) == 0 {
+		return nil
+	}
+	if mr.HomeScore != mr.AwayScore {
+		return errors.New("a penalty shootout can only follow a level score")
+	}
+	return validateShootout(mr.Shootout)
+}
+
+// periodOfMinute guesses the period of a goal from its minute. Goals scored in stoppage time
+// need their period given explicitly
+func periodOfMinute(minute int) string {
+	switch {
+	case minute < 45:
+		return PeriodFirstHalf
+	case minute < 90:
+		return PeriodSecondHalf
+	}
+	return PeriodExtraTime
+}
+
+// validateShootout replays the kicks in order and checks the sides take turns, that the
+// shootout stops as soon as it is decided, and that it is decided at all. Each side takes
+// five kicks, followed by sudden death rounds of one kick each
+func validateShootout(kicks []ShootoutKick) error {
+	ordered := make([]*ShootoutKick, len(kicks))
+	for i := range kicks {
+		kick := &kicks[i]
+		if kick.Order < 1 || kick.Order > len(kicks) || ordered[kick.Order-1] != nil {
+			return errors.New("shootout kicks must be numbered 1 to the number of kicks, each once")
+		}
+		if kick.Team != "home" && kick.Team != "away" {
+			return fmt.Errorf("invalid team %q for shootout kick %d. Use home or away", kick.Team, kick.Order)
+		}
+		if kick.Taker == "" {
+			return fmt.Errorf("shootout kick %d has no taker", kick.Order)
+		}
+		ordered[kick.Order-1] = kick
+	}
+
+	var taken, scored [2]int
+	decided := false
+	for _, kick := range ordered {
+		if decided {
+			return fmt.Errorf("shootout kick %d was taken after the shootout was decided", kick.Order)
+		}
+		side := 0
+		if kick.Team == "away" {
+			side = 1
+		}
+		if taken[side] > taken[1-side] {
+			return fmt.Errorf("shootout kick %d: the %s side kicked twice in a row", kick.Order, kick.Team)
+		}
+		taken[side]++
+		if kick.Scored {
+			scored[side]++
+		}
+
+		if taken[0] <= 5 && taken[1] <= 5 {
+			// Decided once one side cannot catch up with its remaining kicks
+			decided = scored[0] > scored[1]+5-taken[1] || scored[1] > scored[0]+5-taken[0]
+		} else {
+			decided = taken[0] == taken[1] && scored[0] != scored[1]
+		}
+	}
+	if !decided {
+		return errors.New("the penalty shootout does not produce a winner")
+	}
+	return nil
+}
+
+// InvalidGoal describes a goal whose scorer or assist is not a player of the side it belongs to
+type InvalidGoal struct {
+	Index  int    `json:"index"` // Position of the goal in the result, starting at 0
+	Scorer string `json:"scorer"`
+	Team   string `json:"team"`
+	Reason string `json:"reason"`
+}
+
+// InvalidGoalsError lists every goal of a result that failed the squad check
+type InvalidGoalsError struct {
+	Goals []InvalidGoal
+}
+
+func (e *InvalidGoalsError) Error() string {
+	reasons := make([]string, 0, len(e.Goals))
+	for _, goal := range e.Goals {
+		reasons = append(reasons, fmt.Sprintf("goal %d: %s", goal.Index+1, goal.Reason))
+	}
+	return "invalid goals: " + strings.Join(reasons, "; ")
+}
+
 // MatchResultRequest represents the request structure for reporting match results
 type MatchResultRequest struct {
-	MatchID   int    `json:"match_id" binding:"required"`
-	HomeScore int    `json:"home_score" binding:"required"`
-	AwayScore int    `json:"away_score" binding:"required"`
-	Goals     []Goal `json:"goals,omitempty"`
+	MatchID   int            `json:"match_id"`
+	HomeScore int            `json:"home_score"` // Score after regular and, if played, extra time
+	AwayScore int            `json:"away_score"`
+	Goals     []Goal         `json:"goals,omitempty"`
+	Shootout  []ShootoutKick `json:"shootout,omitempty"` // Only for a level score
 }
 
 // ToMatchResult converts MatchResultRequest to MatchResult domain model
@@ -43,31 +300,43 @@ func (mr *MatchResultRequest) ToMatchResult() *MatchResult {
 		HomeScore: mr.HomeScore,
 		AwayScore: mr.AwayScore,
 		Goals:     mr.Goals,
+		Shootout:  mr.Shootout,
 	}
 }
 
 // MatchResultResponse represents the response structure for match results
 type MatchResultResponse struct {
-	ID        int        `json:"id"`
-	MatchID   int        `json:"match_id"`
-	HomeScore int        `json:"home_score"`
-	AwayScore int        `json:"away_score"`
-	Goals     []Goal     `json:"goals,omitempty"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
-	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	ID            int            `json:"id"`
+	MatchID       int            `json:"match_id"`
+	HomeScore     int            `json:"home_score"`
+	AwayScore     int            `json:"away_score"`
+	HomePenalties *int           `json:"home_penalties,omitempty"`
+	AwayPenalties *int           `json:"away_penalties,omitempty"`
+	Winner        string         `json:"winner,omitempty"` // "home" or "away", empty for a draw
+	Goals         []Goal         `json:"goals,omitempty"`
+	Shootout      []ShootoutKick `json:"shootout,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     *time.Time     `json:"deleted_at,omitempty"`
 }
 
 // ToMatchResultResponse converts MatchResult domain model to MatchResultResponse
 func (mr *MatchResult) ToMatchResultResponse() *MatchResultResponse {
-	return &MatchResultResponse{
+	response := &MatchResultResponse{
 		ID:        mr.ID,
 		MatchID:   mr.MatchID,
 		HomeScore: mr.HomeScore,
 		AwayScore: mr.AwayScore,
+		Winner:    mr.Winner(),
 		Goals:     mr.Goals,
+		Shootout:  mr.Shootout,
 		CreatedAt: mr.CreatedAt,
 		UpdatedAt: mr.UpdatedAt,
 		DeletedAt: mr.DeletedAt,
 	}
+	if len(mr.Shootout) > 0 {
+		home, away := mr.ShootoutScore()
+		response.HomePenalties, response.AwayPenalties = &home, &away
+	}
+	return response
 }
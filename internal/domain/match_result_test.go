@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// kicks builds a shootout from alternating home and away kicks, "x" for scored and "o" for missed
+func kicks(outcomes string) []ShootoutKick {
+	shootout := make([]ShootoutKick, len(outcomes))
+	for i, outcome := range outcomes {
+		team := "home"
+		if i%2 == 1 {
+			team = "away"
+		}
+		shootout[i] = ShootoutKick{Order: i + 1, Team: team, Taker: "Taker", Scored: outcome == 'x'}
+	}
+	return shootout
+}
+
+func TestMatchResultValidate(t *testing.T) {
+	t.Run("Goal periods default from the goal time", func(t *testing.T) {
+		result := MatchResult{HomeScore: 3, AwayScore: 1, Goals: []Goal{
+			{Scorer: "A", GoalTime: "12:00", Team: "home"},
+			{Scorer: "B", GoalTime: "67:30", Team: "away"},
+			{Scorer: "C", GoalTime: "01:45:10", Team: "home"},
+			{Scorer: "D", GoalTime: "45:00", Team: "home", Period: PeriodFirstHalf},
+		}}
+
+		require.NoError(t, result.Validate())
+		assert.Equal(t, PeriodFirstHalf, result.Goals[0].Period)
+		assert.Equal(t, PeriodSecondHalf, result.Goals[1].Period)
+		assert.Equal(t, PeriodExtraTime, result.Goals[2].Period)
+		assert.Equal(t, PeriodFirstHalf, result.Goals[3].Period)
+	})
+
+	t.Run("Unknown periods and goal times are rejected", func(t *testing.T) {
+		result := MatchResult{HomeScore: 1, Goals: []Goal{{Scorer: "A", GoalTime: "12:00", Team: "home", Period: "overtime"}}}
+		assert.Error(t, result.Validate())
+
+		result = MatchResult{HomeScore: 1, Goals: []Goal{{Scorer: "A", GoalTime: "late", Team: "home"}}}
+		assert.Error(t, result.Validate())
+	})
+
+	t.Run("Goals must add up to the score", func(t *testing.T) {
+		result := MatchResult{HomeScore: 1, AwayScore: 0}
+		assert.EqualError(t, result.Validate(), "home score does not match number of home goals")
+	})
+
+	t.Run("Shootout decided within five kicks each", func(t *testing.T) {
+		result := MatchResult{Shootout: kicks("xxxxxxxxxo")}
+		require.NoError(t, result.Validate())
+		assert.Equal(t, "home", result.Winner())
+
+		home, away := result.ShootoutScore()
+		assert.Equal(t, 5, home)
+		assert.Equal(t, 4, away)
+	})
+
+	t.Run("Shootout decided early", func(t *testing.T) {
+		result := MatchResult{Shootout: kicks("xoxoxo")}
+		require.NoError(t, result.Validate())
+		assert.Equal(t, "home", result.Winner())
+	})
+
+	t.Run("Shootout decided in sudden death", func(t *testing.T) {
+		result := MatchResult{Shootout: kicks("xxxxxxxxxxxxox")}
+		require.NoError(t, result.Validate())
+		assert.Equal(t, "away", result.Winner())
+	})
+
+	t.Run("Shootout only follows a level score", func(t *testing.T) {
+		result := MatchResult{HomeScore: 1, Goals: []Goal{{Scorer: "A", GoalTime: "12:00", Team: "home"}}, Shootout: kicks("xoxoxo")}
+		assert.EqualError(t, result.Validate(), "a penalty shootout can only follow a level score")
+	})
+
+	t.Run("Shootout must produce a winner", func(t *testing.T) {
+		result := MatchResult{Shootout: kicks("xxxxxxxxxx")}
+		assert.EqualError(t, result.Validate(), "the penalty shootout does not produce a winner")
+		assert.Equal(t, "", result.Winner())
+	})
+
+	t.Run("Kicks after the shootout is decided are rejected", func(t *testing.T) {
+		result := MatchResult{Shootout: kicks("xoxoxox")}
+		assert.Error(t, result.Validate())
+	})
+
+	t.Run("Sides take turns", func(t *testing.T) {
+		shootout := kicks("xoxoxo")
+		shootout[1].Team = "home"
+		result := MatchResult{Shootout: shootout}
+		assert.Error(t, result.Validate())
+	})
+
+	t.Run("Kicks are numbered once each", func(t *testing.T) {
+		shootout := kicks("xoxoxo")
+		shootout[1].Order = 1
+		result := MatchResult{Shootout: shootout}
+		assert.Error(t, result.Validate())
+	})
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchTransitionTo(t *testing.T) {
+	now := time.Date(2024, 8, 10, 15, 0, 0, 0, time.UTC)
+	match := func(status MatchStatus, date time.Time) *Match {
+		return &Match{MatchDate: date, Status: status}
+	}
+
+	t.Run("Follows the lifecycle", func(t *testing.T) {
+		m := match(MatchScheduled, now)
+		assert.NoError(t, m.TransitionTo(MatchLive, now))
+		assert.NoError(t, m.TransitionTo(MatchFinished, now))
+		assert.Equal(t, MatchFinished, m.Status)
+	})
+
+	t.Run("Postponed matches are rescheduled", func(t *testing.T) {
+		m := match(MatchScheduled, now)
+		assert.NoError(t, m.TransitionTo(MatchPostponed, now))
+		assert.NoError(t, m.TransitionTo(MatchScheduled, now))
+	})
+
+	t.Run("Rejects transitions that are not allowed", func(t *testing.T) {
+		m := match(MatchScheduled, now)
+		assert.EqualError(t, m.TransitionTo(MatchFinished, now), "cannot change match status from scheduled to finished")
+
+		m = match(MatchFinished, now)
+		assert.Error(t, m.TransitionTo(MatchLive, now))
+		assert.Equal(t, MatchFinished, m.Status)
+
+		m = match(MatchCancelled, now)
+		assert.Error(t, m.TransitionTo(MatchScheduled, now))
+	})
+
+	t.Run("Rejects unknown statuses", func(t *testing.T) {
+		assert.Error(t, match(MatchScheduled, now).TransitionTo("halftime", now))
+	})
+
+	t.Run("Cannot kick off before the match date", func(t *testing.T) {
+		m := match(MatchScheduled, now.AddDate(1, 0, 0))
+		assert.Error(t, m.TransitionTo(MatchLive, now))
+		assert.Equal(t, MatchScheduled, m.Status)
+	})
+
+	t.Run("Results only for live or finished matches", func(t *testing.T) {
+		assert.True(t, MatchLive.AcceptsResult())
+		assert.True(t, MatchFinished.AcceptsResult())
+		assert.False(t, MatchScheduled.AcceptsResult())
+		assert.False(t, MatchPostponed.AcceptsResult())
+	})
+}
+
+func TestMatchPlayedBefore(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 8, d, 0, 0, 0, 0, time.UTC) }
+	first := Match{ID: 5, MatchDate: day(1), MatchTime: "19:00"}
+
+	assert.True(t, first.PlayedBefore(Match{ID: 1, MatchDate: day(2), MatchTime: "13:00"}))
+	assert.True(t, first.PlayedBefore(Match{ID: 1, MatchDate: day(1), MatchTime: "20:30"}))
+	assert.True(t, first.PlayedBefore(Match{ID: 6, MatchDate: day(1), MatchTime: "19:00"}))
+	assert.False(t, first.PlayedBefore(Match{ID: 4, MatchDate: day(1), MatchTime: "19:00"}))
+	assert.False(t, first.PlayedBefore(first))
+}
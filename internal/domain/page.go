@@ -0,0 +1,122 @@
+package domain
+
+import "strings"
+
+// Bounds of the number of items on one page of a list
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// PageRequest selects one page of a list: up to Limit items after skipping Offset of them, ordered by
+// Sort. Sort names one of the sort fields of the listed type, a leading "-" sorts descending, e.g.
+// "-created_at". Items sorting equal are ordered by ID, so pages never overlap
+type PageRequest struct {
+	Limit  int
+	Offset int
+	Sort   string
+}
+
+// Fields lists can be sorted by, followed by the order used when no sort is requested
+var (
+	TeamSortFields        = []string{"id", "name", "city", "year_founded", "created_at"}
+	PlayerSortFields      = []string{"id", "name", "position", "jersey_number", "height", "weight", "team", "created_at"}
+	MatchSortFields       = []string{"date", "id", "status", "created_at"}
+	MatchResultSortFields = []string{"id", "match_id", "created_at"}
+)
+
+const (
+	DefaultTeamSort        = "id"
+	DefaultPlayerSort      = "id"
+	DefaultMatchSort       = "date"
+	DefaultMatchResultSort = "-created_at" // Newest first
+)
+
+// ValidSort reports whether sort names one of fields, with or without a leading "-"
+func ValidSort(sort string, fields []string) bool {
+	field := strings.TrimPrefix(sort, "-")
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Normalized returns the request with its limit brought within 1 and MaxPageLimit, a missing limit
+// becoming DefaultPageLimit, a negative offset becoming 0 and a missing sort becoming fallback
+func (p PageRequest) Normalized(fallback string) PageRequest {
+	if p.Limit <= 0 {
+		p.Limit = DefaultPageLimit
+	}
+	if p.Limit > MaxPageLimit {
+		p.Limit = MaxPageLimit
+	}
+	if p.Offset < 0 {
+		p.Offset = 0
+	}
+	if p.Sort == "" {
+		p.Sort = fallback
+	}
+	return p
+}
+
+// SortBy returns the field to sort by and whether to sort descending
+func (p PageRequest) SortBy() (field string, desc bool) {
+	return strings.TrimPrefix(p.Sort, "-"), strings.HasPrefix(p.Sort, "-")
+}
+
+// Page is one page of a list together with the number of items in the whole list
+type Page[T any] struct {
+	Items  []T `json:"items"`
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// NewPage returns the page of items selected by page out of total items. Items is never nil, so an
+// empty page is rendered as [] rather than null
+func NewPage[T any](items []T, total int, page PageRequest) *Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return &Page[T]{Items: items, Total: total, Limit: page.Limit, Offset: page.Offset}
+}
+
+// TeamQuery selects a page of the teams, only those in City when it is set
+type TeamQuery struct {
+	City    string // Compared case-insensitively
+	Deleted DeletedFilter
+	PageRequest
+}
+
+// PlayerQuery selects a page of the players. Empty filters match every player
+type PlayerQuery struct {
+	Team         string // ID or slug of the player's team
+	Position     PlayerPosition
+	JerseyNumber *int
+	Deleted      DeletedFilter
+	PageRequest
+}
+
+// MatchQuery selects a page of the matches. Venue is "home" or "away" and narrows the matches
+// of Team down to those it played at home or away
+type MatchQuery struct {
+	MatchFilter
+	Team    string // ID or slug of one of the teams playing
+	Venue   string
+	Deleted DeletedFilter
+	PageRequest
+}
+
+// MatchResultQuery selects a page of the match results
+type MatchResultQuery struct {
+	Deleted DeletedFilter
+	PageRequest
+}
+
+// Venues a team can play a match at
+const (
+	VenueHome = "home"
+	VenueAway = "away"
+)
@@ -4,7 +4,9 @@ import "time"
 
 // Player represents a football player under a team
 // Fields: name, height, weight, position, jersey number
-// All fields are required for registration
+// All fields are required for registration, Validate checks them. The ID and slug are assigned on
+// registration and never change. The optional contract start dates back the first contract with the
+// team for players who joined before they were registered
 
 type PlayerPosition string
 
@@ -16,13 +18,24 @@ const (
 )
 
 type Player struct {
-	Name         string         `json:"name" binding:"required"`
-	Height       int            `json:"height" binding:"required"` // in cm
-	Weight       int            `json:"weight" binding:"required"` // in kg
-	Position     PlayerPosition `json:"position" binding:"required"`
-	JerseyNumber int            `json:"jersey_number" binding:"required"`
-	TeamName     string         `json:"team_name" binding:"required"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    *time.Time     `json:"deleted_at,omitempty"`
+	ID            int            `json:"id"`
+	Slug          string         `json:"slug"`
+	Name          string         `json:"name"`
+	Height        int            `json:"height"` // in cm
+	Weight        int            `json:"weight"` // in kg
+	Position      PlayerPosition `json:"position"`
+	JerseyNumber  int            `json:"jersey_number"`
+	TeamName      string         `json:"team_name"`
+	ContractStart string         `json:"contract_start,omitempty"` // YYYY-MM-DD, only read on registration
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     *time.Time     `json:"deleted_at,omitempty"`
+}
+
+// FirstContractStart returns the start of the contract the player is registered on, today being now
+func (p *Player) FirstContractStart(now time.Time) time.Time {
+	if start, err := time.Parse("2006-01-02", p.ContractStart); err == nil {
+		return start
+	}
+	return now
 }
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// TeamRating is the current Elo rating of a team
+// Teams that have not played a rated match yet hold the initial rating
+
+type TeamRating struct {
+	Rank      int        `json:"rank"`
+	Team      string     `json:"team"`
+	Rating    float64    `json:"rating"`
+	Played    int        `json:"played"` // Number of rated matches
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// RatingChange is the change of one team's rating caused by one finished match
+type RatingChange struct {
+	ID           int       `json:"id"`
+	MatchID      int       `json:"match_id"`
+	MatchDate    time.Time `json:"match_date"`
+	MatchTime    string    `json:"match_time"` // Format: "HH:MM"
+	Team         string    `json:"team"`
+	Opponent     string    `json:"opponent"`
+	Venue        string    `json:"venue"` // "home" or "away"
+	GoalsFor     int       `json:"goals_for"`
+	GoalsAgainst int       `json:"goals_against"`
+	Outcome      string    `json:"outcome"` // "W", "D" or "L"
+	Before       float64   `json:"rating_before"`
+	After        float64   `json:"rating_after"`
+	CreatedAt    time.Time `json:"created_at"`
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Season represents one edition of a competition, e.g. the 2024/25 league season
+// Fields: competition ID, name, start date, end date
+// All fields are required for registration
+
+type Season struct {
+	ID            int        `json:"id"`
+	CompetitionID int        `json:"competition_id"`
+	Name          string     `json:"name"`
+	StartDate     time.Time  `json:"start_date"`
+	EndDate       time.Time  `json:"end_date"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty"`
+}
+
+// Contains reports whether date falls within the season, both ends inclusive
+func (s *Season) Contains(date time.Time) bool {
+	return !date.Before(s.StartDate) && !date.After(s.EndDate)
+}
+
+// SeasonRequest represents the request structure for creating/updating seasons
+type SeasonRequest struct {
+	CompetitionID int    `json:"competition_id" binding:"required"`
+	Name          string `json:"name" binding:"required"`
+	StartDate     string `json:"start_date" binding:"required"` // Format: "YYYY-MM-DD"
+	EndDate       string `json:"end_date" binding:"required"`   // Format: "YYYY-MM-DD"
+}
+
+// ToSeason converts SeasonRequest to Season domain model
+func (sr *SeasonRequest) ToSeason() (*Season, error) {
+	startDate, err := time.Parse("2006-01-02", sr.StartDate)
+	if err != nil {
+		return nil, errors.New("invalid start date format. Use YYYY-MM-DD")
+	}
+	endDate, err := time.Parse("2006-01-02", sr.EndDate)
+	if err != nil {
+		return nil, errors.New("invalid end date format. Use YYYY-MM-DD")
+	}
+	if endDate.Before(startDate) {
+		return nil, errors.New("end date cannot be before start date")
+	}
+
+	return &Season{
+		CompetitionID: sr.CompetitionID,
+		Name:          sr.Name,
+		StartDate:     startDate,
+		EndDate:       endDate,
+	}, nil
+}
+
+// SeasonResponse represents the response structure for seasons
+type SeasonResponse struct {
+	ID            int        `json:"id"`
+	CompetitionID int        `json:"competition_id"`
+	Name          string     `json:"name"`
+	StartDate     string     `json:"start_date"` // Format: "YYYY-MM-DD"
+	EndDate       string     `json:"end_date"`   // Format: "YYYY-MM-DD"
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty"`
+}
+
+// ToSeasonResponse converts Season domain model to SeasonResponse
+func (s *Season) ToSeasonResponse() *SeasonResponse {
+	return &SeasonResponse{
+		ID:            s.ID,
+		CompetitionID: s.CompetitionID,
+		Name:          s.Name,
+		StartDate:     s.StartDate.Format("2006-01-02"),
+		EndDate:       s.EndDate.Format("2006-01-02"),
+		CreatedAt:     s.CreatedAt,
+		UpdatedAt:     s.UpdatedAt,
+		DeletedAt:     s.DeletedAt,
+	}
+}
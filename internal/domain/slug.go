@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Slugify turns a name into the lowercase, dash-separated form used in URLs, e.g. "Bali United" becomes
+// "bali-united". Slugs never consist of digits only, so they cannot be mistaken for an ID
+func Slugify(name, prefix string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		default:
+			dash = true
+		}
+	}
+
+	slug := b.String()
+	if strings.Trim(slug, "0123456789") == "" {
+		return strings.TrimSuffix(prefix+"-"+slug, "-")
+	}
+	return slug
+}
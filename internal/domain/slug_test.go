@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Bali United", "bali-united"},
+		{"  Persija   Jakarta ", "persija-jakarta"},
+		{"PSM Makassar F.C.", "psm-makassar-f-c"},
+		{"Arema-Malang", "arema-malang"},
+		{"Pusamania Borneo 2", "pusamania-borneo-2"},
+		{"Bayu Pradana Ärzt", "bayu-pradana-ärzt"},
+		{"1927", "team-1927"},
+		{"19 27", "19-27"},
+		{"!!!", "team"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Slugify(tt.name, "team"))
+		})
+	}
+}
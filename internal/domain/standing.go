@@ -0,0 +1,34 @@
+package domain
+
+// Standing represents one row of a league table
+
+type Standing struct {
+	Position       int    `json:"position"`
+	Team           string `json:"team"`
+	Played         int    `json:"played"`
+	Won            int    `json:"won"`
+	Drawn          int    `json:"drawn"`
+	Lost           int    `json:"lost"`
+	GoalsFor       int    `json:"goals_for"`
+	GoalsAgainst   int    `json:"goals_against"`
+	GoalDifference int    `json:"goal_difference"`
+	Points         int    `json:"points"`
+}
+
+// Tiebreaker decides the order of teams level on points
+type Tiebreaker string
+
+const (
+	TiebreakerGoalDifference Tiebreaker = "goal_difference"
+	TiebreakerGoalsFor       Tiebreaker = "goals_for"
+	TiebreakerHeadToHead     Tiebreaker = "head_to_head"
+)
+
+// ValidTiebreaker reports whether t is a supported tiebreaker
+func ValidTiebreaker(t Tiebreaker) bool {
+	switch t {
+	case TiebreakerGoalDifference, TiebreakerGoalsFor, TiebreakerHeadToHead:
+		return true
+	}
+	return false
+}
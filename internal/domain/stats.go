@@ -0,0 +1,27 @@
+package domain
+
+// PlayerStats are the goal and appearance figures of one player
+// Appearances and minutes are derived from the match timeline, see the README for the rules
+
+type PlayerStats struct {
+	Player         string   `json:"player"`
+	Team           string   `json:"team"` // Team the player last played for
+	Goals          int      `json:"goals"`
+	PenaltyGoals   int      `json:"penalty_goals"`
+	OwnGoals       int      `json:"own_goals"` // Not counted in goals
+	Assists        int      `json:"assists"`
+	YellowCards    int      `json:"yellow_cards"`
+	RedCards       int      `json:"red_cards"`
+	Appearances    int      `json:"appearances"`
+	Minutes        int      `json:"minutes"`
+	MinutesPerGoal *float64 `json:"minutes_per_goal,omitempty"` // Empty without goals
+}
+
+// TeamGoals are the goals scored and conceded by one team
+type TeamGoals struct {
+	Team          string  `json:"team"`
+	Played        int     `json:"played"`
+	GoalsFor      int     `json:"goals_for"`
+	GoalsAgainst  int     `json:"goals_against"`
+	GoalsPerMatch float64 `json:"goals_per_match"`
+}
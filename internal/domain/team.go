@@ -4,14 +4,17 @@ import "time"
 
 // Team represents a football team under company XYZ
 // Fields: name, logo, year founded, stadium address, city
-// All fields are required for registration
+// All fields are required for registration, Validate checks them. The ID and slug are assigned on
+// registration and never change, so they keep identifying the team after a rename
 
 type Team struct {
-	Name        string     `json:"name" binding:"required"`
-	Logo        string     `json:"logo" binding:"required"`
-	YearFounded int        `json:"year_founded" binding:"required"`
-	StadiumAddr string     `json:"stadium_addr" binding:"required"`
-	City        string     `json:"city" binding:"required"`
+	ID          int        `json:"id"`
+	Slug        string     `json:"slug"`
+	Name        string     `json:"name"`
+	Logo        string     `json:"logo"`
+	YearFounded int        `json:"year_founded"`
+	StadiumAddr string     `json:"stadium_addr"`
+	City        string     `json:"city"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
@@ -0,0 +1,280 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Transfer represents a player moving from one team to another
+// Fields: player, selling team, buying team, date, fee, type, loan end date, jersey number at the new team
+// The selling team is always taken from the player's contracts, never from the request
+
+type TransferType string
+
+const (
+	TransferPermanent  TransferType = "permanent"
+	TransferLoan       TransferType = "loan"
+	TransferLoanReturn TransferType = "loan_return" // Ends a loan and sends the player back to the parent team
+)
+
+// ValidTransferType reports whether t is one of the known transfer types
+func ValidTransferType(t TransferType) bool {
+	switch t {
+	case TransferPermanent, TransferLoan, TransferLoanReturn:
+		return true
+	}
+	return false
+}
+
+type Transfer struct {
+	ID           int          `json:"id"`
+	Player       string       `json:"player"`
+	FromTeam     string       `json:"from_team"`
+	ToTeam       string       `json:"to_team"`
+	TransferDate time.Time    `json:"transfer_date"`
+	Fee          int64        `json:"fee"`
+	Type         TransferType `json:"type"`
+	LoanEndDate  *time.Time   `json:"loan_end_date,omitempty"`
+	JerseyNumber int          `json:"jersey_number"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+// Validate checks the parts of a transfer that do not depend on the player's current contracts
+func (t *Transfer) Validate() error {
+	if !ValidTransferType(t.Type) {
+		return errors.New("invalid transfer type. Use permanent, loan or loan_return")
+	}
+	if t.Fee < 0 {
+		return errors.New("transfer fee cannot be negative")
+	}
+	if t.JerseyNumber < 1 {
+		return errors.New("jersey number must be positive")
+	}
+
+	switch t.Type {
+	case TransferLoan:
+		if t.LoanEndDate == nil {
+			return errors.New("a loan needs a loan end date")
+		}
+		if !t.LoanEndDate.After(t.TransferDate) {
+			return errors.New("loan end date must be after the transfer date")
+		}
+	case TransferLoanReturn:
+		if t.LoanEndDate != nil {
+			return errors.New("a loan return cannot have a loan end date")
+		}
+		if t.Fee != 0 {
+			return errors.New("a loan return cannot have a fee")
+		}
+	default:
+		if t.LoanEndDate != nil {
+			return errors.New("only a loan can have a loan end date")
+		}
+	}
+
+	if t.ToTeam == "" && t.Type != TransferLoanReturn {
+		return errors.New("to team is required")
+	}
+	return nil
+}
+
+type ContractType string
+
+const (
+	ContractPermanent ContractType = "permanent"
+	ContractLoan      ContractType = "loan"
+)
+
+// Contract is one spell of a player at a team. A player on loan has two current contracts:
+// the permanent one with the parent team and the loan
+type Contract struct {
+	ID          int          `json:"id"`
+	Player      string       `json:"player"`
+	Team        string       `json:"team"`
+	Type        ContractType `json:"type"`
+	StartDate   time.Time    `json:"start_date"`
+	EndDate     *time.Time   `json:"end_date,omitempty"`      // Set once the player has left
+	LoanEndDate *time.Time   `json:"loan_end_date,omitempty"` // Agreed end of a loan
+	TransferID  *int         `json:"transfer_id,omitempty"`   // Empty for the contract the player was registered with
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// Current reports whether the player is still under the contract
+func (c *Contract) Current() bool {
+	return c.EndDate == nil
+}
+
+// TransferWindow is a period of a season in which players may move between teams
+type TransferWindow struct {
+	ID        int        `json:"id"`
+	SeasonID  int        `json:"season_id"`
+	Name      string     `json:"name"`
+	StartDate time.Time  `json:"start_date"`
+	EndDate   time.Time  `json:"end_date"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// TransferRequest represents the request structure for transferring a player
+type TransferRequest struct {
+	Player       string       `json:"player" binding:"required"`        // ID or slug of the player
+	ToTeam       string       `json:"to_team"`                          // Defaults to the parent team for a loan return
+	TransferDate string       `json:"transfer_date" binding:"required"` // Format: "YYYY-MM-DD"
+	Fee          int64        `json:"fee"`                              // In whole currency units, 0 for a free transfer
+	Type         TransferType `json:"type" binding:"required"`          // permanent, loan or loan_return
+	LoanEndDate  string       `json:"loan_end_date,omitempty"`          // Format: "YYYY-MM-DD", only for a loan
+	JerseyNumber int          `json:"jersey_number" binding:"required"` // Number at the new team
+}
+
+// ToTransfer converts TransferRequest to Transfer domain model
+func (tr *TransferRequest) ToTransfer() (*Transfer, error) {
+	transferDate, err := time.Parse("2006-01-02", tr.TransferDate)
+	if err != nil {
+		return nil, errors.New("invalid transfer date format. Use YYYY-MM-DD")
+	}
+
+	transfer := &Transfer{
+		Player:       tr.Player,
+		ToTeam:       tr.ToTeam,
+		TransferDate: transferDate,
+		Fee:          tr.Fee,
+		Type:         tr.Type,
+		JerseyNumber: tr.JerseyNumber,
+	}
+	if tr.LoanEndDate != "" {
+		loanEndDate, err := time.Parse("2006-01-02", tr.LoanEndDate)
+		if err != nil {
+			return nil, errors.New("invalid loan end date format. Use YYYY-MM-DD")
+		}
+		transfer.LoanEndDate = &loanEndDate
+	}
+	return transfer, transfer.Validate()
+}
+
+// TransferResponse represents the response structure for transfers
+type TransferResponse struct {
+	ID           int          `json:"id"`
+	Player       string       `json:"player"`
+	FromTeam     string       `json:"from_team"`
+	ToTeam       string       `json:"to_team"`
+	TransferDate string       `json:"transfer_date"` // Format: "YYYY-MM-DD"
+	Fee          int64        `json:"fee"`
+	Type         TransferType `json:"type"`
+	LoanEndDate  *string      `json:"loan_end_date,omitempty"` // Format: "YYYY-MM-DD"
+	JerseyNumber int          `json:"jersey_number"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+// ToTransferResponse converts Transfer domain model to TransferResponse
+func (t *Transfer) ToTransferResponse() *TransferResponse {
+	return &TransferResponse{
+		ID:           t.ID,
+		Player:       t.Player,
+		FromTeam:     t.FromTeam,
+		ToTeam:       t.ToTeam,
+		TransferDate: t.TransferDate.Format("2006-01-02"),
+		Fee:          t.Fee,
+		Type:         t.Type,
+		LoanEndDate:  formatOptionalDate(t.LoanEndDate),
+		JerseyNumber: t.JerseyNumber,
+		CreatedAt:    t.CreatedAt,
+	}
+}
+
+// ContractResponse represents the response structure for contracts
+type ContractResponse struct {
+	ID          int          `json:"id"`
+	Player      string       `json:"player"`
+	Team        string       `json:"team"`
+	Type        ContractType `json:"type"`
+	StartDate   string       `json:"start_date"`              // Format: "YYYY-MM-DD"
+	EndDate     *string      `json:"end_date,omitempty"`      // Format: "YYYY-MM-DD"
+	LoanEndDate *string      `json:"loan_end_date,omitempty"` // Format: "YYYY-MM-DD"
+	TransferID  *int         `json:"transfer_id,omitempty"`
+	Current     bool         `json:"current"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// ToContractResponse converts Contract domain model to ContractResponse
+func (c *Contract) ToContractResponse() *ContractResponse {
+	return &ContractResponse{
+		ID:          c.ID,
+		Player:      c.Player,
+		Team:        c.Team,
+		Type:        c.Type,
+		StartDate:   c.StartDate.Format("2006-01-02"),
+		EndDate:     formatOptionalDate(c.EndDate),
+		LoanEndDate: formatOptionalDate(c.LoanEndDate),
+		TransferID:  c.TransferID,
+		Current:     c.Current(),
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
+
+// TransferWindowRequest represents the request structure for opening a transfer window
+type TransferWindowRequest struct {
+	SeasonID  int    `json:"season_id" binding:"required"`
+	Name      string `json:"name" binding:"required"`       // e.g. "Summer" or "January"
+	StartDate string `json:"start_date" binding:"required"` // Format: "YYYY-MM-DD"
+	EndDate   string `json:"end_date" binding:"required"`   // Format: "YYYY-MM-DD"
+}
+
+// ToTransferWindow converts TransferWindowRequest to TransferWindow domain model
+func (wr *TransferWindowRequest) ToTransferWindow() (*TransferWindow, error) {
+	startDate, err := time.Parse("2006-01-02", wr.StartDate)
+	if err != nil {
+		return nil, errors.New("invalid start date format. Use YYYY-MM-DD")
+	}
+	endDate, err := time.Parse("2006-01-02", wr.EndDate)
+	if err != nil {
+		return nil, errors.New("invalid end date format. Use YYYY-MM-DD")
+	}
+	if endDate.Before(startDate) {
+		return nil, errors.New("end date cannot be before start date")
+	}
+
+	return &TransferWindow{
+		SeasonID:  wr.SeasonID,
+		Name:      wr.Name,
+		StartDate: startDate,
+		EndDate:   endDate,
+	}, nil
+}
+
+// TransferWindowResponse represents the response structure for transfer windows
+type TransferWindowResponse struct {
+	ID        int        `json:"id"`
+	SeasonID  int        `json:"season_id"`
+	Name      string     `json:"name"`
+	StartDate string     `json:"start_date"` // Format: "YYYY-MM-DD"
+	EndDate   string     `json:"end_date"`   // Format: "YYYY-MM-DD"
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// ToTransferWindowResponse converts TransferWindow domain model to TransferWindowResponse
+func (w *TransferWindow) ToTransferWindowResponse() *TransferWindowResponse {
+	return &TransferWindowResponse{
+		ID:        w.ID,
+		SeasonID:  w.SeasonID,
+		Name:      w.Name,
+		StartDate: w.StartDate.Format("2006-01-02"),
+		EndDate:   w.EndDate.Format("2006-01-02"),
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: w.UpdatedAt,
+		DeletedAt: w.DeletedAt,
+	}
+}
+
+func formatOptionalDate(date *time.Time) *string {
+	if date == nil {
+		return nil
+	}
+	formatted := date.Format("2006-01-02")
+	return &formatted
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferRequestToTransfer(t *testing.T) {
+	request := func(transferType TransferType, loanEndDate string) *TransferRequest {
+		return &TransferRequest{Player: "Andi", ToTeam: "Persija", TransferDate: "2024-07-01", Type: transferType, LoanEndDate: loanEndDate, JerseyNumber: 10}
+	}
+
+	t.Run("Loan with an end date", func(t *testing.T) {
+		transfer, err := request(TransferLoan, "2025-06-30").ToTransfer()
+		require.NoError(t, err)
+		assert.Equal(t, "2025-06-30", transfer.LoanEndDate.Format("2006-01-02"))
+	})
+
+	t.Run("Loan needs an end date after the transfer", func(t *testing.T) {
+		_, err := request(TransferLoan, "").ToTransfer()
+		assert.EqualError(t, err, "a loan needs a loan end date")
+
+		_, err = request(TransferLoan, "2024-07-01").ToTransfer()
+		assert.EqualError(t, err, "loan end date must be after the transfer date")
+	})
+
+	t.Run("Only loans have an end date", func(t *testing.T) {
+		_, err := request(TransferPermanent, "2025-06-30").ToTransfer()
+		assert.EqualError(t, err, "only a loan can have a loan end date")
+	})
+
+	t.Run("Rejects unknown types and negative fees", func(t *testing.T) {
+		_, err := request("swap", "").ToTransfer()
+		assert.Error(t, err)
+
+		negative := request(TransferPermanent, "")
+		negative.Fee = -1
+		_, err = negative.ToTransfer()
+		assert.EqualError(t, err, "transfer fee cannot be negative")
+	})
+
+	t.Run("Loan return may leave out the team", func(t *testing.T) {
+		loanReturn := request(TransferLoanReturn, "")
+		loanReturn.ToTeam = ""
+		_, err := loanReturn.ToTransfer()
+		assert.NoError(t, err)
+
+		permanent := request(TransferPermanent, "")
+		permanent.ToTeam = ""
+		_, err = permanent.ToTransfer()
+		assert.EqualError(t, err, "to team is required")
+	})
+}
@@ -0,0 +1,71 @@
+package domain
+
+import "time"
+
+// DeletedFilter says whether a list shows soft-deleted records. Lists leave them out by default
+type DeletedFilter string
+
+const (
+	ExcludeDeleted DeletedFilter = ""
+	IncludeDeleted DeletedFilter = "include"
+	OnlyDeleted    DeletedFilter = "only"
+)
+
+// Shows reports whether a record deleted at deletedAt, nil if it is active, belongs in the list
+func (f DeletedFilter) Shows(deletedAt *time.Time) bool {
+	switch f {
+	case IncludeDeleted:
+		return true
+	case OnlyDeleted:
+		return deletedAt != nil
+	}
+	return deletedAt == nil
+}
+
+// Kinds of soft-deleted records held in the trash
+const (
+	TrashTeam        = "team"
+	TrashPlayer      = "player"
+	TrashMatch       = "match"
+	TrashMatchResult = "match_result"
+)
+
+// TrashTypes lists the kinds of records in the trash, in the order the retention job purges them, so
+// results go before their matches and players before their teams
+var TrashTypes = []string{TrashMatchResult, TrashMatch, TrashPlayer, TrashTeam}
+
+// ValidTrashType reports whether itemType is one of TrashTypes
+func ValidTrashType(itemType string) bool {
+	for _, t := range TrashTypes {
+		if t == itemType {
+			return true
+		}
+	}
+	return false
+}
+
+// TrashItem is a soft-deleted record. Matches and results are named after their teams, e.g. "Persija vs Persib"
+type TrashItem struct {
+	Type      string    `json:"type"`
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// TrashSortFields are the fields the trash can be sorted by, most recently deleted first by default
+var TrashSortFields = []string{"deleted_at", "type", "name", "id"}
+
+const DefaultTrashSort = "-deleted_at"
+
+// TrashQuery selects a page of the trash, only records of Type when it is set
+type TrashQuery struct {
+	Type string
+	PageRequest
+}
+
+// PurgeReport counts per kind the records a retention run removed for good, and those it kept because
+// other records still refer to them, such as a deleted team that still has players
+type PurgeReport struct {
+	Purged map[string]int `json:"purged"`
+	Kept   map[string]int `json:"kept"`
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeletedFilterShows(t *testing.T) {
+	deletedAt := time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.True(t, ExcludeDeleted.Shows(nil))
+	assert.False(t, ExcludeDeleted.Shows(&deletedAt))
+	assert.True(t, IncludeDeleted.Shows(nil))
+	assert.True(t, IncludeDeleted.Shows(&deletedAt))
+	assert.False(t, OnlyDeleted.Shows(nil))
+	assert.True(t, OnlyDeleted.Shows(&deletedAt))
+}
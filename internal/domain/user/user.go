@@ -1,17 +1,116 @@
 package user
 
 import (
+	"time"
+
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// Roles that can be assigned to a user account
+// Admins have global rights, team managers may only manage the teams assigned to them
+const (
+	RoleAdmin       = "admin"
+	RoleTeamManager = "team_manager"
+	RoleUser        = "user"
+)
+
+// ValidRole reports whether role is one of the supported roles
+func ValidRole(role string) bool {
+	switch role {
+	case RoleAdmin, RoleTeamManager, RoleUser:
+		return true
+	}
+	return false
+}
+
+// User represents an account that can log in to the API
+// Password holds the bcrypt hash, never the plain text password
+
 type User struct {
-	Username string `json:"username" binding:"required"`
-	Password string `json:"password" binding:"required"`
-	Role     string `json:"role"`
+	Username  string     `json:"username" binding:"required"`
+	Password  string     `json:"password" binding:"required"`
+	Role      string     `json:"role"`
+	Teams     []string   `json:"teams,omitempty"` // Teams a team manager is responsible for
+	Disabled  bool       `json:"disabled"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// UserRequest represents the request structure for creating users
+type UserRequest struct {
+	Username string   `json:"username" binding:"required"`
+	Password string   `json:"password" binding:"required"`
+	Role     string   `json:"role" binding:"required"`
+	Teams    []string `json:"teams,omitempty"`
+}
+
+// UserTeamsRequest represents the request structure for assigning teams to a team manager
+type UserTeamsRequest struct {
+	Teams []string `json:"teams" binding:"required"`
 }
 
+// UserResponse represents the response structure for users, without the password hash
+type UserResponse struct {
+	Username  string     `json:"username"`
+	Role      string     `json:"role"`
+	Teams     []string   `json:"teams,omitempty"`
+	Disabled  bool       `json:"disabled"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// ToUserResponse converts User to UserResponse
+func (u *User) ToUserResponse() *UserResponse {
+	return &UserResponse{
+		Username:  u.Username,
+		Role:      u.Role,
+		Teams:     u.Teams,
+		Disabled:  u.Disabled,
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
+		DeletedAt: u.DeletedAt,
+	}
+}
+
+// Claims are the JWT access token claims. SessionID ties the token to the
+// login session it was issued for and RegisteredClaims.ID carries the jti
 type Claims struct {
-	Username string `json:"username"`
-	Role     string `json:"role"`
+	Username  string   `json:"username"`
+	Role      string   `json:"role"`
+	Teams     []string `json:"teams,omitempty"`
+	SessionID string   `json:"sid"`
 	jwt.RegisteredClaims
 }
+
+// CanManageTeam reports whether the token holder may modify data belonging to team
+func (c *Claims) CanManageTeam(team string) bool {
+	switch c.Role {
+	case RoleAdmin:
+		return true
+	case RoleTeamManager:
+		for _, t := range c.Teams {
+			if t == team {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Session represents a login session that refresh tokens are issued under
+type Session struct {
+	ID        string     `json:"id"`
+	Username  string     `json:"username"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// TokenPair is returned on login and refresh
+type TokenPair struct {
+	AccessToken  string    `json:"token"`
+	RefreshToken string    `json:"refresh_token"`
+	Type         string    `json:"type"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
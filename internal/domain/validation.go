@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Ranges accepted for request fields
+const (
+	MinJerseyNumber = 1
+	MaxJerseyNumber = 99
+	MinHeight       = 140 // in cm
+	MaxHeight       = 230
+	MinWeight       = 40 // in kg
+	MaxWeight       = 150
+	MinYearFounded  = 1857 // Sheffield FC, the oldest club still playing
+)
+
+// FieldError describes why the value of one request field was rejected
+type FieldError struct {
+	Field   string `json:"field"` // JSON name of the field, e.g. "jersey_number" or "goals[1].goal_time"
+	Message string `json:"message"`
+}
+
+// ValidationError lists every rejected field of a request, so a client can fix them all at once
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Field+" "+field.Message)
+	}
+	return "invalid fields: " + strings.Join(messages, "; ")
+}
+
+// fieldErrors collects the rejected fields of a request while it is checked
+type fieldErrors []FieldError
+
+func (f *fieldErrors) add(field, format string, args ...any) {
+	*f = append(*f, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (f *fieldErrors) required(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		f.add(field, "is required")
+	}
+}
+
+func (f *fieldErrors) between(field string, value, min, max int) {
+	if value < min || value > max {
+		f.add(field, "must be between %d and %d", min, max)
+	}
+}
+
+// err returns a *ValidationError listing the collected fields, or nil if there are none
+func (f fieldErrors) err() error {
+	if len(f) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: f}
+}
+
+// validClock reports whether value is a time of day in HH:MM format. time.Parse alone accepts a single
+// digit hour such as "9:30"
+func validClock(value string) bool {
+	_, err := time.Parse("15:04", value)
+	return err == nil && len(value) == len("15:04")
+}
+
+// ValidPosition reports whether position is one of the known player positions
+func ValidPosition(position PlayerPosition) bool {
+	switch position {
+	case PositionForward, PositionMidfielder, PositionDefender, PositionGoalkeeper:
+		return true
+	}
+	return false
+}
+
+// Validate checks the fields of a team sent for registration or update. A team cannot be founded after
+// the year of now
+func (t *Team) Validate(now time.Time) error {
+	var errs fieldErrors
+	errs.required("name", t.Name)
+	errs.required("logo", t.Logo)
+	errs.between("year_founded", t.YearFounded, MinYearFounded, now.Year())
+	errs.required("stadium_addr", t.StadiumAddr)
+	errs.required("city", t.City)
+	return errs.err()
+}
+
+// Validate checks the fields of a player sent for registration or update
+func (p *Player) Validate() error {
+	var errs fieldErrors
+	errs.required("name", p.Name)
+	errs.between("height", p.Height, MinHeight, MaxHeight)
+	errs.between("weight", p.Weight, MinWeight, MaxWeight)
+	if !ValidPosition(p.Position) {
+		errs.add("position", "must be one of %s, %s, %s or %s", PositionForward, PositionMidfielder, PositionDefender, PositionGoalkeeper)
+	}
+	errs.between("jersey_number", p.JerseyNumber, MinJerseyNumber, MaxJerseyNumber)
+	errs.required("team_name", p.TeamName)
+	if p.ContractStart != "" {
+		if _, err := time.Parse("2006-01-02", p.ContractStart); err != nil {
+			errs.add("contract_start", "must be a date in YYYY-MM-DD format")
+		}
+	}
+	return errs.err()
+}
+
+// Validate checks the fields of a match sent for registration or update
+func (mr *MatchRequest) Validate() error {
+	var errs fieldErrors
+	if _, err := time.Parse("2006-01-02", mr.MatchDate); err != nil {
+		errs.add("match_date", "must be a date in YYYY-MM-DD format")
+	}
+	if !validClock(mr.MatchTime) {
+		errs.add("match_time", "must be a time in HH:MM format")
+	}
+	errs.required("home_team", mr.HomeTeam)
+	errs.required("away_team", mr.AwayTeam)
+	if mr.HomeTeam != "" && mr.HomeTeam == mr.AwayTeam {
+		errs.add("away_team", "must differ from home_team")
+	}
+	if mr.SeasonID != nil && *mr.SeasonID < 1 {
+		errs.add("season_id", "must be a positive number")
+	}
+	return errs.err()
+}
+
+// Validate checks the fields of a result sent for reporting or update. Whether the goals add up to the
+// score and the order of the shootout kicks are left to MatchResult.Validate, the goals may still be
+// taken from the match timeline
+func (mr *MatchResultRequest) Validate() error {
+	var errs fieldErrors
+	if mr.MatchID < 1 {
+		errs.add("match_id", "must be a positive number")
+	}
+	if mr.HomeScore < 0 {
+		errs.add("home_score", "cannot be negative")
+	}
+	if mr.AwayScore < 0 {
+		errs.add("away_score", "cannot be negative")
+	}
+	for i, goal := range mr.Goals {
+		field := fmt.Sprintf("goals[%d].", i)
+		errs.required(field+"scorer", goal.Scorer)
+		if _, err := matchClock(goal.GoalTime); err != nil {
+			errs.add(field+"goal_time", "must be a time of play in MM:SS or HH:MM:SS format")
+		}
+		if goal.Team != "home" && goal.Team != "away" {
+			errs.add(field+"team", "must be home or away")
+		}
+		if goal.Period != "" && !ValidPeriod(goal.Period) {
+			errs.add(field+"period", "must be one of %s, %s or %s", PeriodFirstHalf, PeriodSecondHalf, PeriodExtraTime)
+		}
+		if goal.Type != "" && !ValidGoalType(goal.Type) {
+			errs.add(field+"type", "must be one of %s, %s or %s", GoalOpenPlay, GoalPenalty, GoalOwnGoal)
+		}
+		if goal.Type == GoalOwnGoal && goal.Assist != "" {
+			errs.add(field+"assist", "must be empty for an own goal")
+		}
+	}
+	for i, kick := range mr.Shootout {
+		field := fmt.Sprintf("shootout[%d].", i)
+		if kick.Order < 1 {
+			errs.add(field+"order", "must be a positive number")
+		}
+		if kick.Team != "home" && kick.Team != "away" {
+			errs.add(field+"team", "must be home or away")
+		}
+		errs.required(field+"taker", kick.Taker)
+	}
+	return errs.err()
+}
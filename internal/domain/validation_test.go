@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fieldsOf returns the names of the fields rejected by err
+func fieldsOf(t *testing.T, err error) []string {
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	fields := make([]string, 0, len(validationErr.Fields))
+	for _, field := range validationErr.Fields {
+		fields = append(fields, field.Field)
+	}
+	return fields
+}
+
+func TestTeamValidate(t *testing.T) {
+	now := time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+	team := Team{Name: "Persija", Logo: "persija.png", YearFounded: 1928, StadiumAddr: "Jakarta International Stadium", City: "Jakarta"}
+
+	t.Run("Accepts a complete team", func(t *testing.T) {
+		assert.NoError(t, team.Validate(now))
+	})
+
+	t.Run("Lists every missing field", func(t *testing.T) {
+		empty := Team{Name: "  "}
+		assert.Equal(t, []string{"name", "logo", "year_founded", "stadium_addr", "city"}, fieldsOf(t, empty.Validate(now)))
+	})
+
+	t.Run("Founded year cannot be in the future", func(t *testing.T) {
+		future := team
+		future.YearFounded = 2025
+		err := future.Validate(now)
+		assert.EqualError(t, err, "invalid fields: year_founded must be between 1857 and 2024")
+
+		future.YearFounded = 2024
+		assert.NoError(t, future.Validate(now))
+	})
+}
+
+func TestPlayerValidate(t *testing.T) {
+	player := Player{Name: "Andi", Height: 175, Weight: 70, Position: PositionMidfielder, JerseyNumber: 8, TeamName: "Persija"}
+
+	t.Run("Accepts a complete player", func(t *testing.T) {
+		assert.NoError(t, player.Validate())
+	})
+
+	t.Run("Jersey numbers run from 1 to 99", func(t *testing.T) {
+		for _, number := range []int{0, 100} {
+			invalid := player
+			invalid.JerseyNumber = number
+			assert.EqualError(t, invalid.Validate(), "invalid fields: jersey_number must be between 1 and 99")
+		}
+	})
+
+	t.Run("Rejects unrealistic sizes and unknown positions at once", func(t *testing.T) {
+		invalid := player
+		invalid.Height, invalid.Weight, invalid.Position = 17, 700, "striker"
+		assert.Equal(t, []string{"height", "weight", "position"}, fieldsOf(t, invalid.Validate()))
+	})
+
+	t.Run("Contract start is an optional date", func(t *testing.T) {
+		joined := player
+		joined.ContractStart = "2024-01-01"
+		assert.NoError(t, joined.Validate())
+		assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), joined.FirstContractStart(time.Now()))
+
+		joined.ContractStart = "01/01/2024"
+		assert.EqualError(t, joined.Validate(), "invalid fields: contract_start must be a date in YYYY-MM-DD format")
+	})
+}
+
+func TestMatchRequestValidate(t *testing.T) {
+	request := MatchRequest{MatchDate: "2024-08-10", MatchTime: "19:00", HomeTeam: "Persija", AwayTeam: "Persib"}
+
+	t.Run("Accepts a complete match", func(t *testing.T) {
+		assert.NoError(t, request.Validate())
+	})
+
+	t.Run("Checks date and time formats", func(t *testing.T) {
+		invalid := request
+		invalid.MatchDate, invalid.MatchTime = "10-08-2024", "7pm"
+		assert.Equal(t, []string{"match_date", "match_time"}, fieldsOf(t, invalid.Validate()))
+
+		invalid.MatchTime = "25:00"
+		assert.Contains(t, fieldsOf(t, invalid.Validate()), "match_time")
+
+		invalid.MatchDate, invalid.MatchTime = request.MatchDate, "9:30"
+		assert.Equal(t, []string{"match_time"}, fieldsOf(t, invalid.Validate()))
+	})
+
+	t.Run("A team cannot play itself", func(t *testing.T) {
+		invalid := request
+		invalid.AwayTeam = "Persija"
+		assert.EqualError(t, invalid.Validate(), "invalid fields: away_team must differ from home_team")
+	})
+}
+
+func TestMatchResultRequestValidate(t *testing.T) {
+	t.Run("Accepts a goalless draw", func(t *testing.T) {
+		request := MatchResultRequest{MatchID: 1}
+		assert.NoError(t, request.Validate())
+	})
+
+	t.Run("Rejects negative scores and broken goals", func(t *testing.T) {
+		request := MatchResultRequest{
+			MatchID:   1,
+			HomeScore: -1,
+			AwayScore: 1,
+			Goals:     []Goal{{Scorer: "Ciro", GoalTime: "30:00", Team: "away"}, {GoalTime: "half time", Team: "visitors"}},
+		}
+		assert.Equal(t, []string{"home_score", "goals[1].scorer", "goals[1].goal_time", "goals[1].team"}, fieldsOf(t, request.Validate()))
+	})
+
+	t.Run("Names the goal and kick fields it rejects", func(t *testing.T) {
+		request := MatchResultRequest{
+			MatchID:   1,
+			HomeScore: 1,
+			AwayScore: 1,
+			Goals: []Goal{
+				{Scorer: "Riko", GoalTime: "10:00", Team: "home", Period: "overtime"},
+				{Scorer: "Ciro", GoalTime: "80:00", Team: "away", Type: GoalOwnGoal, Assist: "Riko"},
+				{Scorer: "Ciro", GoalTime: "85:00", Team: "away", Type: "header"},
+			},
+			Shootout: []ShootoutKick{{Order: 0, Team: "visitors", Taker: " "}},
+		}
+		assert.Equal(t, []string{"goals[0].period", "goals[1].assist", "goals[2].type", "shootout[0].order", "shootout[0].team", "shootout[0].taker"}, fieldsOf(t, request.Validate()))
+	})
+
+	t.Run("The match is required", func(t *testing.T) {
+		request := MatchResultRequest{}
+		assert.EqualError(t, request.Validate(), "invalid fields: match_id must be a positive number")
+	})
+}
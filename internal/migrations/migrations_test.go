@@ -0,0 +1,71 @@
+package migrations
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	file := func(contents string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte(contents)}
+	}
+
+	t.Run("Embedded migrations are valid", func(t *testing.T) {
+		migrations, err := load(embedded)
+		require.NoError(t, err)
+		require.NotEmpty(t, migrations)
+		assert.Equal(t, "initial_schema", migrations[0].Name)
+		assert.Contains(t, migrations[0].Up, "CREATE TABLE teams")
+	})
+
+	t.Run("Migrations are sorted by version", func(t *testing.T) {
+		migrations, err := load(fstest.MapFS{
+			"sql/0002_add_index.up.sql":   file("CREATE INDEX"),
+			"sql/0002_add_index.down.sql": file("DROP INDEX"),
+			"sql/0001_init.up.sql":        file("CREATE TABLE"),
+			"sql/0001_init.down.sql":      file("DROP TABLE"),
+		})
+		require.NoError(t, err)
+		require.Len(t, migrations, 2)
+		assert.Equal(t, Migration{Version: 1, Name: "init", Up: "CREATE TABLE", Down: "DROP TABLE"}, migrations[0])
+		assert.Equal(t, 2, migrations[1].Version)
+	})
+
+	t.Run("Every migration needs a down file", func(t *testing.T) {
+		_, err := load(fstest.MapFS{"sql/0001_init.up.sql": file("CREATE TABLE")})
+		assert.EqualError(t, err, "migration 0001_init needs both an up and a down file")
+	})
+
+	t.Run("Versions have no gaps", func(t *testing.T) {
+		_, err := load(fstest.MapFS{
+			"sql/0001_init.up.sql":        file("CREATE TABLE"),
+			"sql/0001_init.down.sql":      file("DROP TABLE"),
+			"sql/0003_add_index.up.sql":   file("CREATE INDEX"),
+			"sql/0003_add_index.down.sql": file("DROP INDEX"),
+		})
+		assert.EqualError(t, err, "migration versions must run from 1 without gaps, found 3 where 2 was expected")
+	})
+
+	t.Run("File names are checked", func(t *testing.T) {
+		_, err := load(fstest.MapFS{"sql/init.sql": file("CREATE TABLE")})
+		assert.Error(t, err)
+
+		_, err = load(fstest.MapFS{
+			"sql/0001_init.up.sql":      file("CREATE TABLE"),
+			"sql/0001_initial.down.sql": file("DROP TABLE"),
+		})
+		assert.EqualError(t, err, "migration 1 has two names: init and initial")
+	})
+}
+
+func TestPending(t *testing.T) {
+	migrations := []Migration{{Version: 1}, {Version: 2}, {Version: 3}}
+
+	assert.Equal(t, migrations, pending(migrations, map[int]time.Time{}))
+	assert.Equal(t, []Migration{{Version: 3}}, pending(migrations, map[int]time.Time{1: time.Now(), 2: time.Now()}))
+	assert.Empty(t, pending(migrations, map[int]time.Time{1: time.Now(), 2: time.Now(), 3: time.Now()}))
+}
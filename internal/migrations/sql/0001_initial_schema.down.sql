@@ -0,0 +1,20 @@
+DROP TABLE contracts;
+DROP TABLE transfers;
+DROP TABLE transfer_windows;
+DROP TABLE team_ratings;
+DROP TABLE rating_history;
+DROP TABLE shootout_kicks;
+DROP TABLE match_events;
+DROP TABLE match_results;
+DROP TABLE bracket_ties;
+DROP TABLE brackets;
+DROP TABLE matches;
+DROP TABLE seasons;
+DROP TABLE competitions;
+DROP TABLE players;
+DROP TABLE user_teams;
+DROP TABLE teams;
+DROP TABLE revoked_tokens;
+DROP TABLE refresh_tokens;
+DROP TABLE sessions;
+DROP TABLE users;
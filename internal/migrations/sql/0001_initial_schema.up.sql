@@ -0,0 +1,235 @@
+CREATE TABLE users (
+    username TEXT PRIMARY KEY,
+    password_hash TEXT NOT NULL,
+    role TEXT NOT NULL CHECK (role IN ('admin', 'team_manager', 'user')),
+    disabled BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP
+);
+
+CREATE TABLE sessions (
+    id TEXT PRIMARY KEY,
+    username TEXT NOT NULL REFERENCES users(username),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMP
+);
+
+CREATE TABLE refresh_tokens (
+    token_hash TEXT PRIMARY KEY,
+    session_id TEXT NOT NULL REFERENCES sessions(id),
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE revoked_tokens (
+    jti TEXT PRIMARY KEY,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE teams (
+    id SERIAL PRIMARY KEY,
+    slug TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL UNIQUE,
+    logo TEXT NOT NULL,
+    year_founded INT NOT NULL,
+    stadium_addr TEXT NOT NULL,
+    city TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP
+);
+
+CREATE TABLE user_teams (
+    username TEXT NOT NULL REFERENCES users(username),
+    team_id INT NOT NULL REFERENCES teams(id),
+    PRIMARY KEY (username, team_id)
+);
+
+CREATE TABLE players (
+    id SERIAL PRIMARY KEY,
+    slug TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL UNIQUE,
+    height INT NOT NULL,
+    weight INT NOT NULL,
+    position TEXT NOT NULL,
+    jersey_number INT NOT NULL,
+    team_id INT NOT NULL REFERENCES teams(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP,
+    UNIQUE(team_id, jersey_number)
+);
+
+CREATE TABLE competitions (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    type TEXT NOT NULL CHECK (type IN ('league', 'cup')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP
+);
+
+CREATE TABLE seasons (
+    id SERIAL PRIMARY KEY,
+    competition_id INT NOT NULL REFERENCES competitions(id),
+    name TEXT NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP,
+    CHECK (end_date >= start_date)
+);
+
+CREATE TABLE matches (
+    id SERIAL PRIMARY KEY,
+    match_date DATE NOT NULL,
+    match_time TIME NOT NULL,
+    home_team_id INT NOT NULL REFERENCES teams(id),
+    away_team_id INT NOT NULL REFERENCES teams(id),
+    season_id INT REFERENCES seasons(id),
+    status TEXT NOT NULL DEFAULT 'scheduled' CHECK (status IN ('scheduled', 'live', 'finished', 'postponed', 'abandoned', 'cancelled')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP,
+    CHECK (home_team_id != away_team_id)
+);
+
+CREATE TABLE brackets (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    season_id INT REFERENCES seasons(id),
+    size INT NOT NULL,
+    start_date DATE NOT NULL,
+    interval_days INT NOT NULL,
+    kick_off_time TIME NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP
+);
+
+CREATE TABLE bracket_ties (
+    id SERIAL PRIMARY KEY,
+    bracket_id INT NOT NULL REFERENCES brackets(id),
+    round INT NOT NULL,
+    position INT NOT NULL,
+    home_team_id INT REFERENCES teams(id),
+    away_team_id INT REFERENCES teams(id),
+    home_seed INT,
+    away_seed INT,
+    match_id INT REFERENCES matches(id),
+    winner_id INT REFERENCES teams(id),
+    bye BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE(bracket_id, round, position),
+    UNIQUE(match_id)
+);
+
+CREATE TABLE match_results (
+    id SERIAL PRIMARY KEY,
+    match_id INT NOT NULL REFERENCES matches(id),
+    home_score INT NOT NULL,
+    away_score INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP,
+    UNIQUE(match_id)
+);
+
+CREATE TABLE match_events (
+    id SERIAL PRIMARY KEY,
+    match_id INT NOT NULL REFERENCES matches(id),
+    event_type TEXT NOT NULL CHECK (event_type IN ('goal', 'yellow_card', 'red_card', 'substitution')),
+    event_time TEXT NOT NULL,
+    elapsed_seconds INT NOT NULL,
+    period TEXT NOT NULL CHECK (period IN ('first_half', 'second_half', 'extra_time')),
+    team TEXT NOT NULL CHECK (team IN ('home', 'away')),
+    player TEXT NOT NULL,
+    goal_type TEXT CHECK (goal_type IN ('open_play', 'penalty', 'own_goal')),
+    assist TEXT,
+    player_in TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP,
+    CHECK ((event_type = 'goal') = (goal_type IS NOT NULL)),
+    CHECK (event_type = 'substitution' OR player_in IS NULL)
+);
+
+CREATE TABLE shootout_kicks (
+    id SERIAL PRIMARY KEY,
+    match_id INT NOT NULL REFERENCES matches(id),
+    kick_order INT NOT NULL,
+    team TEXT NOT NULL CHECK (team IN ('home', 'away')),
+    taker TEXT NOT NULL,
+    scored BOOLEAN NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP
+);
+
+CREATE TABLE rating_history (
+    id SERIAL PRIMARY KEY,
+    match_id INT NOT NULL REFERENCES matches(id),
+    match_date DATE NOT NULL,
+    match_time TIME NOT NULL,
+    team_id INT NOT NULL REFERENCES teams(id),
+    opponent_id INT NOT NULL REFERENCES teams(id),
+    venue TEXT NOT NULL CHECK (venue IN ('home', 'away')),
+    goals_for INT NOT NULL,
+    goals_against INT NOT NULL,
+    outcome TEXT NOT NULL CHECK (outcome IN ('W', 'D', 'L')),
+    rating_before DOUBLE PRECISION NOT NULL,
+    rating_after DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE(match_id, team_id)
+);
+
+CREATE TABLE team_ratings (
+    team_id INT PRIMARY KEY REFERENCES teams(id),
+    rating DOUBLE PRECISION NOT NULL,
+    played INT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE transfer_windows (
+    id SERIAL PRIMARY KEY,
+    season_id INT NOT NULL REFERENCES seasons(id),
+    name TEXT NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMP,
+    CHECK (end_date >= start_date)
+);
+
+CREATE TABLE transfers (
+    id SERIAL PRIMARY KEY,
+    player_id INT NOT NULL REFERENCES players(id),
+    from_team_id INT NOT NULL REFERENCES teams(id),
+    to_team_id INT NOT NULL REFERENCES teams(id),
+    transfer_date DATE NOT NULL,
+    fee BIGINT NOT NULL DEFAULT 0 CHECK (fee >= 0),
+    type TEXT NOT NULL CHECK (type IN ('permanent', 'loan', 'loan_return')),
+    loan_end_date DATE,
+    jersey_number INT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (from_team_id != to_team_id),
+    CHECK ((type = 'loan') = (loan_end_date IS NOT NULL))
+);
+
+CREATE TABLE contracts (
+    id SERIAL PRIMARY KEY,
+    player_id INT NOT NULL REFERENCES players(id),
+    team_id INT NOT NULL REFERENCES teams(id),
+    type TEXT NOT NULL CHECK (type IN ('permanent', 'loan')),
+    start_date DATE NOT NULL,
+    end_date DATE,
+    loan_end_date DATE,
+    transfer_id INT REFERENCES transfers(id),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
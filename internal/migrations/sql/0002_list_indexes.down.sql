@@ -0,0 +1,5 @@
+DROP INDEX matches_away_team_id_idx;
+DROP INDEX matches_home_team_id_idx;
+DROP INDEX matches_match_date_idx;
+DROP INDEX players_position_idx;
+DROP INDEX teams_city_idx;
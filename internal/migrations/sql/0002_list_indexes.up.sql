@@ -0,0 +1,6 @@
+-- Indexes behind the filters and sort orders of the list endpoints
+CREATE INDEX teams_city_idx ON teams (lower(city)) WHERE deleted_at IS NULL;
+CREATE INDEX players_position_idx ON players (position) WHERE deleted_at IS NULL;
+CREATE INDEX matches_match_date_idx ON matches (match_date, match_time) WHERE deleted_at IS NULL;
+CREATE INDEX matches_home_team_id_idx ON matches (home_team_id);
+CREATE INDEX matches_away_team_id_idx ON matches (away_team_id);
@@ -1,93 +1,250 @@
 package errors
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
 )
 
-// AppError represents application-specific errors
+// AppError represents application-specific errors. Clients branch on Code, Message is meant for people
+// and Details carries anything else that helps, e.g. the goals that failed a check
 type AppError struct {
 	Code       string `json:"code"`
 	Message    string `json:"message"`
-	Details    string `json:"details,omitempty"`
+	Details    any    `json:"details,omitempty"`
 	HTTPStatus int    `json:"-"`
+	Err        error  `json:"-"` // Underlying error, never shown to clients
 }
 
 func (e *AppError) Error() string {
-	return fmt.Sprintf("[%s] %s", e.Code, e.Message)
+	return e.Message
 }
 
-// Common application errors
+// Unwrap returns the underlying error, so errors.As still finds e.g. a *domain.InvalidGoalsError
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is an AppError with the same code, so a copy made by WithMessage or
+// WithDetails still matches its sentinel with errors.Is
+func (e *AppError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	return ok && t.Code == e.Code
+}
+
+// WithMessage returns a copy of e with another message
+func (e *AppError) WithMessage(message string) *AppError {
+	copied := *e
+	copied.Message = message
+	return &copied
+}
+
+// WithDetails returns a copy of e carrying details
+func (e *AppError) WithDetails(details any) *AppError {
+	copied := *e
+	copied.Details = details
+	return &copied
+}
+
+// Wrap returns a copy of e recording err as the underlying error
+func (e *AppError) Wrap(err error) *AppError {
+	copied := *e
+	copied.Err = err
+	return &copied
+}
+
+// Common application errors. Never modify them, derive a copy with WithMessage, WithDetails or Wrap
 var (
 	ErrTeamNotFound = &AppError{
 		Code:       "TEAM_NOT_FOUND",
-		Message:    "Team not found",
+		Message:    "team not found",
 		HTTPStatus: http.StatusNotFound,
 	}
 
 	ErrTeamAlreadyExists = &AppError{
 		Code:       "TEAM_ALREADY_EXISTS",
-		Message:    "Team already exists",
+		Message:    "team already exists",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrTeamNameTaken = &AppError{
+		Code:       "TEAM_NAME_TAKEN",
+		Message:    "team name already taken",
 		HTTPStatus: http.StatusConflict,
 	}
 
 	ErrPlayerNotFound = &AppError{
 		Code:       "PLAYER_NOT_FOUND",
-		Message:    "Player not found",
+		Message:    "player not found",
 		HTTPStatus: http.StatusNotFound,
 	}
 
 	ErrPlayerAlreadyExists = &AppError{
 		Code:       "PLAYER_ALREADY_EXISTS",
-		Message:    "Player already exists",
+		Message:    "player already exists",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrPlayerNameTaken = &AppError{
+		Code:       "PLAYER_NAME_TAKEN",
+		Message:    "player name already taken",
 		HTTPStatus: http.StatusConflict,
 	}
 
 	ErrJerseyNumberTaken = &AppError{
 		Code:       "JERSEY_NUMBER_TAKEN",
-		Message:    "Jersey number already taken in this team",
+		Message:    "jersey number already taken in this team",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrCompetitionNotFound = &AppError{
+		Code:       "COMPETITION_NOT_FOUND",
+		Message:    "competition not found",
+		HTTPStatus: http.StatusNotFound,
+	}
+
+	ErrCompetitionAlreadyExists = &AppError{
+		Code:       "COMPETITION_ALREADY_EXISTS",
+		Message:    "competition already exists",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrSeasonNotFound = &AppError{
+		Code:       "SEASON_NOT_FOUND",
+		Message:    "season not found",
+		HTTPStatus: http.StatusNotFound,
+	}
+
+	ErrSeasonAlreadyExists = &AppError{
+		Code:       "SEASON_ALREADY_EXISTS",
+		Message:    "season already exists in this competition",
 		HTTPStatus: http.StatusConflict,
 	}
 
 	ErrMatchNotFound = &AppError{
 		Code:       "MATCH_NOT_FOUND",
-		Message:    "Match not found",
+		Message:    "match not found",
 		HTTPStatus: http.StatusNotFound,
 	}
 
+	ErrMatchNotPlayed = &AppError{
+		Code:       "MATCH_NOT_PLAYED",
+		Message:    "results and events can only be recorded once the match is live or finished",
+		HTTPStatus: http.StatusConflict,
+	}
+
 	ErrMatchResultNotFound = &AppError{
 		Code:       "MATCH_RESULT_NOT_FOUND",
-		Message:    "Match result not found",
+		Message:    "match result not found",
 		HTTPStatus: http.StatusNotFound,
 	}
 
 	ErrMatchResultAlreadyExists = &AppError{
 		Code:       "MATCH_RESULT_ALREADY_EXISTS",
-		Message:    "Result already exists for this match",
+		Message:    "result already exists for this match",
 		HTTPStatus: http.StatusConflict,
 	}
 
+	ErrInvalidGoals = &AppError{
+		Code:       "INVALID_GOALS",
+		Message:    "goals do not match the squads",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
+	ErrMatchEventNotFound = &AppError{
+		Code:       "MATCH_EVENT_NOT_FOUND",
+		Message:    "match event not found",
+		HTTPStatus: http.StatusNotFound,
+	}
+
+	ErrBracketNotFound = &AppError{
+		Code:       "BRACKET_NOT_FOUND",
+		Message:    "bracket not found",
+		HTTPStatus: http.StatusNotFound,
+	}
+
+	ErrTransferNotFound = &AppError{
+		Code:       "TRANSFER_NOT_FOUND",
+		Message:    "transfer not found",
+		HTTPStatus: http.StatusNotFound,
+	}
+
+	ErrTransferWindowNotFound = &AppError{
+		Code:       "TRANSFER_WINDOW_NOT_FOUND",
+		Message:    "transfer window not found",
+		HTTPStatus: http.StatusNotFound,
+	}
+
+	ErrTransferWindowOverlap = &AppError{
+		Code:       "TRANSFER_WINDOW_OVERLAP",
+		Message:    "transfer window overlaps another window of the season",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrUserNotFound = &AppError{
+		Code:       "USER_NOT_FOUND",
+		Message:    "user not found",
+		HTTPStatus: http.StatusNotFound,
+	}
+
+	ErrUserAlreadyExists = &AppError{
+		Code:       "USER_ALREADY_EXISTS",
+		Message:    "user already exists",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrSessionNotFound = &AppError{
+		Code:       "SESSION_NOT_FOUND",
+		Message:    "session not found or already revoked",
+		HTTPStatus: http.StatusNotFound,
+	}
+
+	ErrDuplicate = &AppError{
+		Code:       "DUPLICATE_RECORD",
+		Message:    "record already exists",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrReferenceViolation = &AppError{
+		Code:       "REFERENCE_VIOLATION",
+		Message:    "record refers to a missing record or is still referred to",
+		HTTPStatus: http.StatusConflict,
+	}
+
+	ErrValidation = &AppError{
+		Code:       "VALIDATION_FAILED",
+		Message:    "request has invalid fields",
+		HTTPStatus: http.StatusBadRequest,
+	}
+
 	ErrInvalidInput = &AppError{
 		Code:       "INVALID_INPUT",
-		Message:    "Invalid input data",
+		Message:    "invalid input data",
 		HTTPStatus: http.StatusBadRequest,
 	}
 
 	ErrUnauthorized = &AppError{
 		Code:       "UNAUTHORIZED",
-		Message:    "Unauthorized access",
+		Message:    "unauthorized access",
 		HTTPStatus: http.StatusUnauthorized,
 	}
 
 	ErrForbidden = &AppError{
 		Code:       "FORBIDDEN",
-		Message:    "Insufficient permissions",
+		Message:    "insufficient permissions",
 		HTTPStatus: http.StatusForbidden,
 	}
 
+	// ErrDependentUpdateFailed reports a change that was saved, but left data derived from it, such as
+	// ratings or a cup bracket, out of date
+	ErrDependentUpdateFailed = &AppError{
+		Code:       "DEPENDENT_UPDATE_FAILED",
+		Message:    "change saved, but updating dependent data failed",
+		HTTPStatus: http.StatusInternalServerError,
+	}
+
 	ErrInternalServer = &AppError{
 		Code:       "INTERNAL_SERVER_ERROR",
-		Message:    "Internal server error",
+		Message:    "internal server error",
 		HTTPStatus: http.StatusInternalServerError,
 	}
 )
@@ -101,16 +258,28 @@ func NewAppError(code, message string, status int) *AppError {
 	}
 }
 
-// WrapError wraps an existing error with additional context
+// Invalid turns a validation error, e.g. from a domain type, into an INVALID_INPUT error with the same
+// message. AppErrors and nil are returned unchanged
+func Invalid(err error) error {
+	var appErr *AppError
+	if err == nil || errors.As(err, &appErr) {
+		return err
+	}
+	return ErrInvalidInput.WithMessage(err.Error()).Wrap(err)
+}
+
+// WrapError wraps an existing error with additional context. AppErrors keep their code and get message
+// as details, other errors become internal server errors keeping the cause only in Err, so its text is
+// logged but never sent to clients
 func WrapError(err error, message string) error {
-	if appErr, ok := err.(*AppError); ok {
-		appErr.Details = message
-		return appErr
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr.WithDetails(message)
 	}
 	return &AppError{
 		Code:       "WRAPPED_ERROR",
 		Message:    message,
-		Details:    err.Error(),
 		HTTPStatus: http.StatusInternalServerError,
+		Err:        err,
 	}
 }
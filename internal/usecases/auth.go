@@ -1,51 +1,168 @@
 package usecases
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"football-team-management/internal/domain/user"
+	apperrors "football-team-management/internal/pkg/errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	accessTokenTTL  = 15 * time.Minute
+	refreshTokenTTL = 7 * 24 * time.Hour
+)
+
 type AuthService interface {
-	GenerateToken(username, password string) (string, error)
+	GenerateToken(username, password string) (*user.TokenPair, error)
+	RefreshToken(refreshToken string) (*user.TokenPair, error)
 	ValidateToken(tokenString string) (*user.Claims, error)
+	Logout(claims *user.Claims) error
+	RevokeUserSessions(username string) error
 }
 
 type authService struct {
 	jwtSecret []byte
 	getUser   GetUser
+	sessions  SessionRepository
 }
 
-func NewAuthService(jwtSecret string, getUser GetUser) AuthService {
+func NewAuthService(jwtSecret string, getUser GetUser, sessions SessionRepository) AuthService {
 	return &authService{
 		jwtSecret: []byte(jwtSecret),
 		getUser:   getUser,
+		sessions:  sessions,
 	}
 }
 
-func (a *authService) GenerateToken(username, password string) (string, error) {
+func (a *authService) GenerateToken(username, password string) (*user.TokenPair, error) {
+	ctx := context.Background()
+
 	// Get user from repository
-	userData, err := a.getUser.Execute(nil, username)
+	// Unknown and disabled users get the same answer as a wrong password
+	userData, err := a.getUser.Execute(ctx, username)
+	if errors.Is(err, apperrors.ErrUserNotFound) || errors.Is(err, apperrors.ErrForbidden) {
+		return nil, apperrors.ErrUnauthorized.WithMessage("invalid credentials")
+	}
 	if err != nil {
-		return "", errors.New("invalid credentials")
+		return nil, err
 	}
 
 	// Check password
 	if err := bcrypt.CompareHashAndPassword([]byte(userData.Password), []byte(password)); err != nil {
-		return "", errors.New("invalid credentials")
+		return nil, apperrors.ErrUnauthorized.WithMessage("invalid credentials")
+	}
+
+	// Start a new session with its first refresh token
+	sessionID, err := randomToken(16)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	session := user.Session{ID: sessionID, Username: userData.Username}
+	if err := a.sessions.Create(ctx, session, hashToken(refreshToken), time.Now().Add(refreshTokenTTL)); err != nil {
+		return nil, err
+	}
+
+	return a.issueTokenPair(userData, sessionID, refreshToken)
+}
+
+func (a *authService) RefreshToken(refreshToken string) (*user.TokenPair, error) {
+	ctx := context.Background()
+
+	nextRefreshToken, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	session, err := a.sessions.Rotate(ctx, hashToken(refreshToken), hashToken(nextRefreshToken), time.Now().Add(refreshTokenTTL))
+	if err != nil {
+		return nil, err
+	}
+
+	// Re-read the user so disabled accounts and role changes take effect on refresh
+	userData, err := a.getUser.Execute(ctx, session.Username)
+	if err != nil {
+		if revokeErr := a.sessions.Revoke(ctx, session.ID); revokeErr != nil {
+			return nil, revokeErr
+		}
+		return nil, errInvalidRefreshToken
+	}
+
+	return a.issueTokenPair(userData, session.ID, nextRefreshToken)
+}
+
+func (a *authService) ValidateToken(tokenString string) (*user.Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &user.Claims{}, func(token *jwt.Token) (interface{}, error) {
+		return a.jwtSecret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+
+	if err != nil {
+		return nil, apperrors.ErrUnauthorized.WithMessage("invalid token").Wrap(err)
+	}
+
+	claims, ok := token.Claims.(*user.Claims)
+	if !ok || !token.Valid {
+		return nil, apperrors.ErrUnauthorized.WithMessage("invalid token")
+	}
+
+	// Reject tokens whose session was logged out or whose jti was revoked
+	active, err := a.sessions.IsActive(context.Background(), claims.SessionID, claims.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !active {
+		return nil, apperrors.ErrUnauthorized.WithMessage("token has been revoked")
+	}
+
+	return claims, nil
+}
+
+func (a *authService) Logout(claims *user.Claims) error {
+	ctx := context.Background()
+
+	expiresAt := time.Now().Add(accessTokenTTL)
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+	if err := a.sessions.RevokeToken(ctx, claims.ID, expiresAt); err != nil {
+		return err
+	}
+	return a.sessions.Revoke(ctx, claims.SessionID)
+}
+
+func (a *authService) RevokeUserSessions(username string) error {
+	return a.sessions.RevokeAllForUser(context.Background(), username)
+}
+
+func (a *authService) issueTokenPair(userData user.User, sessionID, refreshToken string) (*user.TokenPair, error) {
+	jti, err := randomToken(16)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create claims
+	now := time.Now()
+	expiresAt := now.Add(accessTokenTTL)
 	claims := &user.Claims{
-		Username: userData.Username,
-		Role:     userData.Role,
+		Username:  userData.Username,
+		Role:      userData.Role,
+		Teams:     userData.Teams,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)), // 24 hours
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
+			ID:        jti,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 		},
 	}
 
@@ -53,24 +170,37 @@ func (a *authService) GenerateToken(username, password string) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString(a.jwtSecret)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return tokenString, nil
+	return &user.TokenPair{
+		AccessToken:  tokenString,
+		RefreshToken: refreshToken,
+		Type:         "Bearer",
+		ExpiresAt:    expiresAt,
+	}, nil
 }
 
-func (a *authService) ValidateToken(tokenString string) (*user.Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &user.Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return a.jwtSecret, nil
-	})
-
+// HashPassword returns the bcrypt hash stored for a user's password
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
+	return string(hash), nil
+}
 
-	if claims, ok := token.Claims.(*user.Claims); ok && token.Valid {
-		return claims, nil
+// randomToken returns n random bytes encoded for use in URLs and JSON
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
 	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
 
-	return nil, errors.New("invalid token")
+// hashToken is how refresh tokens are stored, so a database leak does not expose usable tokens
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
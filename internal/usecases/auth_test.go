@@ -0,0 +1,230 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"football-team-management/internal/domain/user"
+	apperrors "football-team-management/internal/pkg/errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeGetUser struct {
+	users map[string]user.User
+}
+
+func (f *fakeGetUser) Execute(ctx context.Context, username string) (user.User, error) {
+	u, ok := f.users[username]
+	if !ok || u.Disabled {
+		return user.User{}, errors.New("user not found")
+	}
+	return u, nil
+}
+
+type fakeRefreshToken struct {
+	sessionID string
+	expiresAt time.Time
+	used      bool
+}
+
+type fakeSessionRepo struct {
+	mu            sync.Mutex
+	sessions      map[string]*user.Session
+	refreshTokens map[string]*fakeRefreshToken
+	revokedTokens map[string]time.Time
+}
+
+func newFakeSessionRepo() *fakeSessionRepo {
+	return &fakeSessionRepo{
+		sessions:      map[string]*user.Session{},
+		refreshTokens: map[string]*fakeRefreshToken{},
+		revokedTokens: map[string]time.Time{},
+	}
+}
+
+func (f *fakeSessionRepo) Create(ctx context.Context, session user.Session, refreshTokenHash string, expiresAt time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sessions[session.ID] = &session
+	f.refreshTokens[refreshTokenHash] = &fakeRefreshToken{sessionID: session.ID, expiresAt: expiresAt}
+	return nil
+}
+
+func (f *fakeSessionRepo) Rotate(ctx context.Context, oldTokenHash, newTokenHash string, expiresAt time.Time) (*user.Session, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	token, ok := f.refreshTokens[oldTokenHash]
+	if !ok {
+		return nil, errInvalidRefreshToken
+	}
+	session := f.sessions[token.sessionID]
+	if session.RevokedAt != nil || time.Now().After(token.expiresAt) {
+		return nil, errInvalidRefreshToken
+	}
+	if token.used {
+		now := time.Now()
+		session.RevokedAt = &now
+		return nil, errRefreshTokenReused
+	}
+	token.used = true
+	f.refreshTokens[newTokenHash] = &fakeRefreshToken{sessionID: session.ID, expiresAt: expiresAt}
+	return session, nil
+}
+
+func (f *fakeSessionRepo) Revoke(ctx context.Context, sessionID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := time.Now()
+	f.sessions[sessionID].RevokedAt = &now
+	return nil
+}
+
+func (f *fakeSessionRepo) RevokeAllForUser(ctx context.Context, username string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := time.Now()
+	for _, session := range f.sessions {
+		if session.Username == username {
+			session.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (f *fakeSessionRepo) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.revokedTokens[jti] = expiresAt
+	return nil
+}
+
+func (f *fakeSessionRepo) IsActive(ctx context.Context, sessionID, jti string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	session, ok := f.sessions[sessionID]
+	if !ok || session.RevokedAt != nil {
+		return false, nil
+	}
+	_, revoked := f.revokedTokens[jti]
+	return !revoked, nil
+}
+
+func newTestAuthService(t *testing.T) AuthService {
+	hash, err := HashPassword("pass")
+	require.NoError(t, err)
+	getUser := &fakeGetUser{users: map[string]user.User{
+		"coach": {Username: "coach", Password: hash, Role: user.RoleAdmin},
+	}}
+	return NewAuthService("test-secret", getUser, newFakeSessionRepo())
+}
+
+func TestAuthService_Tokens(t *testing.T) {
+	t.Run("Login issues short-lived access token and refresh token", func(t *testing.T) {
+		auth := newTestAuthService(t)
+
+		tokens, err := auth.GenerateToken("coach", "pass")
+		require.NoError(t, err)
+		assert.NotEmpty(t, tokens.RefreshToken)
+		assert.WithinDuration(t, time.Now().Add(accessTokenTTL), tokens.ExpiresAt, time.Minute)
+
+		claims, err := auth.ValidateToken(tokens.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, "coach", claims.Username)
+		assert.NotEmpty(t, claims.ID)
+		assert.NotEmpty(t, claims.SessionID)
+	})
+
+	t.Run("Wrong password is rejected", func(t *testing.T) {
+		auth := newTestAuthService(t)
+
+		_, err := auth.GenerateToken("coach", "wrong")
+		assert.Error(t, err)
+	})
+
+	t.Run("Refresh rotates the refresh token", func(t *testing.T) {
+		auth := newTestAuthService(t)
+		tokens, err := auth.GenerateToken("coach", "pass")
+		require.NoError(t, err)
+
+		refreshed, err := auth.RefreshToken(tokens.RefreshToken)
+		require.NoError(t, err)
+		assert.NotEqual(t, tokens.RefreshToken, refreshed.RefreshToken)
+
+		_, err = auth.ValidateToken(refreshed.AccessToken)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Reusing a refresh token revokes the session", func(t *testing.T) {
+		auth := newTestAuthService(t)
+		tokens, err := auth.GenerateToken("coach", "pass")
+		require.NoError(t, err)
+		refreshed, err := auth.RefreshToken(tokens.RefreshToken)
+		require.NoError(t, err)
+
+		_, err = auth.RefreshToken(tokens.RefreshToken)
+		assert.ErrorIs(t, err, errRefreshTokenReused)
+
+		_, err = auth.ValidateToken(refreshed.AccessToken)
+		assert.Error(t, err)
+		_, err = auth.RefreshToken(refreshed.RefreshToken)
+		assert.Error(t, err)
+	})
+
+	t.Run("Logout revokes access and refresh tokens", func(t *testing.T) {
+		auth := newTestAuthService(t)
+		tokens, err := auth.GenerateToken("coach", "pass")
+		require.NoError(t, err)
+		claims, err := auth.ValidateToken(tokens.AccessToken)
+		require.NoError(t, err)
+
+		require.NoError(t, auth.Logout(claims))
+
+		_, err = auth.ValidateToken(tokens.AccessToken)
+		assert.Error(t, err)
+		_, err = auth.RefreshToken(tokens.RefreshToken)
+		assert.Error(t, err)
+	})
+}
+
+// unreachableUserRepo fails every lookup the way a repository does when the database is down
+type unreachableUserRepo struct {
+	*MemoryUserRepo
+}
+
+func (r unreachableUserRepo) GetByUsername(ctx context.Context, username string) (*user.User, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestBootstrapAdmin(t *testing.T) {
+	t.Run("Creates the admin once", func(t *testing.T) {
+		repo := NewMemoryUserRepo(NewMemoryStore())
+		require.NoError(t, BootstrapAdmin(context.Background(), repo, "admin", "secret"))
+		require.NoError(t, BootstrapAdmin(context.Background(), repo, "admin", "secret"))
+
+		admin, err := repo.GetByUsername(context.Background(), "admin")
+		require.NoError(t, err)
+		assert.Equal(t, user.RoleAdmin, admin.Role)
+	})
+
+	t.Run("Leaves a deleted admin deleted", func(t *testing.T) {
+		repo := NewMemoryUserRepo(NewMemoryStore())
+		require.NoError(t, BootstrapAdmin(context.Background(), repo, "admin", "secret"))
+		require.NoError(t, repo.Delete(context.Background(), "admin"))
+
+		err := BootstrapAdmin(context.Background(), repo, "admin", "secret")
+		assert.ErrorIs(t, err, apperrors.ErrUserAlreadyExists)
+		assert.EqualError(t, err, "username admin belongs to a deleted account")
+		_, err = repo.GetByUsername(context.Background(), "admin")
+		assert.ErrorIs(t, err, apperrors.ErrUserNotFound)
+	})
+
+	t.Run("Reports lookup failures instead of registering", func(t *testing.T) {
+		repo := unreachableUserRepo{NewMemoryUserRepo(NewMemoryStore())}
+		err := BootstrapAdmin(context.Background(), repo, "admin", "secret")
+		assert.EqualError(t, err, "connection refused")
+	})
+}
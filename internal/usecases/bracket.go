@@ -0,0 +1,476 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type BracketRepository interface {
+	Register(ctx context.Context, bracket domain.Bracket) (int, error)
+	List(ctx context.Context) ([]domain.Bracket, error)
+	GetByID(ctx context.Context, id int) (*domain.Bracket, error)
+	GetTieByMatchID(ctx context.Context, matchID int) (*domain.BracketTie, error)
+	UpdateTie(ctx context.Context, tie domain.BracketTie) error
+}
+
+var errBracketTieNotFound = errors.New("bracket tie not found")
+
+type PostgresBracketRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresBracketRepo(pool *pgxpool.Pool) *PostgresBracketRepo {
+	return &PostgresBracketRepo{pool: pool}
+}
+
+func (r *PostgresBracketRepo) Register(ctx context.Context, bracket domain.Bracket) (int, error) {
+	kickOffTime, err := time.Parse("15:04", bracket.KickOffTime)
+	if err != nil {
+		return 0, apperrors.ErrInvalidInput.WithMessage("invalid kick-off time format. Use HH:MM")
+	}
+
+	// Start transaction
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	now := time.Now()
+	var id int
+	err = tx.QueryRow(ctx, `INSERT INTO brackets (name, season_id, size, start_date, interval_days, kick_off_time, created_at, updated_at, deleted_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULL) RETURNING id`,
+		bracket.Name, bracket.SeasonID, bracket.Size, bracket.StartDate, bracket.IntervalDays, kickOffTime, now, now).Scan(&id)
+	if err != nil {
+		return 0, dbError(err)
+	}
+
+	// Insert ties
+	for _, tie := range bracket.Ties {
+		_, err = tx.Exec(ctx, `INSERT INTO bracket_ties (bracket_id, round, position, home_team_id, away_team_id, home_seed, away_seed, match_id, winner_id, bye, created_at, updated_at) VALUES ($1, $2, $3, (SELECT id FROM teams WHERE name = $4), (SELECT id FROM teams WHERE name = $5), $6, $7, $8, (SELECT id FROM teams WHERE name = $9), $10, $11, $12)`,
+			id, tie.Round, tie.Position, tie.HomeTeam, tie.AwayTeam, tie.HomeSeed, tie.AwaySeed, tie.MatchID, tie.Winner, tie.Bye, now, now)
+		if err != nil {
+			return 0, dbError(err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *PostgresBracketRepo) List(ctx context.Context) ([]domain.Bracket, error) {
+	rows, err := r.pool.Query(ctx, `SELECT id, name, season_id, size, start_date, interval_days, kick_off_time, created_at, updated_at, deleted_at FROM brackets WHERE deleted_at IS NULL ORDER BY start_date DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var brackets []domain.Bracket
+	for rows.Next() {
+		var b domain.Bracket
+		var deletedAt *time.Time
+		var kickOffTime time.Time
+		if err := rows.Scan(&b.ID, &b.Name, &b.SeasonID, &b.Size, &b.StartDate, &b.IntervalDays, &kickOffTime, &b.CreatedAt, &b.UpdatedAt, &deletedAt); err != nil {
+			return nil, err
+		}
+		b.KickOffTime = kickOffTime.Format("15:04")
+		b.DeletedAt = deletedAt
+		brackets = append(brackets, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return brackets, nil
+}
+
+func (r *PostgresBracketRepo) GetByID(ctx context.Context, id int) (*domain.Bracket, error) {
+	var b domain.Bracket
+	var deletedAt *time.Time
+	var kickOffTime time.Time
+	err := r.pool.QueryRow(ctx, `SELECT id, name, season_id, size, start_date, interval_days, kick_off_time, created_at, updated_at, deleted_at FROM brackets WHERE id = $1 AND deleted_at IS NULL`, id).
+		Scan(&b.ID, &b.Name, &b.SeasonID, &b.Size, &b.StartDate, &b.IntervalDays, &kickOffTime, &b.CreatedAt, &b.UpdatedAt, &deletedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperrors.ErrBracketNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	b.KickOffTime = kickOffTime.Format("15:04")
+	b.DeletedAt = deletedAt
+
+	rows, err := r.pool.Query(ctx, bracketTieSelect+` WHERE t.bracket_id = $1 ORDER BY t.round, t.position`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var t domain.BracketTie
+		if err := rows.Scan(&t.ID, &t.BracketID, &t.Round, &t.Position, &t.HomeTeam, &t.AwayTeam, &t.HomeSeed, &t.AwaySeed, &t.MatchID, &t.Winner, &t.Bye); err != nil {
+			return nil, err
+		}
+		b.Ties = append(b.Ties, t)
+	}
+	return &b, rows.Err()
+}
+
+// bracketTieSelect reads ties with the names of the teams they are stored against by ID. Teams are
+// unknown until the ties before have been decided
+const bracketTieSelect = `SELECT t.id, t.bracket_id, t.round, t.position, home.name, away.name, t.home_seed, t.away_seed, t.match_id, winner.name, t.bye FROM bracket_ties t` +
+	` LEFT JOIN teams home ON home.id = t.home_team_id LEFT JOIN teams away ON away.id = t.away_team_id LEFT JOIN teams winner ON winner.id = t.winner_id`
+
+func (r *PostgresBracketRepo) GetTieByMatchID(ctx context.Context, matchID int) (*domain.BracketTie, error) {
+	var t domain.BracketTie
+	err := r.pool.QueryRow(ctx, bracketTieSelect+` JOIN brackets b ON b.id = t.bracket_id WHERE t.match_id = $1 AND b.deleted_at IS NULL`, matchID).
+		Scan(&t.ID, &t.BracketID, &t.Round, &t.Position, &t.HomeTeam, &t.AwayTeam, &t.HomeSeed, &t.AwaySeed, &t.MatchID, &t.Winner, &t.Bye)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errBracketTieNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *PostgresBracketRepo) UpdateTie(ctx context.Context, tie domain.BracketTie) error {
+	now := time.Now()
+	cmd, err := r.pool.Exec(ctx, `UPDATE bracket_ties SET home_team_id=(SELECT id FROM teams WHERE name = $1), away_team_id=(SELECT id FROM teams WHERE name = $2), home_seed=$3, away_seed=$4, match_id=$5, winner_id=(SELECT id FROM teams WHERE name = $6), updated_at=$7 WHERE id=$8`,
+		tie.HomeTeam, tie.AwayTeam, tie.HomeSeed, tie.AwaySeed, tie.MatchID, tie.Winner, now, tie.ID)
+	if err != nil {
+		return dbError(err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errBracketTieNotFound
+	}
+	return nil
+}
+
+type BracketService interface {
+	Create(ctx context.Context, opts domain.BracketOptions) (*domain.Bracket, error)
+	List(ctx context.Context) ([]domain.Bracket, error)
+	GetByID(ctx context.Context, id int) (*domain.Bracket, error)
+	MatchResultObserver
+}
+
+type bracketService struct {
+	repo       BracketRepository
+	teamRepo   TeamRepository
+	matchRepo  MatchRepository
+	resultRepo MatchResultRepository
+}
+
+func NewBracketService(repo BracketRepository, teamRepo TeamRepository, matchRepo MatchRepository, resultRepo MatchResultRepository) BracketService {
+	return &bracketService{repo: repo, teamRepo: teamRepo, matchRepo: matchRepo, resultRepo: resultRepo}
+}
+
+// Create draws the bracket and schedules a match for every tie whose two teams are already known.
+// The matches are registered together before the bracket, so a round date outside the season or a
+// team that already plays that day saves nothing, and they are deleted again if the bracket is not saved
+func (s *bracketService) Create(ctx context.Context, opts domain.BracketOptions) (*domain.Bracket, error) {
+	if err := ensureTeamsExist(ctx, s.teamRepo, opts.Teams); err != nil {
+		return nil, err
+	}
+
+	if !opts.Seeded {
+		opts.Teams = append([]string(nil), opts.Teams...)
+		rand.Shuffle(len(opts.Teams), func(i, j int) {
+			opts.Teams[i], opts.Teams[j] = opts.Teams[j], opts.Teams[i]
+		})
+	}
+
+	drawn, err := BuildBracket(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var ties []*domain.BracketTie
+	var matches []domain.Match
+	for i := range drawn.Ties {
+		tie := &drawn.Ties[i]
+		if tie.Bye || tie.HomeTeam == nil || tie.AwayTeam == nil {
+			continue
+		}
+		ties = append(ties, tie)
+		matches = append(matches, tieMatch(drawn, tie))
+	}
+	matchIDs, err := s.matchRepo.RegisterBatch(ctx, matches)
+	if err != nil {
+		return nil, err
+	}
+	for i, tie := range ties {
+		tie.MatchID = &matchIDs[i]
+	}
+
+	id, err := s.repo.Register(ctx, *drawn)
+	if err != nil {
+		for _, matchID := range matchIDs {
+			if deleteErr := s.matchRepo.Delete(ctx, matchID); deleteErr != nil {
+				return nil, errors.Join(err, deleteErr)
+			}
+		}
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *bracketService) List(ctx context.Context) ([]domain.Bracket, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *bracketService) GetByID(ctx context.Context, id int) (*domain.Bracket, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// ResultChanged moves the winner of a cup tie into the next round once its result is known,
+// and takes them out again if the result is changed or deleted before the next round is played
+func (s *bracketService) ResultChanged(ctx context.Context, matchID int) error {
+	found, err := s.repo.GetTieByMatchID(ctx, matchID)
+	if errors.Is(err, errBracketTieNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	bracket, err := s.repo.GetByID(ctx, found.BracketID)
+	if err != nil {
+		return err
+	}
+	tie := bracket.Tie(found.Round, found.Position)
+
+	// Only a finished match decides the tie, a live score may still change and a deleted match decides nothing
+	match, err := s.matchRepo.GetByID(ctx, matchID)
+	if err != nil && !errors.Is(err, apperrors.ErrMatchNotFound) {
+		return err
+	}
+	var winner *string
+	result, err := s.resultRepo.GetByMatchID(ctx, matchID)
+	switch {
+	case match == nil || match.Status != domain.MatchFinished:
+	case err == nil:
+		switch result.Winner() {
+		case "home":
+			winner = tie.HomeTeam
+		case "away":
+			winner = tie.AwayTeam
+		}
+	case !errors.Is(err, apperrors.ErrMatchResultNotFound):
+		return err
+	}
+
+	if sameTeam(tie.Winner, winner) {
+		return nil
+	}
+	if err := s.checkNextRound(ctx, bracket, tie, winner); err != nil {
+		return err
+	}
+	tie.Winner = winner
+	if err := s.repo.UpdateTie(ctx, *tie); err != nil {
+		return err
+	}
+	return s.advance(ctx, bracket, tie)
+}
+
+// checkNextRound fails if winner would take the place of another team in a next round match that is
+// already live or over, before anything of the bracket is changed
+func (s *bracketService) checkNextRound(ctx context.Context, bracket *domain.Bracket, tie *domain.BracketTie, winner *string) error {
+	next := bracket.Tie(tie.Round+1, tie.Position/2)
+	if next == nil || next.MatchID == nil {
+		return nil
+	}
+	slot := next.HomeTeam
+	if tie.Position%2 == 1 {
+		slot = next.AwayTeam
+	}
+	if sameTeam(slot, winner) {
+		return nil
+	}
+
+	// The next round match was scheduled with the previous winner, it can only change before it is played
+	match, err := s.matchRepo.GetByID(ctx, *next.MatchID)
+	if err != nil {
+		return err
+	}
+	if match.Status != domain.MatchScheduled && match.Status != domain.MatchPostponed {
+		return apperrors.ErrInvalidInput.WithMessage(fmt.Sprintf("the next round match is already %s", match.Status))
+	}
+	return nil
+}
+
+// advance puts the winner of tie, or nobody if it has none, into its slot of the next round
+func (s *bracketService) advance(ctx context.Context, bracket *domain.Bracket, tie *domain.BracketTie) error {
+	next := bracket.Tie(tie.Round+1, tie.Position/2)
+	if next == nil {
+		return nil
+	}
+	slot, seed := &next.HomeTeam, &next.HomeSeed
+	if tie.Position%2 == 1 {
+		slot, seed = &next.AwayTeam, &next.AwaySeed
+	}
+	if sameTeam(*slot, tie.Winner) {
+		return nil
+	}
+	*slot = tie.Winner
+	*seed = winnerSeed(tie)
+
+	if next.MatchID != nil {
+		// checkNextRound made sure the next round match has not been played yet
+		if tie.Winner == nil {
+			if err := s.matchRepo.Delete(ctx, *next.MatchID); err != nil {
+				return err
+			}
+			next.MatchID = nil
+		} else {
+			match, err := s.matchRepo.GetByID(ctx, *next.MatchID)
+			if err != nil {
+				return err
+			}
+			match.HomeTeam, match.AwayTeam = *next.HomeTeam, *next.AwayTeam
+			if err := s.matchRepo.Update(ctx, match.ID, *match); err != nil {
+				return err
+			}
+		}
+	} else if next.HomeTeam != nil && next.AwayTeam != nil {
+		if err := s.schedule(ctx, bracket, next); err != nil {
+			return err
+		}
+	}
+
+	return s.repo.UpdateTie(ctx, *next)
+}
+
+// schedule creates the match of a tie on the date of its round
+func (s *bracketService) schedule(ctx context.Context, bracket *domain.Bracket, tie *domain.BracketTie) error {
+	matchID, err := s.matchRepo.Register(ctx, tieMatch(bracket, tie))
+	if err != nil {
+		return err
+	}
+	tie.MatchID = &matchID
+	return nil
+}
+
+// tieMatch returns the match of a tie whose two teams are known, played on the date of its round
+func tieMatch(bracket *domain.Bracket, tie *domain.BracketTie) domain.Match {
+	return domain.Match{
+		MatchDate: bracket.RoundDate(tie.Round),
+		MatchTime: bracket.KickOffTime,
+		HomeTeam:  *tie.HomeTeam,
+		AwayTeam:  *tie.AwayTeam,
+		SeasonID:  bracket.SeasonID,
+	}
+}
+
+// BuildBracket draws the ties of a bracket from teams given in seed order. The bracket is
+// sized to the next power of two and the missing entrants become byes for the top seeds,
+// who go straight into the second round. Seeds are placed so the top two can only meet in the final
+func BuildBracket(opts domain.BracketOptions) (*domain.Bracket, error) {
+	if opts.Name == "" {
+		return nil, apperrors.ErrInvalidInput.WithMessage("bracket name is required")
+	}
+	if len(opts.Teams) < 2 {
+		return nil, apperrors.ErrInvalidInput.WithMessage("at least two teams are required")
+	}
+	seen := make(map[string]bool, len(opts.Teams))
+	for _, team := range opts.Teams {
+		if seen[team] {
+			return nil, apperrors.ErrInvalidInput.WithMessage("duplicate team: " + team)
+		}
+		seen[team] = true
+	}
+	if opts.IntervalDays < 1 {
+		return nil, apperrors.ErrInvalidInput.WithMessage("interval days must be at least 1")
+	}
+	if _, err := time.Parse("15:04", opts.KickOffTime); err != nil {
+		return nil, apperrors.ErrInvalidInput.WithMessage("invalid kick-off time format. Use HH:MM")
+	}
+
+	size := 2
+	for size < len(opts.Teams) {
+		size *= 2
+	}
+	bracket := &domain.Bracket{
+		Name:         opts.Name,
+		SeasonID:     opts.SeasonID,
+		Size:         size,
+		StartDate:    opts.StartDate,
+		IntervalDays: opts.IntervalDays,
+		KickOffTime:  opts.KickOffTime,
+	}
+	for round := 1; round <= bracket.Rounds(); round++ {
+		for position := 0; position < size>>round; position++ {
+			bracket.Ties = append(bracket.Ties, domain.BracketTie{Round: round, Position: position})
+		}
+	}
+
+	entrant := func(seed int) (*string, *int) {
+		if seed > len(opts.Teams) {
+			return nil, nil
+		}
+		team := opts.Teams[seed-1]
+		if !opts.Seeded {
+			return &team, nil
+		}
+		return &team, &seed
+	}
+
+	order := seedOrder(size)
+	for position := 0; position < size/2; position++ {
+		tie := bracket.Tie(1, position)
+		tie.HomeTeam, tie.HomeSeed = entrant(order[2*position])
+		tie.AwayTeam, tie.AwaySeed = entrant(order[2*position+1])
+		if tie.AwayTeam != nil {
+			continue
+		}
+
+		// Byes always fall on the away side because the home side holds the better seed
+		tie.Bye = true
+		tie.Winner = tie.HomeTeam
+		next := bracket.Tie(2, position/2)
+		if position%2 == 0 {
+			next.HomeTeam, next.HomeSeed = tie.Winner, tie.HomeSeed
+		} else {
+			next.AwayTeam, next.AwaySeed = tie.Winner, tie.HomeSeed
+		}
+	}
+	return bracket, nil
+}
+
+// seedOrder returns the seeds of a bracket of the given size in slot order, e.g. 1, 8, 4, 5, 2, 7, 3, 6
+func seedOrder(size int) []int {
+	order := []int{1}
+	for len(order) < size {
+		slots := len(order) * 2
+		expanded := make([]int, 0, slots)
+		for _, seed := range order {
+			expanded = append(expanded, seed, slots+1-seed)
+		}
+		order = expanded
+	}
+	return order
+}
+
+// winnerSeed returns the seed of the team that won tie, if it has one
+func winnerSeed(tie *domain.BracketTie) *int {
+	switch {
+	case tie.Winner == nil:
+		return nil
+	case sameTeam(tie.Winner, tie.HomeTeam):
+		return tie.HomeSeed
+	default:
+		return tie.AwaySeed
+	}
+}
+
+func sameTeam(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,193 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bracketOptions(teams ...string) domain.BracketOptions {
+	return domain.BracketOptions{
+		Name:         "Piala Indonesia",
+		Teams:        teams,
+		Seeded:       true,
+		StartDate:    time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC),
+		IntervalDays: 14,
+		KickOffTime:  "19:00",
+	}
+}
+
+func teamName(team *string) string {
+	if team == nil {
+		return ""
+	}
+	return *team
+}
+
+func TestSeedOrder(t *testing.T) {
+	assert.Equal(t, []int{1, 2}, seedOrder(2))
+	assert.Equal(t, []int{1, 4, 2, 3}, seedOrder(4))
+	assert.Equal(t, []int{1, 8, 4, 5, 2, 7, 3, 6}, seedOrder(8))
+}
+
+func TestBuildBracket(t *testing.T) {
+	t.Run("Power of two entrants play every first round tie", func(t *testing.T) {
+		bracket, err := BuildBracket(bracketOptions("S1", "S2", "S3", "S4"))
+		require.NoError(t, err)
+
+		assert.Equal(t, 4, bracket.Size)
+		assert.Equal(t, 2, bracket.Rounds())
+		assert.Len(t, bracket.Ties, 3)
+		assert.Equal(t, "S1", teamName(bracket.Tie(1, 0).HomeTeam))
+		assert.Equal(t, "S4", teamName(bracket.Tie(1, 0).AwayTeam))
+		assert.Equal(t, "S2", teamName(bracket.Tie(1, 1).HomeTeam))
+		assert.Equal(t, "S3", teamName(bracket.Tie(1, 1).AwayTeam))
+		assert.Nil(t, bracket.Tie(2, 0).HomeTeam)
+	})
+
+	t.Run("Top seeds get byes into the second round", func(t *testing.T) {
+		bracket, err := BuildBracket(bracketOptions("S1", "S2", "S3", "S4", "S5", "S6"))
+		require.NoError(t, err)
+
+		assert.Equal(t, 8, bracket.Size)
+		assert.Len(t, bracket.Ties, 7)
+
+		bye := bracket.Tie(1, 0)
+		assert.True(t, bye.Bye)
+		assert.Equal(t, "S1", teamName(bye.Winner))
+		assert.True(t, bracket.Tie(1, 2).Bye)
+		assert.Equal(t, "S2", teamName(bracket.Tie(1, 2).Winner))
+		assert.False(t, bracket.Tie(1, 1).Bye)
+		assert.False(t, bracket.Tie(1, 3).Bye)
+
+		assert.Equal(t, "S1", teamName(bracket.Tie(2, 0).HomeTeam))
+		assert.Equal(t, 1, *bracket.Tie(2, 0).HomeSeed)
+		assert.Nil(t, bracket.Tie(2, 0).AwayTeam)
+		assert.Equal(t, "S2", teamName(bracket.Tie(2, 1).HomeTeam))
+	})
+
+	t.Run("Three entrants", func(t *testing.T) {
+		bracket, err := BuildBracket(bracketOptions("S1", "S2", "S3"))
+		require.NoError(t, err)
+
+		assert.True(t, bracket.Tie(1, 0).Bye)
+		assert.Equal(t, "S2", teamName(bracket.Tie(1, 1).HomeTeam))
+		assert.Equal(t, "S3", teamName(bracket.Tie(1, 1).AwayTeam))
+		assert.Equal(t, "S1", teamName(bracket.Tie(2, 0).HomeTeam))
+	})
+
+	t.Run("Unseeded draws carry no seeds", func(t *testing.T) {
+		opts := bracketOptions("A", "B", "C", "D")
+		opts.Seeded = false
+		bracket, err := BuildBracket(opts)
+		require.NoError(t, err)
+
+		assert.Nil(t, bracket.Tie(1, 0).HomeSeed)
+	})
+
+	t.Run("Rounds are scheduled at the configured interval", func(t *testing.T) {
+		bracket, err := BuildBracket(bracketOptions("S1", "S2", "S3", "S4", "S5", "S6", "S7", "S8"))
+		require.NoError(t, err)
+
+		assert.Equal(t, "2024-09-29", bracket.RoundDate(3).Format("2006-01-02"))
+		assert.Equal(t, "Quarter-final", domain.RoundName(1, 3))
+		assert.Equal(t, "Final", domain.RoundName(3, 3))
+		assert.Equal(t, "Round of 16", domain.RoundName(1, 4))
+	})
+
+	t.Run("Response links the final to the ties feeding it", func(t *testing.T) {
+		bracket, err := BuildBracket(bracketOptions("S1", "S2", "S3", "S4"))
+		require.NoError(t, err)
+
+		response := bracket.ToBracketResponse()
+		require.NotNil(t, response.Final)
+		assert.Equal(t, "Final", response.Final.RoundName)
+		assert.Equal(t, "S1", teamName(response.Final.HomeFrom.HomeTeam))
+		assert.Equal(t, "S2", teamName(response.Final.AwayFrom.HomeTeam))
+		assert.Nil(t, response.Final.HomeFrom.HomeFrom)
+	})
+
+	t.Run("Invalid options are rejected", func(t *testing.T) {
+		_, err := BuildBracket(bracketOptions("S1"))
+		assert.Error(t, err)
+
+		_, err = BuildBracket(bracketOptions("S1", "S1"))
+		assert.Error(t, err)
+
+		opts := bracketOptions("S1", "S2")
+		opts.KickOffTime = "late"
+		_, err = BuildBracket(opts)
+		assert.Error(t, err)
+	})
+}
+
+// unsavableBracketRepo fails to save brackets, as a database that went away would
+type unsavableBracketRepo struct {
+	BracketRepository
+}
+
+func (unsavableBracketRepo) Register(ctx context.Context, bracket domain.Bracket) (int, error) {
+	return 0, errors.New("connection refused")
+}
+
+func TestBracketServiceCreate(t *testing.T) {
+	ctx := context.Background()
+	newRepos := func(t *testing.T) *Repositories {
+		repos := NewMemoryRepositories()
+		for _, name := range []string{"S1", "S2", "S3", "S4"} {
+			_, err := repos.Teams.Register(ctx, domain.Team{Name: name})
+			require.NoError(t, err)
+		}
+		return repos
+	}
+	matchCount := func(t *testing.T, repos *Repositories) int {
+		matches, err := repos.Matches.List(ctx, domain.MatchFilter{})
+		require.NoError(t, err)
+		return len(matches)
+	}
+
+	t.Run("Schedules the first round", func(t *testing.T) {
+		repos := newRepos(t)
+		service := NewBracketService(repos.Brackets, repos.Teams, repos.Matches, repos.MatchResults)
+		bracket, err := service.Create(ctx, bracketOptions("S1", "S2", "S3", "S4"))
+		require.NoError(t, err)
+
+		for _, tie := range bracket.Ties {
+			assert.Equal(t, tie.Round == 1, tie.MatchID != nil)
+		}
+		assert.Equal(t, 2, matchCount(t, repos))
+	})
+
+	t.Run("Saves nothing when a match cannot be scheduled", func(t *testing.T) {
+		repos := newRepos(t)
+		competitionID, err := repos.Competitions.Register(ctx, domain.Competition{Name: "Piala Indonesia", Type: domain.CompetitionCup})
+		require.NoError(t, err)
+		seasonID, err := repos.Seasons.Register(ctx, domain.Season{CompetitionID: competitionID, Name: "2024", StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2024, 8, 31, 0, 0, 0, 0, time.UTC)})
+		require.NoError(t, err)
+		service := NewBracketService(repos.Brackets, repos.Teams, repos.Matches, repos.MatchResults)
+
+		opts := bracketOptions("S1", "S2", "S3", "S4")
+		opts.SeasonID = &seasonID
+		_, err = service.Create(ctx, opts)
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+
+		brackets, err := repos.Brackets.List(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, brackets)
+		assert.Zero(t, matchCount(t, repos))
+	})
+
+	t.Run("Takes the matches back when the bracket cannot be saved", func(t *testing.T) {
+		repos := newRepos(t)
+		service := NewBracketService(unsavableBracketRepo{repos.Brackets}, repos.Teams, repos.Matches, repos.MatchResults)
+		_, err := service.Create(ctx, bracketOptions("S1", "S2", "S3", "S4"))
+		assert.EqualError(t, err, "connection refused")
+		assert.Zero(t, matchCount(t, repos))
+	})
+}
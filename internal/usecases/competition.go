@@ -0,0 +1,139 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CompetitionRepository interface {
+	Register(ctx context.Context, competition domain.Competition) (int, error)
+	Update(ctx context.Context, id int, competition domain.Competition) error
+	Delete(ctx context.Context, id int) error
+	List(ctx context.Context) ([]domain.Competition, error)
+	GetByID(ctx context.Context, id int) (*domain.Competition, error)
+	Restore(ctx context.Context, id int) error
+}
+
+type PostgresCompetitionRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresCompetitionRepo(pool *pgxpool.Pool) *PostgresCompetitionRepo {
+	return &PostgresCompetitionRepo{pool: pool}
+}
+
+func (r *PostgresCompetitionRepo) Register(ctx context.Context, competition domain.Competition) (int, error) {
+	if !domain.ValidCompetitionType(competition.Type) {
+		return 0, apperrors.ErrInvalidInput.WithMessage("invalid competition type. Use league or cup")
+	}
+
+	// Check if competition already exists
+	var competitionExists bool
+	err := r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM competitions WHERE name = $1 AND deleted_at IS NULL)`, competition.Name).Scan(&competitionExists)
+	if err != nil {
+		return 0, err
+	}
+	if competitionExists {
+		return 0, apperrors.ErrCompetitionAlreadyExists
+	}
+
+	now := time.Now()
+	var id int
+	err = r.pool.QueryRow(ctx, `INSERT INTO competitions (name, type, created_at, updated_at, deleted_at) VALUES ($1, $2, $3, $4, NULL) RETURNING id`,
+		competition.Name, competition.Type, now, now).Scan(&id)
+	return id, dbError(err)
+}
+
+func (r *PostgresCompetitionRepo) Update(ctx context.Context, id int, competition domain.Competition) error {
+	if !domain.ValidCompetitionType(competition.Type) {
+		return apperrors.ErrInvalidInput.WithMessage("invalid competition type. Use league or cup")
+	}
+
+	// Check if the new name is taken by another competition
+	var nameTaken bool
+	err := r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM competitions WHERE name = $1 AND id != $2 AND deleted_at IS NULL)`, competition.Name, id).Scan(&nameTaken)
+	if err != nil {
+		return err
+	}
+	if nameTaken {
+		return apperrors.ErrCompetitionAlreadyExists
+	}
+
+	now := time.Now()
+	cmd, err := r.pool.Exec(ctx, `UPDATE competitions SET name=$1, type=$2, updated_at=$3 WHERE id=$4 AND deleted_at IS NULL`,
+		competition.Name, competition.Type, now, id)
+	if err != nil {
+		return dbError(err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return apperrors.ErrCompetitionNotFound
+	}
+	return nil
+}
+
+func (r *PostgresCompetitionRepo) Delete(ctx context.Context, id int) error {
+	now := time.Now()
+	cmd, err := r.pool.Exec(ctx, `UPDATE competitions SET deleted_at=$1, updated_at=$2 WHERE id=$3 AND deleted_at IS NULL`, now, now, id)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return apperrors.ErrCompetitionNotFound
+	}
+	return nil
+}
+
+func (r *PostgresCompetitionRepo) List(ctx context.Context) ([]domain.Competition, error) {
+	rows, err := r.pool.Query(ctx, `SELECT id, name, type, created_at, updated_at, deleted_at FROM competitions WHERE deleted_at IS NULL ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var competitions []domain.Competition
+	for rows.Next() {
+		var c domain.Competition
+		var deletedAt *time.Time
+		if err := rows.Scan(&c.ID, &c.Name, &c.Type, &c.CreatedAt, &c.UpdatedAt, &deletedAt); err != nil {
+			return nil, err
+		}
+		c.DeletedAt = deletedAt
+		competitions = append(competitions, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return competitions, nil
+}
+
+func (r *PostgresCompetitionRepo) GetByID(ctx context.Context, id int) (*domain.Competition, error) {
+	var c domain.Competition
+	var deletedAt *time.Time
+	err := r.pool.QueryRow(ctx, `SELECT id, name, type, created_at, updated_at, deleted_at FROM competitions WHERE id = $1 AND deleted_at IS NULL`, id).
+		Scan(&c.ID, &c.Name, &c.Type, &c.CreatedAt, &c.UpdatedAt, &deletedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperrors.ErrCompetitionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.DeletedAt = deletedAt
+	return &c, nil
+}
+
+func (r *PostgresCompetitionRepo) Restore(ctx context.Context, id int) error {
+	now := time.Now()
+	cmd, err := r.pool.Exec(ctx, `UPDATE competitions SET deleted_at=NULL, updated_at=$1 WHERE id=$2 AND deleted_at IS NOT NULL`, now, id)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return apperrors.ErrCompetitionNotFound.WithMessage("competition not found or not deleted")
+	}
+	return nil
+}
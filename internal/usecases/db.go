@@ -0,0 +1,125 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// querier is implemented by both *pgxpool.Pool and pgx.Tx, so repository helpers
+// can run the same statements standalone or as part of a larger transaction
+type querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// keyID returns the ID a route key stands for, or nil if the key is a slug. Slugs never consist of
+// digits only, so `WHERE (id = $1 OR slug = $2)` with keyID(key) and key matches either way
+func keyID(key string) *int {
+	id, err := strconv.Atoi(key)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+// uniqueSlug returns base, or base with the lowest numeric suffix not yet used as a slug in table
+func uniqueSlug(ctx context.Context, q querier, table, base string) (string, error) {
+	slug := base
+	for n := 2; ; n++ {
+		var taken bool
+		err := q.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM `+table+` WHERE slug = $1)`, slug).Scan(&taken)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return slug, nil
+		}
+		slug = base + "-" + strconv.Itoa(n)
+	}
+}
+
+// constraintErrors names the error for violations of constraints whose meaning is known. Postgres names
+// inline constraints <table>_<columns>_key
+var constraintErrors = map[string]*apperrors.AppError{
+	"users_pkey":                        apperrors.ErrUserAlreadyExists,
+	"teams_name_key":                    apperrors.ErrTeamAlreadyExists,
+	"players_name_key":                  apperrors.ErrPlayerAlreadyExists,
+	"players_team_id_jersey_number_key": apperrors.ErrJerseyNumberTaken,
+	"match_results_match_id_key":        apperrors.ErrMatchResultAlreadyExists,
+}
+
+// dbError turns unique and foreign key violations into AppErrors with the violated constraint as details,
+// so a write losing a race against another one is reported like the check it slipped past. Other errors
+// are returned unchanged
+func dbError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+	if known, ok := constraintErrors[pgErr.ConstraintName]; ok {
+		return known.WithDetails(pgErr.ConstraintName).Wrap(err)
+	}
+	switch pgErr.Code {
+	case "23505": // unique_violation
+		return apperrors.ErrDuplicate.WithDetails(pgErr.ConstraintName).Wrap(err)
+	case "23503": // foreign_key_violation
+		return apperrors.ErrReferenceViolation.WithDetails(pgErr.ConstraintName).Wrap(err)
+	}
+	return err
+}
+
+// withContext prefixes the message of err with prefix. AppErrors keep their code, so the error is still
+// reported with the status of its cause
+func withContext(err error, prefix string) error {
+	var appErr *apperrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.WithMessage(prefix + ": " + appErr.Message)
+	}
+	return fmt.Errorf("%s: %w", prefix, err)
+}
+
+// optional returns nil for an empty value, so `$1::text IS NULL OR ...` skips a filter that was not given
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// deletedClause returns the condition selecting the rows filter shows, column is the deleted_at column
+// of the listed table
+func deletedClause(filter domain.DeletedFilter, column string) string {
+	switch filter {
+	case domain.IncludeDeleted:
+		return "TRUE"
+	case domain.OnlyDeleted:
+		return column + " IS NOT NULL"
+	}
+	return column + " IS NULL"
+}
+
+// orderBy returns the ORDER BY clause sorting by the columns of page's sort field and then by idColumn,
+// all in the requested direction. columns doubles as the whitelist, the sort never reaches the SQL itself
+func orderBy(page domain.PageRequest, columns map[string][]string, idColumn string) string {
+	field, desc := page.SortBy()
+	direction := " ASC"
+	if desc {
+		direction = " DESC"
+	}
+
+	var terms []string
+	for _, column := range columns[field] {
+		terms = append(terms, column+direction)
+	}
+	terms = append(terms, idColumn+direction)
+	return " ORDER BY " + strings.Join(terms, ", ")
+}
@@ -0,0 +1,141 @@
+package usecases
+
+import (
+	"context"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"time"
+)
+
+type FixtureService interface {
+	Generate(ctx context.Context, opts domain.FixtureOptions, dryRun bool) ([][]domain.Match, error)
+}
+
+type fixtureService struct {
+	teamRepo  TeamRepository
+	matchRepo MatchRepository
+}
+
+func NewFixtureService(teamRepo TeamRepository, matchRepo MatchRepository) FixtureService {
+	return &fixtureService{teamRepo: teamRepo, matchRepo: matchRepo}
+}
+
+// Generate builds the schedule and, unless dryRun is set, saves every match in a single transaction
+func (s *fixtureService) Generate(ctx context.Context, opts domain.FixtureOptions, dryRun bool) ([][]domain.Match, error) {
+	// Check all teams are registered before proposing anything
+	if err := ensureTeamsExist(ctx, s.teamRepo, opts.Teams); err != nil {
+		return nil, err
+	}
+
+	rounds, err := GenerateRoundRobin(opts)
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		return rounds, nil
+	}
+
+	var matches []domain.Match
+	for _, round := range rounds {
+		matches = append(matches, round...)
+	}
+	ids, err := s.matchRepo.RegisterBatch(ctx, matches)
+	if err != nil {
+		return nil, err
+	}
+
+	next := 0
+	for _, round := range rounds {
+		for i := range round {
+			round[i].ID = ids[next]
+			next++
+		}
+	}
+	return rounds, nil
+}
+
+// GenerateRoundRobin returns the matches of each match day using the circle method.
+// Home and away are assigned so that every team alternates, with at most one break
+// (two home or two away games in a row) per team in each half of the schedule.
+// With an odd number of teams one team rests every match day
+func GenerateRoundRobin(opts domain.FixtureOptions) ([][]domain.Match, error) {
+	if len(opts.Teams) < 2 {
+		return nil, apperrors.ErrInvalidInput.WithMessage("at least two teams are required")
+	}
+	seen := make(map[string]bool, len(opts.Teams))
+	for _, team := range opts.Teams {
+		if seen[team] {
+			return nil, apperrors.ErrInvalidInput.WithMessage("duplicate team: " + team)
+		}
+		seen[team] = true
+	}
+	if opts.IntervalDays < 1 {
+		return nil, apperrors.ErrInvalidInput.WithMessage("interval days must be at least 1")
+	}
+	if len(opts.KickOffTimes) == 0 {
+		return nil, apperrors.ErrInvalidInput.WithMessage("at least one kick-off time is required")
+	}
+	for _, kickOff := range opts.KickOffTimes {
+		if _, err := time.Parse("15:04", kickOff); err != nil {
+			return nil, apperrors.ErrInvalidInput.WithMessage("invalid kick-off time format. Use HH:MM")
+		}
+	}
+
+	// An empty slot stands for a bye when the number of teams is odd
+	slots := append([]string(nil), opts.Teams...)
+	if len(slots)%2 == 1 {
+		slots = append(slots, "")
+	}
+	n := len(slots)
+
+	var pairings [][][2]string
+	for round := 0; round < n-1; round++ {
+		var pairs [][2]string
+		for i := 0; i < n/2; i++ {
+			home := slots[(round+i)%(n-1)]
+			away := slots[((round-i)%(n-1)+(n-1))%(n-1)]
+			if i == 0 {
+				away = slots[n-1]
+				if round%2 == 1 {
+					home, away = away, home
+				}
+			} else if i%2 == 1 {
+				home, away = away, home
+			}
+			if home == "" || away == "" {
+				continue
+			}
+			pairs = append(pairs, [2]string{home, away})
+		}
+		pairings = append(pairings, pairs)
+	}
+
+	// The second half repeats the first with home and away swapped
+	if opts.DoubleRound {
+		for round := 0; round < n-1; round++ {
+			var pairs [][2]string
+			for _, pair := range pairings[round] {
+				pairs = append(pairs, [2]string{pair[1], pair[0]})
+			}
+			pairings = append(pairings, pairs)
+		}
+	}
+
+	rounds := make([][]domain.Match, 0, len(pairings))
+	for round, pairs := range pairings {
+		date := opts.StartDate.AddDate(0, 0, round*opts.IntervalDays)
+		matches := make([]domain.Match, 0, len(pairs))
+		for i, pair := range pairs {
+			matches = append(matches, domain.Match{
+				MatchDate: date,
+				MatchTime: opts.KickOffTimes[i%len(opts.KickOffTimes)],
+				HomeTeam:  pair[0],
+				AwayTeam:  pair[1],
+				SeasonID:  opts.SeasonID,
+				Status:    domain.MatchScheduled,
+			})
+		}
+		rounds = append(rounds, matches)
+	}
+	return rounds, nil
+}
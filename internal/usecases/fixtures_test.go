@@ -0,0 +1,114 @@
+package usecases
+
+import (
+	"football-team-management/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fixtureOptions(teams int, double bool) domain.FixtureOptions {
+	opts := domain.FixtureOptions{
+		StartDate:    time.Date(2024, 8, 10, 0, 0, 0, 0, time.UTC),
+		IntervalDays: 7,
+		KickOffTimes: []string{"15:00", "19:30"},
+		DoubleRound:  double,
+	}
+	for i := 0; i < teams; i++ {
+		opts.Teams = append(opts.Teams, string(rune('A'+i)))
+	}
+	return opts
+}
+
+func TestGenerateRoundRobin(t *testing.T) {
+	t.Run("Every pair meets once in a single round robin", func(t *testing.T) {
+		for _, teams := range []int{2, 5, 6, 9, 20} {
+			rounds, err := GenerateRoundRobin(fixtureOptions(teams, false))
+			require.NoError(t, err)
+
+			met := map[[2]string]int{}
+			for _, round := range rounds {
+				playing := map[string]bool{}
+				for _, m := range round {
+					assert.False(t, playing[m.HomeTeam] || playing[m.AwayTeam], "team plays twice on one match day")
+					playing[m.HomeTeam], playing[m.AwayTeam] = true, true
+					pair := [2]string{m.HomeTeam, m.AwayTeam}
+					if pair[0] > pair[1] {
+						pair[0], pair[1] = pair[1], pair[0]
+					}
+					met[pair]++
+				}
+			}
+			assert.Len(t, met, teams*(teams-1)/2)
+			for _, count := range met {
+				assert.Equal(t, 1, count)
+			}
+		}
+	})
+
+	t.Run("Double round robin swaps home and away", func(t *testing.T) {
+		rounds, err := GenerateRoundRobin(fixtureOptions(6, true))
+		require.NoError(t, err)
+		require.Len(t, rounds, 10)
+
+		home := map[[2]string]int{}
+		for _, round := range rounds {
+			for _, m := range round {
+				home[[2]string{m.HomeTeam, m.AwayTeam}]++
+			}
+		}
+		assert.Len(t, home, 30)
+		for _, count := range home {
+			assert.Equal(t, 1, count)
+		}
+	})
+
+	t.Run("Home and away alternate with at most one break per team", func(t *testing.T) {
+		rounds, err := GenerateRoundRobin(fixtureOptions(10, false))
+		require.NoError(t, err)
+
+		venues := map[string]string{}
+		for _, round := range rounds {
+			for _, m := range round {
+				venues[m.HomeTeam] += "H"
+				venues[m.AwayTeam] += "A"
+			}
+		}
+		for team, sequence := range venues {
+			breaks := 0
+			for i := 1; i < len(sequence); i++ {
+				if sequence[i] == sequence[i-1] {
+					breaks++
+				}
+			}
+			assert.LessOrEqual(t, breaks, 1, "team %s plays %s", team, sequence)
+		}
+	})
+
+	t.Run("Dates and kick-off times follow the options", func(t *testing.T) {
+		rounds, err := GenerateRoundRobin(fixtureOptions(4, false))
+		require.NoError(t, err)
+
+		assert.Equal(t, "2024-08-10", rounds[0][0].MatchDate.Format("2006-01-02"))
+		assert.Equal(t, "2024-08-24", rounds[2][0].MatchDate.Format("2006-01-02"))
+		assert.Equal(t, "15:00", rounds[1][0].MatchTime)
+		assert.Equal(t, "19:30", rounds[1][1].MatchTime)
+	})
+
+	t.Run("Invalid options are rejected", func(t *testing.T) {
+		opts := fixtureOptions(4, false)
+		opts.Teams[1] = opts.Teams[0]
+		_, err := GenerateRoundRobin(opts)
+		assert.Error(t, err)
+
+		opts = fixtureOptions(4, false)
+		opts.KickOffTimes = []string{"7pm"}
+		_, err = GenerateRoundRobin(opts)
+		assert.Error(t, err)
+
+		_, err = GenerateRoundRobin(fixtureOptions(1, false))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,136 @@
+package usecases
+
+import (
+	"context"
+	"football-team-management/internal/domain"
+	"strconv"
+)
+
+type FormService interface {
+	Form(ctx context.Context, teamKey string, filter domain.MatchFilter, window int) (*domain.TeamForm, error)
+}
+
+type formService struct {
+	teamRepo   TeamRepository
+	matchRepo  MatchRepository
+	resultRepo MatchResultRepository
+}
+
+func NewFormService(teamRepo TeamRepository, matchRepo MatchRepository, resultRepo MatchResultRepository) FormService {
+	return &formService{teamRepo: teamRepo, matchRepo: matchRepo, resultRepo: resultRepo}
+}
+
+// Form reads the finished matches passing filter of the team with the given ID or slug. The window
+// defaults to five matches
+func (s *formService) Form(ctx context.Context, teamKey string, filter domain.MatchFilter, window int) (*domain.TeamForm, error) {
+	team, err := s.teamRepo.GetByKey(ctx, teamKey)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := s.matchRepo.ListByTeam(ctx, strconv.Itoa(team.ID), filter)
+	if err != nil {
+		return nil, err
+	}
+	results, err := s.resultRepo.ListByMatches(ctx, matchIDs(matches))
+	if err != nil {
+		return nil, err
+	}
+	if window <= 0 {
+		window = defaultFormLength
+	}
+	return computeForm(team.Name, matches, results, window), nil
+}
+
+// computeForm builds the form guide from the team's matches, which are expected in date order.
+// Matches that are not finished or have no result are skipped, a shootout counts as a draw
+func computeForm(team string, matches []domain.Match, results []domain.MatchResult, window int) *domain.TeamForm {
+	resultsByMatch := make(map[int]domain.MatchResult, len(results))
+	for _, result := range results {
+		resultsByMatch[result.MatchID] = result
+	}
+
+	// Most recent first
+	var played []domain.FormMatch
+	for i := len(matches) - 1; i >= 0; i-- {
+		match := matches[i]
+		result, ok := resultsByMatch[match.ID]
+		if !ok || match.Status != domain.MatchFinished {
+			continue
+		}
+
+		entry := domain.FormMatch{
+			MatchID:      match.ID,
+			MatchDate:    match.MatchDate.Format("2006-01-02"),
+			Opponent:     match.AwayTeam,
+			Venue:        "home",
+			GoalsFor:     result.HomeScore,
+			GoalsAgainst: result.AwayScore,
+		}
+		if match.AwayTeam == team {
+			entry.Opponent, entry.Venue = match.HomeTeam, "away"
+			entry.GoalsFor, entry.GoalsAgainst = result.AwayScore, result.HomeScore
+		}
+		switch {
+		case entry.GoalsFor > entry.GoalsAgainst:
+			entry.Outcome = "W"
+		case entry.GoalsFor < entry.GoalsAgainst:
+			entry.Outcome = "L"
+		default:
+			entry.Outcome = "D"
+		}
+		played = append(played, entry)
+	}
+
+	form := &domain.TeamForm{Team: team, Window: window, Form: []string{}, Matches: []domain.FormMatch{}}
+	for i, entry := range played {
+		if i >= window {
+			break
+		}
+		form.Form = append(form.Form, entry.Outcome)
+		form.Matches = append(form.Matches, entry)
+
+		record := &form.Home
+		if entry.Venue == "away" {
+			record = &form.Away
+		}
+		record.Played++
+		record.GoalsFor += entry.GoalsFor
+		record.GoalsAgainst += entry.GoalsAgainst
+		switch entry.Outcome {
+		case "W":
+			record.Won++
+		case "D":
+			record.Drawn++
+		case "L":
+			record.Lost++
+		}
+		if entry.GoalsAgainst == 0 {
+			form.CleanSheets++
+		}
+	}
+
+	form.Streaks = domain.FormStreaks{
+		Unbeaten: streak(played, func(m domain.FormMatch) bool { return m.Outcome != "L" }),
+		Winless:  streak(played, func(m domain.FormMatch) bool { return m.Outcome != "W" }),
+		Scoring:  streak(played, func(m domain.FormMatch) bool { return m.GoalsFor > 0 }),
+	}
+	if len(played) > 0 {
+		current := played[0].Outcome
+		form.Streaks.Current = current
+		form.Streaks.Length = streak(played, func(m domain.FormMatch) bool { return m.Outcome == current })
+	}
+	return form
+}
+
+// streak counts the matches from the most recent one backwards for which holds is true
+func streak(played []domain.FormMatch, holds func(domain.FormMatch) bool) int {
+	n := 0
+	for _, match := range played {
+		if !holds(match) {
+			break
+		}
+		n++
+	}
+	return n
+}
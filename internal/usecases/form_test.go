@@ -0,0 +1,58 @@
+package usecases
+
+import (
+	"football-team-management/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeForm(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 8, d, 0, 0, 0, 0, time.UTC) }
+	match := func(id, d int, home, away string) domain.Match {
+		m := fixture(id, home, away)
+		m.MatchDate = day(d)
+		return m
+	}
+	live := match(7, 20, "Persija", "Bali United")
+	live.Status = domain.MatchLive
+
+	// In date order, oldest first
+	matches := []domain.Match{
+		match(1, 1, "Persija", "Persib"),
+		match(2, 4, "Arema", "Persija"),
+		match(3, 8, "Persija", "PSM"),
+		match(4, 11, "Persebaya", "Persija"),
+		match(5, 15, "Persija", "Persis"),
+		match(6, 18, "Persija", "Madura United"),
+		live,
+	}
+	results := []domain.MatchResult{
+		score(1, 0, 1), // L
+		score(2, 0, 2), // W
+		score(3, 1, 1), // D
+		score(4, 0, 0), // D
+		score(5, 3, 0), // W
+		score(7, 0, 5),
+	}
+
+	form := computeForm("Persija", matches, results, 3)
+
+	assert.Equal(t, []string{"W", "D", "D"}, form.Form)
+	assert.Equal(t, 5, form.Matches[0].MatchID)
+	assert.Equal(t, "Persebaya", form.Matches[1].Opponent)
+	assert.Equal(t, "away", form.Matches[1].Venue)
+	assert.Equal(t, domain.FormRecord{Played: 2, Won: 1, Drawn: 1, GoalsFor: 4, GoalsAgainst: 1}, form.Home)
+	assert.Equal(t, domain.FormRecord{Played: 1, Drawn: 1}, form.Away)
+	assert.Equal(t, 2, form.CleanSheets)
+
+	assert.Equal(t, domain.FormStreaks{Current: "W", Length: 1, Unbeaten: 4, Winless: 0, Scoring: 1}, form.Streaks)
+}
+
+func TestComputeFormWithoutMatches(t *testing.T) {
+	form := computeForm("Persija", nil, nil, 5)
+
+	assert.Empty(t, form.Form)
+	assert.Equal(t, domain.FormStreaks{}, form.Streaks)
+}
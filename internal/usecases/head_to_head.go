@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"context"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+)
+
+// defaultFormLength is the number of matches in the form guide when none is asked for
+const defaultFormLength = 5
+
+type HeadToHeadService interface {
+	Compare(ctx context.Context, teamKey, opponentKey string, last int) (*domain.HeadToHead, error)
+}
+
+type headToHeadService struct {
+	teamRepo   TeamRepository
+	resultRepo MatchResultRepository
+}
+
+func NewHeadToHeadService(teamRepo TeamRepository, resultRepo MatchResultRepository) HeadToHeadService {
+	return &headToHeadService{teamRepo: teamRepo, resultRepo: resultRepo}
+}
+
+// Compare summarises the finished matches between the teams with the given IDs or slugs. The form
+// guide covers the last matches, five unless last is positive
+func (s *headToHeadService) Compare(ctx context.Context, teamKey, opponentKey string, last int) (*domain.HeadToHead, error) {
+	team, err := s.teamRepo.GetByKey(ctx, teamKey)
+	if err != nil {
+		return nil, err
+	}
+	opponent, err := s.teamRepo.GetByKey(ctx, opponentKey)
+	if err != nil {
+		return nil, err
+	}
+	if team.ID == opponent.ID {
+		return nil, apperrors.ErrInvalidInput.WithMessage("a team cannot be compared with itself")
+	}
+
+	matches, err := s.resultRepo.ListBetween(ctx, team.Name, opponent.Name)
+	if err != nil {
+		return nil, err
+	}
+	if last <= 0 {
+		last = defaultFormLength
+	}
+	return computeHeadToHead(team.Name, opponent.Name, matches, last), nil
+}
+
+// computeHeadToHead aggregates matches, which are expected most recent first, from the point of view of team
+func computeHeadToHead(team, opponent string, matches []domain.HeadToHeadMatch, last int) *domain.HeadToHead {
+	h2h := &domain.HeadToHead{Team: team, Opponent: opponent, Form: []string{}, Matches: []domain.HeadToHeadMatch{}}
+	biggestWin, biggestDefeat := 0, 0
+
+	for _, match := range matches {
+		scored, conceded := match.HomeScore, match.AwayScore
+		if match.AwayTeam == team {
+			scored, conceded = conceded, scored
+		}
+
+		match.Winner = ""
+		switch {
+		case match.HomeScore > match.AwayScore:
+			match.Winner = match.HomeTeam
+		case match.AwayScore > match.HomeScore:
+			match.Winner = match.AwayTeam
+		case match.HomePenalties != nil && *match.HomePenalties > *match.AwayPenalties:
+			match.Winner = match.HomeTeam
+		case match.HomePenalties != nil && *match.AwayPenalties > *match.HomePenalties:
+			match.Winner = match.AwayTeam
+		}
+
+		h2h.Played++
+		h2h.GoalsFor += scored
+		h2h.GoalsAgainst += conceded
+
+		outcome := "D"
+		switch margin := scored - conceded; {
+		case margin > 0:
+			outcome = "W"
+			h2h.Wins++
+			if margin > biggestWin {
+				biggestWin = margin
+				h2h.BiggestWin = &match
+			}
+		case margin < 0:
+			outcome = "L"
+			h2h.Losses++
+			if -margin > biggestDefeat {
+				biggestDefeat = -margin
+				h2h.BiggestDefeat = &match
+			}
+		default:
+			h2h.Draws++
+		}
+		if len(h2h.Form) < last {
+			h2h.Form = append(h2h.Form, outcome)
+		}
+
+		h2h.Matches = append(h2h.Matches, match)
+	}
+	return h2h
+}
@@ -0,0 +1,55 @@
+package usecases
+
+import (
+	"football-team-management/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func played(id int, date, home, away string, homeScore, awayScore int) domain.HeadToHeadMatch {
+	return domain.HeadToHeadMatch{MatchID: id, MatchDate: date, HomeTeam: home, AwayTeam: away, HomeScore: homeScore, AwayScore: awayScore}
+}
+
+func TestComputeHeadToHead(t *testing.T) {
+	shootout := played(5, "2024-05-01", "Persib", "Persija", 1, 1)
+	home, away := 4, 3
+	shootout.HomePenalties, shootout.AwayPenalties = &home, &away
+
+	// Most recent first
+	matches := []domain.HeadToHeadMatch{
+		shootout,
+		played(4, "2024-03-01", "Persija", "Persib", 3, 0),
+		played(3, "2023-10-01", "Persib", "Persija", 0, 3),
+		played(2, "2023-03-01", "Persija", "Persib", 0, 2),
+		played(1, "2022-10-01", "Persib", "Persija", 2, 2),
+	}
+
+	h2h := computeHeadToHead("Persija", "Persib", matches, 3)
+
+	assert.Equal(t, 5, h2h.Played)
+	assert.Equal(t, 2, h2h.Wins)
+	assert.Equal(t, 2, h2h.Draws)
+	assert.Equal(t, 1, h2h.Losses)
+	assert.Equal(t, 9, h2h.GoalsFor)
+	assert.Equal(t, 5, h2h.GoalsAgainst)
+	assert.Equal(t, []string{"D", "W", "W"}, h2h.Form)
+
+	require.NotNil(t, h2h.BiggestWin)
+	assert.Equal(t, 4, h2h.BiggestWin.MatchID, "the most recent of two equal margins")
+	require.NotNil(t, h2h.BiggestDefeat)
+	assert.Equal(t, 2, h2h.BiggestDefeat.MatchID)
+
+	assert.Equal(t, "Persib", h2h.Matches[0].Winner, "shootout winner")
+	assert.Equal(t, "", h2h.Matches[4].Winner)
+}
+
+func TestComputeHeadToHeadWithoutMatches(t *testing.T) {
+	h2h := computeHeadToHead("Persija", "Persib", nil, 5)
+
+	assert.Equal(t, 0, h2h.Played)
+	assert.Nil(t, h2h.BiggestWin)
+	assert.Empty(t, h2h.Form)
+	assert.NotNil(t, h2h.Matches)
+}
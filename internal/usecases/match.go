@@ -3,20 +3,43 @@ package usecases
 import (
 	"context"
 	"errors"
+	"fmt"
 	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type MatchRepository interface {
-	Register(ctx context.Context, match domain.Match) error
+	Register(ctx context.Context, match domain.Match) (int, error)
+	RegisterBatch(ctx context.Context, matches []domain.Match) ([]int, error)
 	Update(ctx context.Context, id int, match domain.Match) error
 	Delete(ctx context.Context, id int) error
-	List(ctx context.Context) ([]domain.Match, error)
-	ListByTeam(ctx context.Context, teamName string) ([]domain.Match, error)
+	List(ctx context.Context, filter domain.MatchFilter) ([]domain.Match, error)
+	ListByTeam(ctx context.Context, teamKey string, filter domain.MatchFilter) ([]domain.Match, error)
+	Search(ctx context.Context, query domain.MatchQuery) (*domain.Page[domain.Match], error)
 	GetByID(ctx context.Context, id int) (*domain.Match, error)
 	Restore(ctx context.Context, id int) error
+	UpdateStatus(ctx context.Context, id int, status domain.MatchStatus) (*domain.Match, error)
+}
+
+// matchFilterClause applies a domain.MatchFilter passed as $1 (season ID), $2 (competition ID), $3 (from) and $4 (to)
+const matchFilterClause = `($1::int IS NULL OR season_id = $1) AND ($2::int IS NULL OR season_id IN (SELECT id FROM seasons WHERE competition_id = $2))` +
+	` AND ($3::date IS NULL OR match_date >= $3) AND ($4::date IS NULL OR match_date <= $4)`
+
+// matchSelect reads matches with the names of the teams they are stored against by ID
+const matchFrom = ` FROM matches m JOIN teams home ON home.id = m.home_team_id JOIN teams away ON away.id = m.away_team_id`
+
+const matchSelect = `SELECT m.id, m.match_date, m.match_time, home.name, away.name, m.season_id, m.status, m.created_at, m.updated_at, m.deleted_at` + matchFrom
+
+// matchSortColumns maps the sort fields of domain.MatchSortFields to columns
+var matchSortColumns = map[string][]string{
+	"date":       {"m.match_date", "m.match_time"},
+	"id":         {"m.id"},
+	"status":     {"m.status"},
+	"created_at": {"m.created_at"},
 }
 
 type PostgresMatchRepo struct {
@@ -27,42 +50,32 @@ func NewPostgresMatchRepo(pool *pgxpool.Pool) *PostgresMatchRepo {
 	return &PostgresMatchRepo{pool: pool}
 }
 
-func (r *PostgresMatchRepo) Register(ctx context.Context, match domain.Match) error {
-	// Check if home team exists
-	var homeTeamExists bool
-	err := r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM teams WHERE name = $1 AND deleted_at IS NULL)`, match.HomeTeam).Scan(&homeTeamExists)
-	if err != nil {
-		return err
-	}
-	if !homeTeamExists {
-		return errors.New("home team not found")
-	}
+func (r *PostgresMatchRepo) Register(ctx context.Context, match domain.Match) (int, error) {
+	return r.register(ctx, r.pool, match)
+}
 
-	// Check if away team exists
-	var awayTeamExists bool
-	err = r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM teams WHERE name = $1 AND deleted_at IS NULL)`, match.AwayTeam).Scan(&awayTeamExists)
+// RegisterBatch registers all matches in one transaction, so either every match is saved or none is
+func (r *PostgresMatchRepo) RegisterBatch(ctx context.Context, matches []domain.Match) ([]int, error) {
+	// Start transaction
+	tx, err := r.pool.Begin(ctx)
 	if err != nil {
-		return err
-	}
-	if !awayTeamExists {
-		return errors.New("away team not found")
+		return nil, err
 	}
+	defer tx.Rollback(ctx)
 
-	// Check if teams are different
-	if match.HomeTeam == match.AwayTeam {
-		return errors.New("home team and away team cannot be the same")
+	ids := make([]int, 0, len(matches))
+	for i, match := range matches {
+		id, err := r.register(ctx, tx, match)
+		if err != nil {
+			return nil, withContext(err, fmt.Sprintf("match %d (%s vs %s)", i+1, match.HomeTeam, match.AwayTeam))
+		}
+		ids = append(ids, id)
 	}
 
-	// Parse the time string to time.Time
-	matchTime, err := time.Parse("15:04", match.MatchTime)
-	if err != nil {
-		return errors.New("invalid time format. Use HH:MM")
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
 	}
-
-	now := time.Now()
-	_, err = r.pool.Exec(ctx, `INSERT INTO matches (match_date, match_time, home_team, away_team, created_at, updated_at, deleted_at) VALUES ($1, $2, $3, $4, $5, $6, NULL)`,
-		match.MatchDate, matchTime, match.HomeTeam, match.AwayTeam, now, now)
-	return err
+	return ids, nil
 }
 
 func (r *PostgresMatchRepo) Update(ctx context.Context, id int, match domain.Match) error {
@@ -73,7 +86,7 @@ func (r *PostgresMatchRepo) Update(ctx context.Context, id int, match domain.Mat
 		return err
 	}
 	if !homeTeamExists {
-		return errors.New("home team not found")
+		return apperrors.ErrTeamNotFound.WithMessage("home team not found")
 	}
 
 	// Check if away team exists
@@ -83,28 +96,33 @@ func (r *PostgresMatchRepo) Update(ctx context.Context, id int, match domain.Mat
 		return err
 	}
 	if !awayTeamExists {
-		return errors.New("away team not found")
+		return apperrors.ErrTeamNotFound.WithMessage("away team not found")
 	}
 
 	// Check if teams are different
 	if match.HomeTeam == match.AwayTeam {
-		return errors.New("home team and away team cannot be the same")
+		return apperrors.ErrInvalidInput.WithMessage("home team and away team cannot be the same")
+	}
+
+	// Check the season exists and covers the match date
+	if err := checkSeason(ctx, r.pool, match); err != nil {
+		return err
 	}
 
 	// Parse the time string to time.Time
 	matchTime, err := time.Parse("15:04", match.MatchTime)
 	if err != nil {
-		return errors.New("invalid time format. Use HH:MM")
+		return apperrors.ErrInvalidInput.WithMessage("invalid time format. Use HH:MM")
 	}
 
 	now := time.Now()
-	cmd, err := r.pool.Exec(ctx, `UPDATE matches SET match_date=$1, match_time=$2, home_team=$3, away_team=$4, updated_at=$5 WHERE id=$6 AND deleted_at IS NULL`,
-		match.MatchDate, matchTime, match.HomeTeam, match.AwayTeam, now, id)
+	cmd, err := r.pool.Exec(ctx, `UPDATE matches SET match_date=$1, match_time=$2, home_team_id=(SELECT id FROM teams WHERE name = $3), away_team_id=(SELECT id FROM teams WHERE name = $4), season_id=$5, updated_at=$6 WHERE id=$7 AND deleted_at IS NULL`,
+		match.MatchDate, matchTime, match.HomeTeam, match.AwayTeam, match.SeasonID, now, id)
 	if err != nil {
-		return err
+		return dbError(err)
 	}
 	if cmd.RowsAffected() == 0 {
-		return errors.New("match not found")
+		return apperrors.ErrMatchNotFound
 	}
 	return nil
 }
@@ -116,34 +134,44 @@ func (r *PostgresMatchRepo) Delete(ctx context.Context, id int) error {
 		return err
 	}
 	if cmd.RowsAffected() == 0 {
-		return errors.New("match not found")
+		return apperrors.ErrMatchNotFound
 	}
 	return nil
 }
 
-func (r *PostgresMatchRepo) List(ctx context.Context) ([]domain.Match, error) {
-	rows, err := r.pool.Query(ctx, `SELECT id, match_date, match_time, home_team, away_team, created_at, updated_at, deleted_at FROM matches WHERE deleted_at IS NULL ORDER BY match_date, match_time`)
-	if err != nil {
+func (r *PostgresMatchRepo) List(ctx context.Context, filter domain.MatchFilter) ([]domain.Match, error) {
+	return r.listMatches(ctx, matchSelect+` WHERE m.deleted_at IS NULL AND `+matchFilterClause+` ORDER BY m.match_date, m.match_time`,
+		filter.SeasonID, filter.CompetitionID, filter.From, filter.To)
+}
+
+// ListByTeam returns the matches of the team with the given ID or slug
+func (r *PostgresMatchRepo) ListByTeam(ctx context.Context, teamKey string, filter domain.MatchFilter) ([]domain.Match, error) {
+	return r.listMatches(ctx, matchSelect+` WHERE ($5::int IN (home.id, away.id) OR $6 IN (home.slug, away.slug)) AND m.deleted_at IS NULL AND `+matchFilterClause+` ORDER BY m.match_date, m.match_time`,
+		filter.SeasonID, filter.CompetitionID, filter.From, filter.To, keyID(teamKey), teamKey)
+}
+
+// Search returns a page of the matches, filtered, sorted and cut by the database
+func (r *PostgresMatchRepo) Search(ctx context.Context, query domain.MatchQuery) (*domain.Page[domain.Match], error) {
+	page := query.PageRequest.Normalized(domain.DefaultMatchSort)
+	// $6 is the team's slug and $5 its ID, $7 the venue the team played at
+	where := ` WHERE ` + deletedClause(query.Deleted, "m.deleted_at") + ` AND ` + matchFilterClause + ` AND ($6::text IS NULL` +
+		` OR ($7::text IS DISTINCT FROM 'away' AND ($5::int = home.id OR $6 = home.slug))` +
+		` OR ($7::text IS DISTINCT FROM 'home' AND ($5::int = away.id OR $6 = away.slug)))`
+	args := []any{query.SeasonID, query.CompetitionID, query.From, query.To, keyID(query.Team), optional(query.Team), optional(query.Venue)}
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT count(*)`+matchFrom+where, args...).Scan(&total); err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-	var matches []domain.Match
-	for rows.Next() {
-		var m domain.Match
-		var deletedAt *time.Time
-		var matchTime time.Time
-		if err := rows.Scan(&m.ID, &m.MatchDate, &matchTime, &m.HomeTeam, &m.AwayTeam, &m.CreatedAt, &m.UpdatedAt, &deletedAt); err != nil {
-			return nil, err
-		}
-		m.MatchTime = matchTime.Format("15:04")
-		m.DeletedAt = deletedAt
-		matches = append(matches, m)
+	matches, err := r.listMatches(ctx, matchSelect+where+orderBy(page, matchSortColumns, "m.id")+` LIMIT $8 OFFSET $9`, append(args, page.Limit, page.Offset)...)
+	if err != nil {
+		return nil, err
 	}
-	return matches, nil
+	return domain.NewPage(matches, total, page), nil
 }
 
-func (r *PostgresMatchRepo) ListByTeam(ctx context.Context, teamName string) ([]domain.Match, error) {
-	rows, err := r.pool.Query(ctx, `SELECT id, match_date, match_time, home_team, away_team, created_at, updated_at, deleted_at FROM matches WHERE (home_team = $1 OR away_team = $1) AND deleted_at IS NULL ORDER BY match_date, match_time`, teamName)
+func (r *PostgresMatchRepo) listMatches(ctx context.Context, query string, args ...any) ([]domain.Match, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -153,22 +181,25 @@ func (r *PostgresMatchRepo) ListByTeam(ctx context.Context, teamName string) ([]
 		var m domain.Match
 		var deletedAt *time.Time
 		var matchTime time.Time
-		if err := rows.Scan(&m.ID, &m.MatchDate, &matchTime, &m.HomeTeam, &m.AwayTeam, &m.CreatedAt, &m.UpdatedAt, &deletedAt); err != nil {
+		if err := rows.Scan(&m.ID, &m.MatchDate, &matchTime, &m.HomeTeam, &m.AwayTeam, &m.SeasonID, &m.Status, &m.CreatedAt, &m.UpdatedAt, &deletedAt); err != nil {
 			return nil, err
 		}
 		m.MatchTime = matchTime.Format("15:04")
 		m.DeletedAt = deletedAt
 		matches = append(matches, m)
 	}
-	return matches, nil
+	return matches, rows.Err()
 }
 
 func (r *PostgresMatchRepo) GetByID(ctx context.Context, id int) (*domain.Match, error) {
 	var m domain.Match
 	var deletedAt *time.Time
 	var matchTime time.Time
-	err := r.pool.QueryRow(ctx, `SELECT id, match_date, match_time, home_team, away_team, created_at, updated_at, deleted_at FROM matches WHERE id = $1 AND deleted_at IS NULL`, id).
-		Scan(&m.ID, &m.MatchDate, &matchTime, &m.HomeTeam, &m.AwayTeam, &m.CreatedAt, &m.UpdatedAt, &deletedAt)
+	err := r.pool.QueryRow(ctx, matchSelect+` WHERE m.id = $1 AND m.deleted_at IS NULL`, id).
+		Scan(&m.ID, &m.MatchDate, &matchTime, &m.HomeTeam, &m.AwayTeam, &m.SeasonID, &m.Status, &m.CreatedAt, &m.UpdatedAt, &deletedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperrors.ErrMatchNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -184,7 +215,112 @@ func (r *PostgresMatchRepo) Restore(ctx context.Context, id int) error {
 		return err
 	}
 	if cmd.RowsAffected() == 0 {
-		return errors.New("match not found or not deleted")
+		return apperrors.ErrMatchNotFound.WithMessage("match not found or not deleted")
+	}
+	return nil
+}
+
+// UpdateStatus moves a match to a new status, enforcing the allowed transitions
+func (r *PostgresMatchRepo) UpdateStatus(ctx context.Context, id int, status domain.MatchStatus) (*domain.Match, error) {
+	// Start transaction
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock the match so concurrent transitions are applied one after the other
+	var m domain.Match
+	var matchTime time.Time
+	err = tx.QueryRow(ctx, `SELECT m.id, m.match_date, m.match_time, home.name, away.name, m.season_id, m.status, m.created_at, m.updated_at FROM matches m JOIN teams home ON home.id = m.home_team_id JOIN teams away ON away.id = m.away_team_id WHERE m.id = $1 AND m.deleted_at IS NULL FOR UPDATE OF m`, id).
+		Scan(&m.ID, &m.MatchDate, &matchTime, &m.HomeTeam, &m.AwayTeam, &m.SeasonID, &m.Status, &m.CreatedAt, &m.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperrors.ErrMatchNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	m.MatchTime = matchTime.Format("15:04")
+
+	now := time.Now()
+	if err := m.TransitionTo(status, now); err != nil {
+		return nil, apperrors.Invalid(err)
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE matches SET status=$1, updated_at=$2 WHERE id=$3`, m.Status, now, id)
+	if err != nil {
+		return nil, err
+	}
+	m.UpdatedAt = now
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// Helper method holding the checks and insert shared by Register and RegisterBatch
+func (r *PostgresMatchRepo) register(ctx context.Context, q querier, match domain.Match) (int, error) {
+	// Check if home team exists
+	var homeTeamExists bool
+	err := q.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM teams WHERE name = $1 AND deleted_at IS NULL)`, match.HomeTeam).Scan(&homeTeamExists)
+	if err != nil {
+		return 0, err
+	}
+	if !homeTeamExists {
+		return 0, apperrors.ErrTeamNotFound.WithMessage("home team not found")
+	}
+
+	// Check if away team exists
+	var awayTeamExists bool
+	err = q.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM teams WHERE name = $1 AND deleted_at IS NULL)`, match.AwayTeam).Scan(&awayTeamExists)
+	if err != nil {
+		return 0, err
+	}
+	if !awayTeamExists {
+		return 0, apperrors.ErrTeamNotFound.WithMessage("away team not found")
+	}
+
+	// Check if teams are different
+	if match.HomeTeam == match.AwayTeam {
+		return 0, apperrors.ErrInvalidInput.WithMessage("home team and away team cannot be the same")
+	}
+
+	// Check the season exists and covers the match date
+	if err := checkSeason(ctx, q, match); err != nil {
+		return 0, err
+	}
+
+	// Parse the time string to time.Time
+	matchTime, err := time.Parse("15:04", match.MatchTime)
+	if err != nil {
+		return 0, apperrors.ErrInvalidInput.WithMessage("invalid time format. Use HH:MM")
+	}
+
+	now := time.Now()
+	var id int
+	err = q.QueryRow(ctx, `INSERT INTO matches (match_date, match_time, home_team_id, away_team_id, season_id, status, created_at, updated_at, deleted_at) VALUES ($1, $2, (SELECT id FROM teams WHERE name = $3), (SELECT id FROM teams WHERE name = $4), $5, 'scheduled', $6, $7, NULL) RETURNING id`,
+		match.MatchDate, matchTime, match.HomeTeam, match.AwayTeam, match.SeasonID, now, now).Scan(&id)
+	return id, dbError(err)
+}
+
+// checkSeason verifies that a match's season exists and that the match date falls within it
+func checkSeason(ctx context.Context, q querier, match domain.Match) error {
+	if match.SeasonID == nil {
+		return nil
+	}
+
+	var season domain.Season
+	err := q.QueryRow(ctx, `SELECT start_date, end_date FROM seasons WHERE id = $1 AND deleted_at IS NULL`, *match.SeasonID).
+		Scan(&season.StartDate, &season.EndDate)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apperrors.ErrSeasonNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if !season.Contains(match.MatchDate) {
+		return apperrors.ErrInvalidInput.WithMessage("match date is outside the season")
 	}
 	return nil
 }
@@ -0,0 +1,224 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type MatchEventRepository interface {
+	Register(ctx context.Context, event domain.MatchEvent) (int, error)
+	Delete(ctx context.Context, id int) error
+	GetByID(ctx context.Context, id int) (*domain.MatchEvent, error)
+	ListByMatch(ctx context.Context, matchID int) ([]domain.MatchEvent, error)
+	ListByMatches(ctx context.Context, matchIDs []int) ([]domain.MatchEvent, error)
+}
+
+// matchEventColumns are the columns scanned by scanMatchEvent
+const matchEventColumns = `id, match_id, event_type, event_time, period, team, player, COALESCE(goal_type, ''), COALESCE(assist, ''), COALESCE(player_in, ''), created_at, updated_at, deleted_at`
+
+// matchEventOrder sorts events by period first, so stoppage time of the first half comes before the second half
+const matchEventOrder = `array_position(ARRAY['first_half', 'second_half', 'extra_time'], period), elapsed_seconds, id`
+
+type PostgresMatchEventRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresMatchEventRepo(pool *pgxpool.Pool) *PostgresMatchEventRepo {
+	return &PostgresMatchEventRepo{pool: pool}
+}
+
+// Register records an event. A goal also updates the score of the match result, if there is one
+func (r *PostgresMatchEventRepo) Register(ctx context.Context, event domain.MatchEvent) (int, error) {
+	if err := event.Validate(); err != nil {
+		return 0, apperrors.Invalid(err)
+	}
+
+	// Check the match exists and has kicked off
+	if err := checkMatchPlayed(ctx, r.pool, event.MatchID); err != nil {
+		return 0, err
+	}
+
+	// Check the scorer plays for the side the goal belongs to
+	if event.Type == domain.EventGoal {
+		if err := checkScorers(ctx, r.pool, event.MatchID, []domain.Goal{event.ToGoal()}); err != nil {
+			return 0, err
+		}
+	}
+
+	// Start transaction
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	now := time.Now()
+	id, err := insertMatchEvent(ctx, tx, event, now)
+	if err != nil {
+		return 0, err
+	}
+
+	if event.Type == domain.EventGoal {
+		if err := syncResultScore(ctx, tx, event.MatchID, now); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// Delete removes an event. Removing a goal also updates the score of the match result, if there is one
+func (r *PostgresMatchEventRepo) Delete(ctx context.Context, id int) error {
+	// Start transaction
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	now := time.Now()
+	var matchID int
+	var eventType string
+	err = tx.QueryRow(ctx, `UPDATE match_events SET deleted_at=$1, updated_at=$2 WHERE id=$3 AND deleted_at IS NULL RETURNING match_id, event_type`, now, now, id).
+		Scan(&matchID, &eventType)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apperrors.ErrMatchEventNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if eventType == domain.EventGoal {
+		if err := syncResultScore(ctx, tx, matchID, now); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *PostgresMatchEventRepo) GetByID(ctx context.Context, id int) (*domain.MatchEvent, error) {
+	row := r.pool.QueryRow(ctx, `SELECT `+matchEventColumns+` FROM match_events WHERE id = $1 AND deleted_at IS NULL`, id)
+	event, err := scanMatchEvent(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperrors.ErrMatchEventNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// ListByMatch returns the timeline of a match in the order the events happened
+func (r *PostgresMatchEventRepo) ListByMatch(ctx context.Context, matchID int) ([]domain.MatchEvent, error) {
+	return listMatchEvents(ctx, r.pool, matchID, "")
+}
+
+// ListByMatches returns the events of all given matches in one query, ordered by match and then as ListByMatch
+func (r *PostgresMatchEventRepo) ListByMatches(ctx context.Context, matchIDs []int) ([]domain.MatchEvent, error) {
+	return listEventsOfMatches(ctx, r.pool, matchIDs, "")
+}
+
+// listEventsOfMatches returns the events of all given matches in one query, ordered by match and then in the
+// order they happened, only those of eventType unless it is empty
+func listEventsOfMatches(ctx context.Context, q querier, matchIDs []int, eventType string) ([]domain.MatchEvent, error) {
+	rows, err := q.Query(ctx, `SELECT `+matchEventColumns+` FROM match_events WHERE match_id = ANY($1) AND ($2 = '' OR event_type = $2) AND deleted_at IS NULL ORDER BY match_id, `+matchEventOrder, matchIDs, eventType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []domain.MatchEvent
+	for rows.Next() {
+		event, err := scanMatchEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, *event)
+	}
+	return events, rows.Err()
+}
+
+// listMatchEvents returns the events of a match in the order they happened, only those of eventType unless it is empty
+func listMatchEvents(ctx context.Context, q querier, matchID int, eventType string) ([]domain.MatchEvent, error) {
+	rows, err := q.Query(ctx, `SELECT `+matchEventColumns+` FROM match_events WHERE match_id = $1 AND ($2 = '' OR event_type = $2) AND deleted_at IS NULL ORDER BY `+matchEventOrder, matchID, eventType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []domain.MatchEvent
+	for rows.Next() {
+		event, err := scanMatchEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, *event)
+	}
+	return events, rows.Err()
+}
+
+func scanMatchEvent(row pgx.Row) (*domain.MatchEvent, error) {
+	var event domain.MatchEvent
+	var deletedAt *time.Time
+	err := row.Scan(&event.ID, &event.MatchID, &event.Type, &event.EventTime, &event.Period, &event.Team, &event.Player,
+		&event.GoalType, &event.Assist, &event.PlayerIn, &event.CreatedAt, &event.UpdatedAt, &deletedAt)
+	if err != nil {
+		return nil, err
+	}
+	event.DeletedAt = deletedAt
+	return &event, nil
+}
+
+// insertMatchEvent inserts an already validated event
+func insertMatchEvent(ctx context.Context, q querier, event domain.MatchEvent, now time.Time) (int, error) {
+	seconds, err := event.Seconds()
+	if err != nil {
+		return 0, err
+	}
+
+	var id int
+	err = q.QueryRow(ctx, `INSERT INTO match_events (match_id, event_type, event_time, elapsed_seconds, period, team, player, goal_type, assist, player_in, created_at, updated_at, deleted_at) VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), NULLIF($9, ''), NULLIF($10, ''), $11, $12, NULL) RETURNING id`,
+		event.MatchID, event.Type, event.EventTime, seconds, event.Period, event.Team, event.Player, event.GoalType, event.Assist, event.PlayerIn, now, now).Scan(&id)
+	return id, dbError(err)
+}
+
+// syncResultScore recounts the score of a match result from its goal events. A penalty shootout
+// can only follow a level score, so goals that would break the tie are rejected
+func syncResultScore(ctx context.Context, q querier, matchID int, now time.Time) error {
+	var homeScore, awayScore int
+	err := q.QueryRow(ctx, `UPDATE match_results SET
+			home_score = (SELECT COUNT(*) FROM match_events WHERE match_id = $1 AND event_type = 'goal' AND team = 'home' AND deleted_at IS NULL),
+			away_score = (SELECT COUNT(*) FROM match_events WHERE match_id = $1 AND event_type = 'goal' AND team = 'away' AND deleted_at IS NULL),
+			updated_at = $2
+		WHERE match_id = $1 AND deleted_at IS NULL RETURNING home_score, away_score`, matchID, now).Scan(&homeScore, &awayScore)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// No result reported yet, it takes over the goal events once it is
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if homeScore == awayScore {
+		return nil
+	}
+
+	var shootoutExists bool
+	err = q.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM shootout_kicks WHERE match_id = $1 AND deleted_at IS NULL)`, matchID).Scan(&shootoutExists)
+	if err != nil {
+		return err
+	}
+	if shootoutExists {
+		return apperrors.ErrInvalidInput.WithMessage("the match was decided on penalties, goals cannot break the level score")
+	}
+	return nil
+}
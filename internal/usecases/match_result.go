@@ -3,9 +3,12 @@ package usecases
 import (
 	"context"
 	"errors"
+	"fmt"
 	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -14,9 +17,12 @@ type MatchResultRepository interface {
 	Update(ctx context.Context, id int, result domain.MatchResult) error
 	Delete(ctx context.Context, id int) error
 	List(ctx context.Context) ([]domain.MatchResult, error)
+	ListByMatches(ctx context.Context, matchIDs []int) ([]domain.MatchResult, error)
+	Search(ctx context.Context, query domain.MatchResultQuery) (*domain.Page[domain.MatchResult], error)
 	GetByMatchID(ctx context.Context, matchID int) (*domain.MatchResult, error)
 	GetByID(ctx context.Context, id int) (*domain.MatchResult, error)
 	Restore(ctx context.Context, id int) error
+	ListBetween(ctx context.Context, team, opponent string) ([]domain.HeadToHeadMatch, error)
 }
 
 type PostgresMatchResultRepo struct {
@@ -28,42 +34,39 @@ func NewPostgresMatchResultRepo(pool *pgxpool.Pool) *PostgresMatchResultRepo {
 }
 
 func (r *PostgresMatchResultRepo) Register(ctx context.Context, result domain.MatchResult) error {
-	// Check if match exists
-	var matchExists bool
-	err := r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM matches WHERE id = $1 AND deleted_at IS NULL)`, result.MatchID).Scan(&matchExists)
-	if err != nil {
+	// Check the match exists and has kicked off
+	if err := checkMatchPlayed(ctx, r.pool, result.MatchID); err != nil {
 		return err
 	}
-	if !matchExists {
-		return errors.New("match not found")
-	}
 
 	// Check if result already exists for this match
 	var resultExists bool
-	err = r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM match_results WHERE match_id = $1 AND deleted_at IS NULL)`, result.MatchID).Scan(&resultExists)
+	err := r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM match_results WHERE match_id = $1 AND deleted_at IS NULL)`, result.MatchID).Scan(&resultExists)
 	if err != nil {
 		return err
 	}
 	if resultExists {
-		return errors.New("result already exists for this match")
+		return apperrors.ErrMatchResultAlreadyExists
 	}
 
-	// Validate that scores match the number of goals
-	homeGoals := 0
-	awayGoals := 0
-	for _, goal := range result.Goals {
-		if goal.Team == "home" {
-			homeGoals++
-		} else if goal.Team == "away" {
-			awayGoals++
+	// Without goals in the request, the goals already recorded on the match timeline make up the score
+	fromTimeline := len(result.Goals) == 0
+	if fromTimeline {
+		goals, err := r.getGoalsByMatchID(ctx, result.MatchID)
+		if err != nil {
+			return err
 		}
+		result.Goals = goals
 	}
 
-	if homeGoals != result.HomeScore {
-		return errors.New("home score does not match number of home goals")
+	// Validate the goals against the score, and the penalty shootout
+	if err := result.Validate(); err != nil {
+		return apperrors.Invalid(err)
 	}
-	if awayGoals != result.AwayScore {
-		return errors.New("away score does not match number of away goals")
+
+	// Check every scorer plays for the side the goal belongs to
+	if err := checkScorers(ctx, r.pool, result.MatchID, result.Goals); err != nil {
+		return err
 	}
 
 	// Start transaction
@@ -80,48 +83,54 @@ func (r *PostgresMatchResultRepo) Register(ctx context.Context, result domain.Ma
 	err = tx.QueryRow(ctx, `INSERT INTO match_results (match_id, home_score, away_score, created_at, updated_at, deleted_at) VALUES ($1, $2, $3, $4, $5, NULL) RETURNING id`,
 		result.MatchID, result.HomeScore, result.AwayScore, now, now).Scan(&resultID)
 	if err != nil {
-		return err
+		return dbError(err)
 	}
 
-	// Insert goals
-	for _, goal := range result.Goals {
-		_, err = tx.Exec(ctx, `INSERT INTO goals (match_id, scorer, goal_time, team, created_at, updated_at, deleted_at) VALUES ($1, $2, $3, $4, $5, $6, NULL)`,
-			result.MatchID, goal.Scorer, goal.GoalTime, goal.Team, now, now)
-		if err != nil {
-			return err
-		}
+	// The goals of the result replace the goal events recorded so far
+	if err := replaceResultDetails(ctx, tx, result, fromTimeline, now); err != nil {
+		return err
 	}
 
 	return tx.Commit(ctx)
 }
 
 func (r *PostgresMatchResultRepo) Update(ctx context.Context, id int, result domain.MatchResult) error {
-	// Check if result exists
-	var resultExists bool
-	err := r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM match_results WHERE id = $1 AND deleted_at IS NULL)`, id).Scan(&resultExists)
+	// Check if result exists, it stays with the match it was registered for
+	var matchID int
+	err := r.pool.QueryRow(ctx, `SELECT match_id FROM match_results WHERE id = $1 AND deleted_at IS NULL`, id).Scan(&matchID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apperrors.ErrMatchResultNotFound
+	}
 	if err != nil {
 		return err
 	}
-	if !resultExists {
-		return errors.New("match result not found")
+	if result.MatchID != matchID {
+		return errResultMoved
 	}
 
-	// Validate that scores match the number of goals
-	homeGoals := 0
-	awayGoals := 0
-	for _, goal := range result.Goals {
-		if goal.Team == "home" {
-			homeGoals++
-		} else if goal.Team == "away" {
-			awayGoals++
+	// Check the match exists and has kicked off
+	if err := checkMatchPlayed(ctx, r.pool, result.MatchID); err != nil {
+		return err
+	}
+
+	// Without goals in the request, the goals already recorded on the match timeline make up the score
+	fromTimeline := len(result.Goals) == 0
+	if fromTimeline {
+		goals, err := r.getGoalsByMatchID(ctx, result.MatchID)
+		if err != nil {
+			return err
 		}
+		result.Goals = goals
 	}
 
-	if homeGoals != result.HomeScore {
-		return errors.New("home score does not match number of home goals")
+	// Validate the goals against the score, and the penalty shootout
+	if err := result.Validate(); err != nil {
+		return apperrors.Invalid(err)
 	}
-	if awayGoals != result.AwayScore {
-		return errors.New("away score does not match number of away goals")
+
+	// Check every scorer plays for the side the goal belongs to
+	if err := checkScorers(ctx, r.pool, result.MatchID, result.Goals); err != nil {
+		return err
 	}
 
 	// Start transaction
@@ -137,30 +146,23 @@ func (r *PostgresMatchResultRepo) Update(ctx context.Context, id int, result dom
 	cmd, err := tx.Exec(ctx, `UPDATE match_results SET home_score=$1, away_score=$2, updated_at=$3 WHERE id=$4 AND deleted_at IS NULL`,
 		result.HomeScore, result.AwayScore, now, id)
 	if err != nil {
-		return err
+		return dbError(err)
 	}
 	if cmd.RowsAffected() == 0 {
-		return errors.New("match result not found")
+		return apperrors.ErrMatchResultNotFound
 	}
 
-	// Delete existing goals for this match
-	_, err = tx.Exec(ctx, `DELETE FROM goals WHERE match_id = $1`, result.MatchID)
-	if err != nil {
+	// Replace the goals and shootout kicks of the match
+	if err := replaceResultDetails(ctx, tx, result, fromTimeline, now); err != nil {
 		return err
 	}
 
-	// Insert new goals
-	for _, goal := range result.Goals {
-		_, err = tx.Exec(ctx, `INSERT INTO goals (match_id, scorer, goal_time, team, created_at, updated_at, deleted_at) VALUES ($1, $2, $3, $4, $5, $6, NULL)`,
-			result.MatchID, goal.Scorer, goal.GoalTime, goal.Team, now, now)
-		if err != nil {
-			return err
-		}
-	}
-
 	return tx.Commit(ctx)
 }
 
+// errResultMoved is the error of updating a result with another match than the one it was registered for
+var errResultMoved = apperrors.ErrInvalidInput.WithMessage("a match result cannot be moved to another match")
+
 func (r *PostgresMatchResultRepo) Delete(ctx context.Context, id int) error {
 	now := time.Now()
 	cmd, err := r.pool.Exec(ctx, `UPDATE match_results SET deleted_at=$1, updated_at=$2 WHERE id=$3 AND deleted_at IS NULL`, now, now, id)
@@ -168,13 +170,49 @@ func (r *PostgresMatchResultRepo) Delete(ctx context.Context, id int) error {
 		return err
 	}
 	if cmd.RowsAffected() == 0 {
-		return errors.New("match result not found")
+		return apperrors.ErrMatchResultNotFound
 	}
 	return nil
 }
 
+const matchResultSelect = `SELECT id, match_id, home_score, away_score, created_at, updated_at, deleted_at FROM match_results`
+
+// matchResultSortColumns maps the sort fields of domain.MatchResultSortFields to columns
+var matchResultSortColumns = map[string][]string{
+	"id":         {"id"},
+	"match_id":   {"match_id"},
+	"created_at": {"created_at"},
+}
+
 func (r *PostgresMatchResultRepo) List(ctx context.Context) ([]domain.MatchResult, error) {
-	rows, err := r.pool.Query(ctx, `SELECT id, match_id, home_score, away_score, created_at, updated_at, deleted_at FROM match_results WHERE deleted_at IS NULL ORDER BY created_at DESC`)
+	return r.listResults(ctx, matchResultSelect+` WHERE deleted_at IS NULL ORDER BY created_at DESC`)
+}
+
+// ListByMatches returns the active results of the given matches, ordered by match
+func (r *PostgresMatchResultRepo) ListByMatches(ctx context.Context, matchIDs []int) ([]domain.MatchResult, error) {
+	return r.listResults(ctx, matchResultSelect+` WHERE match_id = ANY($1) AND deleted_at IS NULL ORDER BY match_id`, matchIDs)
+}
+
+// Search returns a page of the results, sorted and cut by the database
+func (r *PostgresMatchResultRepo) Search(ctx context.Context, query domain.MatchResultQuery) (*domain.Page[domain.MatchResult], error) {
+	page := query.PageRequest.Normalized(domain.DefaultMatchResultSort)
+	where := ` WHERE ` + deletedClause(query.Deleted, "deleted_at")
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT count(*) FROM match_results`+where).Scan(&total); err != nil {
+		return nil, err
+	}
+	results, err := r.listResults(ctx, matchResultSelect+where+orderBy(page, matchResultSortColumns, "id")+` LIMIT $1 OFFSET $2`, page.Limit, page.Offset)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewPage(results, total, page), nil
+}
+
+// listResults runs query, which selects the columns of matchResultSelect, and loads the goals and shootout
+// kicks of every result it returns with one query each, after the rows of the results have been read
+func (r *PostgresMatchResultRepo) listResults(ctx context.Context, query string, args ...any) ([]domain.MatchResult, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -188,57 +226,72 @@ func (r *PostgresMatchResultRepo) List(ctx context.Context) ([]domain.MatchResul
 			return nil, err
 		}
 		result.DeletedAt = deletedAt
-
-		// Get goals for this result
-		goals, err := r.getGoalsByMatchID(ctx, result.MatchID)
-		if err != nil {
-			return nil, err
-		}
-		result.Goals = goals
-
 		results = append(results, result)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if err := r.loadDetails(ctx, results); err != nil {
+		return nil, err
+	}
 	return results, nil
 }
 
-func (r *PostgresMatchResultRepo) GetByMatchID(ctx context.Context, matchID int) (*domain.MatchResult, error) {
-	var result domain.MatchResult
-	var deletedAt *time.Time
-	err := r.pool.QueryRow(ctx, `SELECT id, match_id, home_score, away_score, created_at, updated_at, deleted_at FROM match_results WHERE match_id = $1 AND deleted_at IS NULL`, matchID).
-		Scan(&result.ID, &result.MatchID, &result.HomeScore, &result.AwayScore, &result.CreatedAt, &result.UpdatedAt, &deletedAt)
+// loadDetails sets the goals and shootout kicks of results, fetching those of all results in one query each
+func (r *PostgresMatchResultRepo) loadDetails(ctx context.Context, results []domain.MatchResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+	matchIDs := make([]int, 0, len(results))
+	for _, result := range results {
+		matchIDs = append(matchIDs, result.MatchID)
+	}
+
+	events, err := listEventsOfMatches(ctx, r.pool, matchIDs, domain.EventGoal)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	goals := make(map[int][]domain.Goal)
+	for _, event := range events {
+		goals[event.MatchID] = append(goals[event.MatchID], event.ToGoal())
 	}
-	result.DeletedAt = deletedAt
 
-	// Get goals for this result
-	goals, err := r.getGoalsByMatchID(ctx, matchID)
+	kicks, err := r.listShootoutKicks(ctx, matchIDs)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	shootouts := make(map[int][]domain.ShootoutKick)
+	for _, kick := range kicks {
+		shootouts[kick.MatchID] = append(shootouts[kick.MatchID], kick)
+	}
+
+	for i := range results {
+		results[i].Goals = goals[results[i].MatchID]
+		results[i].Shootout = shootouts[results[i].MatchID]
 	}
-	result.Goals = goals
+	return nil
+}
 
-	return &result, nil
+func (r *PostgresMatchResultRepo) GetByMatchID(ctx context.Context, matchID int) (*domain.MatchResult, error) {
+	return r.getResult(ctx, matchResultSelect+` WHERE match_id = $1 AND deleted_at IS NULL`, matchID)
 }
 
 func (r *PostgresMatchResultRepo) GetByID(ctx context.Context, id int) (*domain.MatchResult, error) {
-	var result domain.MatchResult
-	var deletedAt *time.Time
-	err := r.pool.QueryRow(ctx, `SELECT id, match_id, home_score, away_score, created_at, updated_at, deleted_at FROM match_results WHERE id = $1 AND deleted_at IS NULL`, id).
-		Scan(&result.ID, &result.MatchID, &result.HomeScore, &result.AwayScore, &result.CreatedAt, &result.UpdatedAt, &deletedAt)
-	if err != nil {
-		return nil, err
-	}
-	result.DeletedAt = deletedAt
+	return r.getResult(ctx, matchResultSelect+` WHERE id = $1 AND deleted_at IS NULL`, id)
+}
 
-	// Get goals for this result
-	goals, err := r.getGoalsByMatchID(ctx, result.MatchID)
+// getResult returns the one result selected by query with its goals and shootout kicks
+func (r *PostgresMatchResultRepo) getResult(ctx context.Context, query string, args ...any) (*domain.MatchResult, error) {
+	results, err := r.listResults(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
-	result.Goals = goals
-
-	return &result, nil
+	if len(results) == 0 {
+		return nil, apperrors.ErrMatchResultNotFound
+	}
+	return &results[0], nil
 }
 
 func (r *PostgresMatchResultRepo) Restore(ctx context.Context, id int) error {
@@ -248,28 +301,212 @@ func (r *PostgresMatchResultRepo) Restore(ctx context.Context, id int) error {
 		return err
 	}
 	if cmd.RowsAffected() == 0 {
-		return errors.New("match result not found or not deleted")
+		return apperrors.ErrMatchResultNotFound.WithMessage("match result not found or not deleted")
 	}
 	return nil
 }
 
-// Helper method to get goals by match ID
-func (r *PostgresMatchResultRepo) getGoalsByMatchID(ctx context.Context, matchID int) ([]domain.Goal, error) {
-	rows, err := r.pool.Query(ctx, `SELECT id, match_id, scorer, goal_time, team, created_at, updated_at, deleted_at FROM goals WHERE match_id = $1 AND deleted_at IS NULL ORDER BY goal_time`, matchID)
+// ListBetween returns the finished matches between two teams with their results, most recent first
+func (r *PostgresMatchResultRepo) ListBetween(ctx context.Context, team, opponent string) ([]domain.HeadToHeadMatch, error) {
+	rows, err := r.pool.Query(ctx, `SELECT m.id, m.match_date, home.name, away.name, mr.home_score, mr.away_score,
+			EXISTS(SELECT 1 FROM shootout_kicks k WHERE k.match_id = m.id AND k.deleted_at IS NULL),
+			(SELECT COUNT(*) FROM shootout_kicks k WHERE k.match_id = m.id AND k.team = 'home' AND k.scored AND k.deleted_at IS NULL),
+			(SELECT COUNT(*) FROM shootout_kicks k WHERE k.match_id = m.id AND k.team = 'away' AND k.scored AND k.deleted_at IS NULL)
+		FROM matches m JOIN match_results mr ON mr.match_id = m.id AND mr.deleted_at IS NULL
+			JOIN teams home ON home.id = m.home_team_id JOIN teams away ON away.id = m.away_team_id
+		WHERE m.deleted_at IS NULL AND m.status = 'finished'
+			AND ((home.name = $1 AND away.name = $2) OR (home.name = $2 AND away.name = $1))
+		ORDER BY m.match_date DESC, m.match_time DESC`, team, opponent)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	var matches []domain.HeadToHeadMatch
+	for rows.Next() {
+		var m domain.HeadToHeadMatch
+		var matchDate time.Time
+		var shootout bool
+		var homePenalties, awayPenalties int
+		if err := rows.Scan(&m.MatchID, &matchDate, &m.HomeTeam, &m.AwayTeam, &m.HomeScore, &m.AwayScore, &shootout, &homePenalties, &awayPenalties); err != nil {
+			return nil, err
+		}
+		m.MatchDate = matchDate.Format("2006-01-02")
+		if shootout {
+			m.HomePenalties, m.AwayPenalties = &homePenalties, &awayPenalties
+		}
+		matches = append(matches, m)
+	}
+	return matches, rows.Err()
+}
+
+// checkMatchPlayed verifies that a match exists and is live or finished, so results and events
+// cannot be recorded ahead of time or for matches that were never played
+func checkMatchPlayed(ctx context.Context, q querier, matchID int) error {
+	var status domain.MatchStatus
+	err := q.QueryRow(ctx, `SELECT status FROM matches WHERE id = $1 AND deleted_at IS NULL`, matchID).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apperrors.ErrMatchNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if !status.AcceptsResult() {
+		return apperrors.ErrMatchNotPlayed.WithMessage(fmt.Sprintf("match is %s, results and events can only be recorded once it is live or finished", status))
+	}
+	return nil
+}
+
+// checkScorers verifies that every scorer and assist is a registered player of the side the goal belongs to.
+// Own goals are scored by a player of the opponents of the side credited with the goal
+func checkScorers(ctx context.Context, q querier, matchID int, goals []domain.Goal) error {
+	if len(goals) == 0 {
+		return nil
+	}
+
+	var homeTeam, awayTeam string
+	err := q.QueryRow(ctx, `SELECT home.name, away.name FROM matches m JOIN teams home ON home.id = m.home_team_id JOIN teams away ON away.id = m.away_team_id WHERE m.id = $1 AND m.deleted_at IS NULL`, matchID).Scan(&homeTeam, &awayTeam)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apperrors.ErrMatchNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	rows, err := q.Query(ctx, `SELECT p.name, t.name FROM players p JOIN teams t ON t.id = p.team_id WHERE t.name IN ($1, $2) AND p.deleted_at IS NULL`, homeTeam, awayTeam)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	playerTeams := make(map[string]string)
+	for rows.Next() {
+		var name, team string
+		if err := rows.Scan(&name, &team); err != nil {
+			return err
+		}
+		playerTeams[name] = team
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if invalid := findInvalidGoals(goals, homeTeam, awayTeam, playerTeams); len(invalid) > 0 {
+		return invalidGoals(invalid)
+	}
+	return nil
+}
+
+// invalidGoals reports goals failing the squad check, listing them as details
+func invalidGoals(goals []domain.InvalidGoal) error {
+	err := &domain.InvalidGoalsError{Goals: goals}
+	return apperrors.ErrInvalidGoals.WithMessage(err.Error()).WithDetails(goals).Wrap(err)
+}
+
+// findInvalidGoals checks the scorers and assists of goals against playerTeams, which maps
+// the players of both teams to their team
+func findInvalidGoals(goals []domain.Goal, homeTeam, awayTeam string, playerTeams map[string]string) []domain.InvalidGoal {
+	// checkPlayer returns why player cannot act for team, or an empty string if they can
+	checkPlayer := func(player, team string) string {
+		actual, ok := playerTeams[player]
+		switch {
+		case !ok:
+			return fmt.Sprintf("%s is not a registered player of %s", player, team)
+		case actual != team:
+			return fmt.Sprintf("%s plays for %s, not %s", player, actual, team)
+		}
+		return ""
+	}
+
+	var invalid []domain.InvalidGoal
+	for i, goal := range goals {
+		var credited, opponent string
+		switch goal.Team {
+		case "home":
+			credited, opponent = homeTeam, awayTeam
+		case "away":
+			credited, opponent = awayTeam, homeTeam
+		default:
+			invalid = append(invalid, domain.InvalidGoal{Index: i, Scorer: goal.Scorer, Team: goal.Team, Reason: fmt.Sprintf("invalid team %q. Use home or away", goal.Team)})
+			continue
+		}
+
+		scorerTeam := credited
+		if goal.Type == domain.GoalOwnGoal {
+			scorerTeam = opponent
+		}
+		reason := checkPlayer(goal.Scorer, scorerTeam)
+		if reason == "" && goal.Assist != "" {
+			if assistReason := checkPlayer(goal.Assist, credited); assistReason != "" {
+				reason = "assist: " + assistReason
+			}
+		}
+		if reason != "" {
+			invalid = append(invalid, domain.InvalidGoal{Index: i, Scorer: goal.Scorer, Team: goal.Team, Reason: reason})
+		}
+	}
+	return invalid
+}
+
+// Helper method to get goals by match ID, they are the goal events of the match timeline
+func (r *PostgresMatchResultRepo) getGoalsByMatchID(ctx context.Context, matchID int) ([]domain.Goal, error) {
+	events, err := listMatchEvents(ctx, r.pool, matchID, domain.EventGoal)
+	if err != nil {
+		return nil, err
+	}
+
 	var goals []domain.Goal
+	for _, event := range events {
+		goals = append(goals, event.ToGoal())
+	}
+	return goals, nil
+}
+
+// listShootoutKicks returns the penalty shootout kicks of all given matches, ordered by match and kick
+func (r *PostgresMatchResultRepo) listShootoutKicks(ctx context.Context, matchIDs []int) ([]domain.ShootoutKick, error) {
+	rows, err := r.pool.Query(ctx, `SELECT id, match_id, kick_order, team, taker, scored, created_at, updated_at, deleted_at FROM shootout_kicks WHERE match_id = ANY($1) AND deleted_at IS NULL ORDER BY match_id, kick_order`, matchIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var kicks []domain.ShootoutKick
 	for rows.Next() {
-		var goal domain.Goal
+		var kick domain.ShootoutKick
 		var deletedAt *time.Time
-		if err := rows.Scan(&goal.ID, &goal.MatchID, &goal.Scorer, &goal.GoalTime, &goal.Team, &goal.CreatedAt, &goal.UpdatedAt, &deletedAt); err != nil {
+		if err := rows.Scan(&kick.ID, &kick.MatchID, &kick.Order, &kick.Team, &kick.Taker, &kick.Scored, &kick.CreatedAt, &kick.UpdatedAt, &deletedAt); err != nil {
 			return nil, err
 		}
-		goal.DeletedAt = deletedAt
-		goals = append(goals, goal)
+		kick.DeletedAt = deletedAt
+		kicks = append(kicks, kick)
 	}
-	return goals, nil
+	return kicks, rows.Err()
+}
+
+// replaceResultDetails soft-deletes the goal events and shootout kicks of the result's match and inserts
+// those of the result instead. Goals taken from the timeline are already there and stay as they are
+func replaceResultDetails(ctx context.Context, q querier, result domain.MatchResult, fromTimeline bool, now time.Time) error {
+	if !fromTimeline {
+		_, err := q.Exec(ctx, `UPDATE match_events SET deleted_at=$2, updated_at=$2 WHERE match_id = $1 AND event_type = 'goal' AND deleted_at IS NULL`, result.MatchID, now)
+		if err != nil {
+			return err
+		}
+		for _, goal := range result.Goals {
+			if _, err := insertMatchEvent(ctx, q, goal.ToMatchEvent(result.MatchID), now); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := q.Exec(ctx, `UPDATE shootout_kicks SET deleted_at=$2, updated_at=$2 WHERE match_id = $1 AND deleted_at IS NULL`, result.MatchID, now)
+	if err != nil {
+		return err
+	}
+	for _, kick := range result.Shootout {
+		_, err := q.Exec(ctx, `INSERT INTO shootout_kicks (match_id, kick_order, team, taker, scored, created_at, updated_at, deleted_at) VALUES ($1, $2, $3, $4, $5, $6, $7, NULL)`,
+			result.MatchID, kick.Order, kick.Team, kick.Taker, kick.Scored, now, now)
+		if err != nil {
+			return dbError(err)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,57 @@
+package usecases
+
+import (
+	"football-team-management/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindInvalidGoals(t *testing.T) {
+	playerTeams := map[string]string{
+		"Marko Simic":      "Persija",
+		"Riko Simanjuntak": "Persija",
+		"David da Silva":   "Persib",
+		"Marc Klok":        "Persib",
+	}
+
+	t.Run("Accepts scorers of the right side", func(t *testing.T) {
+		goals := []domain.Goal{
+			{Scorer: "Marko Simic", Team: "home", Assist: "Riko Simanjuntak"},
+			{Scorer: "David da Silva", Team: "away"},
+		}
+		assert.Empty(t, findInvalidGoals(goals, "Persija", "Persib", playerTeams))
+	})
+
+	t.Run("Own goals are scored by the opponents", func(t *testing.T) {
+		goals := []domain.Goal{{Scorer: "Marc Klok", Team: "home", Type: domain.GoalOwnGoal}}
+		assert.Empty(t, findInvalidGoals(goals, "Persija", "Persib", playerTeams))
+
+		goals = []domain.Goal{{Scorer: "Marko Simic", Team: "home", Type: domain.GoalOwnGoal}}
+		assert.Len(t, findInvalidGoals(goals, "Persija", "Persib", playerTeams), 1)
+	})
+
+	t.Run("Lists every invalid goal", func(t *testing.T) {
+		goals := []domain.Goal{
+			{Scorer: "Marko Simic", Team: "home"},
+			{Scorer: "Marko Simc", Team: "home"},
+			{Scorer: "Marko Simic", Team: "away"},
+			{Scorer: "David da Silva", Team: "away", Assist: "Riko Simanjuntak"},
+			{Scorer: "David da Silva", Team: "Persib"},
+		}
+		invalid := findInvalidGoals(goals, "Persija", "Persib", playerTeams)
+
+		require.Len(t, invalid, 4)
+		assert.Equal(t, 1, invalid[0].Index)
+		assert.Equal(t, "Marko Simc is not a registered player of Persija", invalid[0].Reason)
+		assert.Equal(t, 2, invalid[1].Index)
+		assert.Equal(t, "Marko Simic plays for Persija, not Persib", invalid[1].Reason)
+		assert.Equal(t, 3, invalid[2].Index)
+		assert.Equal(t, "assist: Riko Simanjuntak plays for Persija, not Persib", invalid[2].Reason)
+		assert.Equal(t, 4, invalid[3].Index)
+
+		err := &domain.InvalidGoalsError{Goals: invalid}
+		assert.Contains(t, err.Error(), "goal 2: Marko Simc is not a registered player of Persija")
+	})
+}
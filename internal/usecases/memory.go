@@ -0,0 +1,232 @@
+package usecases
+
+import (
+	"cmp"
+	"football-team-management/internal/domain"
+	"football-team-management/internal/domain/user"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MemoryStore holds the data of the in-memory repositories. Repositories created from the same store
+// share its data the way the Postgres repositories share a database, and every method holds the store's
+// lock for its whole run, so it behaves like a transaction. The data is lost when the process exits,
+// which suits development and tests
+type MemoryStore struct {
+	mu  sync.RWMutex
+	ids map[string]int // Last ID handed out per table
+
+	users         map[string]*user.User
+	userTeams     map[string][]int
+	sessions      map[string]*user.Session
+	refreshTokens map[string]*memoryRefreshToken
+	revokedTokens map[string]time.Time
+
+	teams        []*domain.Team
+	players      []*memoryPlayer
+	competitions []*domain.Competition
+	seasons      []*domain.Season
+	matches      []*memoryMatch
+	results      []*domain.MatchResult
+	events       []*memoryEvent
+	kicks        []*domain.ShootoutKick
+	brackets     []*domain.Bracket
+	ties         []*memoryTie
+	ratings      []*memoryRatingChange
+	windows      []*domain.TransferWindow
+	transfers    []*memoryTransfer
+	contracts    []*memoryContract
+}
+
+// Rows that refer to teams or players keep their IDs, like the foreign keys of the Postgres schema,
+// so renaming a team or player shows everywhere. Names are filled in when the rows are read
+
+type memoryPlayer struct {
+	domain.Player
+	teamID int
+}
+
+type memoryMatch struct {
+	domain.Match
+	homeID, awayID int
+}
+
+type memoryEvent struct {
+	domain.MatchEvent
+	seconds int // Elapsed time of play, orders the timeline
+}
+
+type memoryTie struct {
+	domain.BracketTie
+	homeID, awayID, winnerID *int
+}
+
+type memoryRatingChange struct {
+	domain.RatingChange
+	teamID, opponentID int
+}
+
+type memoryTransfer struct {
+	domain.Transfer
+	playerID, fromID, toID int
+}
+
+type memoryContract struct {
+	domain.Contract
+	playerID, teamID int
+}
+
+type memoryRefreshToken struct {
+	sessionID string
+	expiresAt time.Time
+	usedAt    *time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		ids:           make(map[string]int),
+		users:         make(map[string]*user.User),
+		userTeams:     make(map[string][]int),
+		sessions:      make(map[string]*user.Session),
+		refreshTokens: make(map[string]*memoryRefreshToken),
+		revokedTokens: make(map[string]time.Time),
+	}
+}
+
+// Helper methods below expect the caller to hold the lock
+
+// nextID returns the next ID of table, IDs are never reused just like a Postgres sequence
+func (s *MemoryStore) nextID(table string) int {
+	s.ids[table]++
+	return s.ids[table]
+}
+
+// matchesKey reports whether key is the given ID or slug, the same way `WHERE (id = $1 OR slug = $2)` does
+func matchesKey(id int, slug, key string) bool {
+	if keyID := keyID(key); keyID != nil && *keyID == id {
+		return true
+	}
+	return slug == key
+}
+
+// uniqueMemorySlug returns base, or base with the lowest numeric suffix for which taken is false, see uniqueSlug
+func uniqueMemorySlug(base string, taken func(slug string) bool) string {
+	slug := base
+	for n := 2; taken(slug); n++ {
+		slug = base + "-" + strconv.Itoa(n)
+	}
+	return slug
+}
+
+// memoryPage sorts items the way orderBy does and cuts out the page. compare holds a comparison per sort
+// field, id returns the ID ordering items that compare equal
+func memoryPage[T any](items []T, page domain.PageRequest, compare map[string]func(a, b T) int, id func(T) int) *domain.Page[T] {
+	field, desc := page.SortBy()
+	sort.SliceStable(items, func(i, j int) bool {
+		order := 0
+		if byField, ok := compare[field]; ok {
+			order = byField(items[i], items[j])
+		}
+		if order == 0 {
+			order = cmp.Compare(id(items[i]), id(items[j]))
+		}
+		if desc {
+			return order > 0
+		}
+		return order < 0
+	})
+
+	var selected []T
+	if page.Offset < len(items) {
+		selected = items[page.Offset:min(page.Offset+page.Limit, len(items))]
+	}
+	return domain.NewPage(selected, len(items), page)
+}
+
+func (s *MemoryStore) teamByID(id int) *domain.Team {
+	for _, team := range s.teams {
+		if team.ID == id {
+			return team
+		}
+	}
+	return nil
+}
+
+// teamByName returns the team with the given name, deleted or not
+func (s *MemoryStore) teamByName(name string) *domain.Team {
+	for _, team := range s.teams {
+		if team.Name == name {
+			return team
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStore) activeTeamByName(name string) *domain.Team {
+	if team := s.teamByName(name); team != nil && team.DeletedAt == nil {
+		return team
+	}
+	return nil
+}
+
+func (s *MemoryStore) teamName(id int) string {
+	if team := s.teamByID(id); team != nil {
+		return team.Name
+	}
+	return ""
+}
+
+// teamIDPtr and teamNamePtr translate the optional teams of bracket ties
+func (s *MemoryStore) teamIDPtr(name *string) *int {
+	if name == nil {
+		return nil
+	}
+	if team := s.teamByName(*name); team != nil {
+		return &team.ID
+	}
+	return nil
+}
+
+func (s *MemoryStore) teamNamePtr(id *int) *string {
+	if id == nil {
+		return nil
+	}
+	name := s.teamName(*id)
+	return &name
+}
+
+func (s *MemoryStore) playerName(id int) string {
+	for _, player := range s.players {
+		if player.ID == id {
+			return player.Name
+		}
+	}
+	return ""
+}
+
+func (s *MemoryStore) activeSeason(id int) *domain.Season {
+	for _, season := range s.seasons {
+		if season.ID == id && season.DeletedAt == nil {
+			return season
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStore) activeMatch(id int) *memoryMatch {
+	for _, match := range s.matches {
+		if match.ID == id && match.DeletedAt == nil {
+			return match
+		}
+	}
+	return nil
+}
+
+// match returns a copy of a stored match with its team names filled in
+func (s *MemoryStore) match(m *memoryMatch) domain.Match {
+	match := m.Match
+	match.HomeTeam, match.AwayTeam = s.teamName(m.homeID), s.teamName(m.awayID)
+	return match
+}
@@ -0,0 +1,134 @@
+package usecases
+
+import (
+	"context"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"sort"
+	"time"
+)
+
+type MemoryBracketRepo struct {
+	store *MemoryStore
+}
+
+func NewMemoryBracketRepo(store *MemoryStore) *MemoryBracketRepo {
+	return &MemoryBracketRepo{store: store}
+}
+
+func (r *MemoryBracketRepo) Register(ctx context.Context, bracket domain.Bracket) (int, error) {
+	kickOffTime, err := time.Parse("15:04", bracket.KickOffTime)
+	if err != nil {
+		return 0, apperrors.ErrInvalidInput.WithMessage("invalid kick-off time format. Use HH:MM")
+	}
+
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	ties := bracket.Ties
+	bracket.ID = s.nextID("brackets")
+	bracket.KickOffTime = kickOffTime.Format("15:04")
+	bracket.Ties = nil
+	bracket.CreatedAt, bracket.UpdatedAt, bracket.DeletedAt = now, now, nil
+	s.brackets = append(s.brackets, &bracket)
+
+	// Insert ties
+	for _, tie := range ties {
+		tie.ID = s.nextID("bracket_ties")
+		tie.BracketID = bracket.ID
+		s.ties = append(s.ties, &memoryTie{
+			BracketTie: tie,
+			homeID:     s.teamIDPtr(tie.HomeTeam),
+			awayID:     s.teamIDPtr(tie.AwayTeam),
+			winnerID:   s.teamIDPtr(tie.Winner),
+		})
+	}
+	return bracket.ID, nil
+}
+
+func (r *MemoryBracketRepo) List(ctx context.Context) ([]domain.Bracket, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var brackets []domain.Bracket
+	for _, bracket := range s.brackets {
+		if bracket.DeletedAt == nil {
+			brackets = append(brackets, *bracket)
+		}
+	}
+	sort.SliceStable(brackets, func(i, j int) bool {
+		return brackets[i].StartDate.After(brackets[j].StartDate)
+	})
+	return brackets, nil
+}
+
+func (r *MemoryBracketRepo) GetByID(ctx context.Context, id int) (*domain.Bracket, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, stored := range s.brackets {
+		if stored.ID != id || stored.DeletedAt != nil {
+			continue
+		}
+		bracket := *stored
+		for _, tie := range s.ties {
+			if tie.BracketID == id {
+				bracket.Ties = append(bracket.Ties, r.tie(tie))
+			}
+		}
+		sort.SliceStable(bracket.Ties, func(i, j int) bool {
+			if bracket.Ties[i].Round != bracket.Ties[j].Round {
+				return bracket.Ties[i].Round < bracket.Ties[j].Round
+			}
+			return bracket.Ties[i].Position < bracket.Ties[j].Position
+		})
+		return &bracket, nil
+	}
+	return nil, apperrors.ErrBracketNotFound
+}
+
+func (r *MemoryBracketRepo) GetTieByMatchID(ctx context.Context, matchID int) (*domain.BracketTie, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, tie := range s.ties {
+		if tie.MatchID == nil || *tie.MatchID != matchID {
+			continue
+		}
+		for _, bracket := range s.brackets {
+			if bracket.ID == tie.BracketID && bracket.DeletedAt == nil {
+				found := r.tie(tie)
+				return &found, nil
+			}
+		}
+	}
+	return nil, errBracketTieNotFound
+}
+
+func (r *MemoryBracketRepo) UpdateTie(ctx context.Context, tie domain.BracketTie) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, stored := range s.ties {
+		if stored.ID != tie.ID {
+			continue
+		}
+		stored.HomeSeed, stored.AwaySeed, stored.MatchID = tie.HomeSeed, tie.AwaySeed, tie.MatchID
+		stored.homeID, stored.awayID, stored.winnerID = s.teamIDPtr(tie.HomeTeam), s.teamIDPtr(tie.AwayTeam), s.teamIDPtr(tie.Winner)
+		return nil
+	}
+	return errBracketTieNotFound
+}
+
+// Helper method returning a copy of a stored tie with its team names filled in
+func (r *MemoryBracketRepo) tie(stored *memoryTie) domain.BracketTie {
+	tie := stored.BracketTie
+	tie.HomeTeam, tie.AwayTeam, tie.Winner = r.store.teamNamePtr(stored.homeID), r.store.teamNamePtr(stored.awayID), r.store.teamNamePtr(stored.winnerID)
+	return tie
+}
@@ -0,0 +1,274 @@
+package usecases
+
+import (
+	"context"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"sort"
+	"time"
+)
+
+type MemoryCompetitionRepo struct {
+	store *MemoryStore
+}
+
+func NewMemoryCompetitionRepo(store *MemoryStore) *MemoryCompetitionRepo {
+	return &MemoryCompetitionRepo{store: store}
+}
+
+func (r *MemoryCompetitionRepo) Register(ctx context.Context, competition domain.Competition) (int, error) {
+	if !domain.ValidCompetitionType(competition.Type) {
+		return 0, apperrors.ErrInvalidInput.WithMessage("invalid competition type. Use league or cup")
+	}
+
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Check if competition already exists
+	if r.nameTaken(competition.Name, 0) {
+		return 0, apperrors.ErrCompetitionAlreadyExists
+	}
+
+	now := time.Now()
+	s.competitions = append(s.competitions, &domain.Competition{
+		ID:        s.nextID("competitions"),
+		Name:      competition.Name,
+		Type:      competition.Type,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	return s.competitions[len(s.competitions)-1].ID, nil
+}
+
+func (r *MemoryCompetitionRepo) Update(ctx context.Context, id int, competition domain.Competition) error {
+	if !domain.ValidCompetitionType(competition.Type) {
+		return apperrors.ErrInvalidInput.WithMessage("invalid competition type. Use league or cup")
+	}
+
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Check if the new name is taken by another competition
+	if r.nameTaken(competition.Name, id) {
+		return apperrors.ErrCompetitionAlreadyExists
+	}
+
+	existing := r.activeByID(id)
+	if existing == nil {
+		return apperrors.ErrCompetitionNotFound
+	}
+	existing.Name, existing.Type, existing.UpdatedAt = competition.Name, competition.Type, time.Now()
+	return nil
+}
+
+func (r *MemoryCompetitionRepo) Delete(ctx context.Context, id int) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	competition := r.activeByID(id)
+	if competition == nil {
+		return apperrors.ErrCompetitionNotFound
+	}
+	now := time.Now()
+	competition.DeletedAt, competition.UpdatedAt = &now, now
+	return nil
+}
+
+func (r *MemoryCompetitionRepo) List(ctx context.Context) ([]domain.Competition, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var competitions []domain.Competition
+	for _, competition := range s.competitions {
+		if competition.DeletedAt == nil {
+			competitions = append(competitions, *competition)
+		}
+	}
+	sort.SliceStable(competitions, func(i, j int) bool {
+		return competitions[i].Name < competitions[j].Name
+	})
+	return competitions, nil
+}
+
+func (r *MemoryCompetitionRepo) GetByID(ctx context.Context, id int) (*domain.Competition, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	competition := r.activeByID(id)
+	if competition == nil {
+		return nil, apperrors.ErrCompetitionNotFound
+	}
+	found := *competition
+	return &found, nil
+}
+
+func (r *MemoryCompetitionRepo) Restore(ctx context.Context, id int) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, competition := range s.competitions {
+		if competition.ID == id && competition.DeletedAt != nil {
+			competition.DeletedAt, competition.UpdatedAt = nil, time.Now()
+			return nil
+		}
+	}
+	return apperrors.ErrCompetitionNotFound.WithMessage("competition not found or not deleted")
+}
+
+// Helper method to find an active competition by its ID
+func (r *MemoryCompetitionRepo) activeByID(id int) *domain.Competition {
+	for _, competition := range r.store.competitions {
+		if competition.ID == id && competition.DeletedAt == nil {
+			return competition
+		}
+	}
+	return nil
+}
+
+// Helper method reporting whether an active competition other than exceptID has the name
+func (r *MemoryCompetitionRepo) nameTaken(name string, exceptID int) bool {
+	for _, competition := range r.store.competitions {
+		if competition.Name == name && competition.ID != exceptID && competition.DeletedAt == nil {
+			return true
+		}
+	}
+	return false
+}
+
+type MemorySeasonRepo struct {
+	store *MemoryStore
+}
+
+func NewMemorySeasonRepo(store *MemoryStore) *MemorySeasonRepo {
+	return &MemorySeasonRepo{store: store}
+}
+
+func (r *MemorySeasonRepo) Register(ctx context.Context, season domain.Season) (int, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := r.check(season, 0); err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	season.ID = s.nextID("seasons")
+	season.CreatedAt, season.UpdatedAt, season.DeletedAt = now, now, nil
+	s.seasons = append(s.seasons, &season)
+	return season.ID, nil
+}
+
+func (r *MemorySeasonRepo) Update(ctx context.Context, id int, season domain.Season) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := r.check(season, id); err != nil {
+		return err
+	}
+
+	existing := s.activeSeason(id)
+	if existing == nil {
+		return apperrors.ErrSeasonNotFound
+	}
+	existing.CompetitionID, existing.Name = season.CompetitionID, season.Name
+	existing.StartDate, existing.EndDate, existing.UpdatedAt = season.StartDate, season.EndDate, time.Now()
+	return nil
+}
+
+func (r *MemorySeasonRepo) Delete(ctx context.Context, id int) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	season := s.activeSeason(id)
+	if season == nil {
+		return apperrors.ErrSeasonNotFound
+	}
+	now := time.Now()
+	season.DeletedAt, season.UpdatedAt = &now, now
+	return nil
+}
+
+func (r *MemorySeasonRepo) List(ctx context.Context) ([]domain.Season, error) {
+	return r.list(func(*domain.Season) bool { return true }), nil
+}
+
+func (r *MemorySeasonRepo) ListByCompetition(ctx context.Context, competitionID int) ([]domain.Season, error) {
+	return r.list(func(season *domain.Season) bool { return season.CompetitionID == competitionID }), nil
+}
+
+func (r *MemorySeasonRepo) GetByID(ctx context.Context, id int) (*domain.Season, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	season := s.activeSeason(id)
+	if season == nil {
+		return nil, apperrors.ErrSeasonNotFound
+	}
+	found := *season
+	return &found, nil
+}
+
+func (r *MemorySeasonRepo) Restore(ctx context.Context, id int) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, season := range s.seasons {
+		if season.ID == id && season.DeletedAt != nil {
+			season.DeletedAt, season.UpdatedAt = nil, time.Now()
+			return nil
+		}
+	}
+	return apperrors.ErrSeasonNotFound.WithMessage("season not found or not deleted")
+}
+
+// Helper method checking the competition exists and no other active season of it, other than
+// exceptID, has the same name
+func (r *MemorySeasonRepo) check(season domain.Season, exceptID int) error {
+	// Check if competition exists
+	competitionExists := false
+	for _, competition := range r.store.competitions {
+		if competition.ID == season.CompetitionID && competition.DeletedAt == nil {
+			competitionExists = true
+		}
+	}
+	if !competitionExists {
+		return apperrors.ErrCompetitionNotFound
+	}
+
+	// Check if season already exists in this competition
+	for _, existing := range r.store.seasons {
+		if existing.CompetitionID == season.CompetitionID && existing.Name == season.Name && existing.ID != exceptID && existing.DeletedAt == nil {
+			return apperrors.ErrSeasonAlreadyExists
+		}
+	}
+	return nil
+}
+
+// Helper method listing the active seasons passing include, latest first
+func (r *MemorySeasonRepo) list(include func(*domain.Season) bool) []domain.Season {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var seasons []domain.Season
+	for _, season := range s.seasons {
+		if season.DeletedAt == nil && include(season) {
+			seasons = append(seasons, *season)
+		}
+	}
+	sort.SliceStable(seasons, func(i, j int) bool {
+		return seasons[i].StartDate.After(seasons[j].StartDate)
+	})
+	return seasons
+}
@@ -0,0 +1,269 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+type MemoryMatchRepo struct {
+	store *MemoryStore
+}
+
+func NewMemoryMatchRepo(store *MemoryStore) *MemoryMatchRepo {
+	return &MemoryMatchRepo{store: store}
+}
+
+func (r *MemoryMatchRepo) Register(ctx context.Context, match domain.Match) (int, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, err := r.check(match)
+	if err != nil {
+		return 0, err
+	}
+	return r.insert(stored), nil
+}
+
+// RegisterBatch registers all matches at once, so either every match is saved or none is
+func (r *MemoryMatchRepo) RegisterBatch(ctx context.Context, matches []domain.Match) ([]int, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checked := make([]*memoryMatch, 0, len(matches))
+	for i, match := range matches {
+		stored, err := r.check(match)
+		if err != nil {
+			return nil, withContext(err, fmt.Sprintf("match %d (%s vs %s)", i+1, match.HomeTeam, match.AwayTeam))
+		}
+		checked = append(checked, stored)
+	}
+
+	ids := make([]int, 0, len(checked))
+	for _, stored := range checked {
+		ids = append(ids, r.insert(stored))
+	}
+	return ids, nil
+}
+
+func (r *MemoryMatchRepo) Update(ctx context.Context, id int, match domain.Match) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checked, err := r.check(match)
+	if err != nil {
+		return err
+	}
+
+	existing := s.activeMatch(id)
+	if existing == nil {
+		return apperrors.ErrMatchNotFound
+	}
+	existing.MatchDate, existing.MatchTime, existing.SeasonID = checked.MatchDate, checked.MatchTime, checked.SeasonID
+	existing.homeID, existing.awayID, existing.UpdatedAt = checked.homeID, checked.awayID, time.Now()
+	return nil
+}
+
+func (r *MemoryMatchRepo) Delete(ctx context.Context, id int) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	match := s.activeMatch(id)
+	if match == nil {
+		return apperrors.ErrMatchNotFound
+	}
+	now := time.Now()
+	match.DeletedAt, match.UpdatedAt = &now, now
+	return nil
+}
+
+func (r *MemoryMatchRepo) List(ctx context.Context, filter domain.MatchFilter) ([]domain.Match, error) {
+	return r.list(filter, domain.ExcludeDeleted, func(*memoryMatch) bool { return true }), nil
+}
+
+// ListByTeam returns the matches of the team with the given ID or slug
+func (r *MemoryMatchRepo) ListByTeam(ctx context.Context, teamKey string, filter domain.MatchFilter) ([]domain.Match, error) {
+	return r.list(filter, domain.ExcludeDeleted, func(m *memoryMatch) bool {
+		home, away := r.store.teamByID(m.homeID), r.store.teamByID(m.awayID)
+		return matchesKey(home.ID, home.Slug, teamKey) || matchesKey(away.ID, away.Slug, teamKey)
+	}), nil
+}
+
+// memoryMatchSorts compares matches by the sort fields of domain.MatchSortFields, see matchSortColumns
+var memoryMatchSorts = map[string]func(a, b domain.Match) int{
+	"date": func(a, b domain.Match) int {
+		if order := a.MatchDate.Compare(b.MatchDate); order != 0 {
+			return order
+		}
+		return strings.Compare(a.MatchTime, b.MatchTime)
+	},
+	"id":         func(a, b domain.Match) int { return cmp.Compare(a.ID, b.ID) },
+	"status":     func(a, b domain.Match) int { return strings.Compare(string(a.Status), string(b.Status)) },
+	"created_at": func(a, b domain.Match) int { return a.CreatedAt.Compare(b.CreatedAt) },
+}
+
+func (r *MemoryMatchRepo) Search(ctx context.Context, query domain.MatchQuery) (*domain.Page[domain.Match], error) {
+	matches := r.list(query.MatchFilter, query.Deleted, func(m *memoryMatch) bool {
+		if query.Team == "" {
+			return true
+		}
+		home, away := r.store.teamByID(m.homeID), r.store.teamByID(m.awayID)
+		return (query.Venue != domain.VenueAway && matchesKey(home.ID, home.Slug, query.Team)) ||
+			(query.Venue != domain.VenueHome && matchesKey(away.ID, away.Slug, query.Team))
+	})
+	page := query.PageRequest.Normalized(domain.DefaultMatchSort)
+	return memoryPage(matches, page, memoryMatchSorts, func(m domain.Match) int { return m.ID }), nil
+}
+
+func (r *MemoryMatchRepo) GetByID(ctx context.Context, id int) (*domain.Match, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stored := s.activeMatch(id)
+	if stored == nil {
+		return nil, apperrors.ErrMatchNotFound
+	}
+	match := s.match(stored)
+	return &match, nil
+}
+
+func (r *MemoryMatchRepo) Restore(ctx context.Context, id int) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, match := range s.matches {
+		if match.ID == id && match.DeletedAt != nil {
+			match.DeletedAt, match.UpdatedAt = nil, time.Now()
+			return nil
+		}
+	}
+	return apperrors.ErrMatchNotFound.WithMessage("match not found or not deleted")
+}
+
+// UpdateStatus moves a match to a new status, enforcing the allowed transitions
+func (r *MemoryMatchRepo) UpdateStatus(ctx context.Context, id int, status domain.MatchStatus) (*domain.Match, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored := s.activeMatch(id)
+	if stored == nil {
+		return nil, apperrors.ErrMatchNotFound
+	}
+	match := s.match(stored)
+
+	now := time.Now()
+	if err := match.TransitionTo(status, now); err != nil {
+		return nil, apperrors.Invalid(err)
+	}
+	stored.Status, stored.UpdatedAt = match.Status, now
+	match.UpdatedAt = now
+	return &match, nil
+}
+
+// Helper method holding the checks shared by Register, RegisterBatch and Update. It returns the match
+// as it would be stored
+func (r *MemoryMatchRepo) check(match domain.Match) (*memoryMatch, error) {
+	s := r.store
+
+	// Check if home team exists
+	home := s.activeTeamByName(match.HomeTeam)
+	if home == nil {
+		return nil, apperrors.ErrTeamNotFound.WithMessage("home team not found")
+	}
+
+	// Check if away team exists
+	away := s.activeTeamByName(match.AwayTeam)
+	if away == nil {
+		return nil, apperrors.ErrTeamNotFound.WithMessage("away team not found")
+	}
+
+	// Check if teams are different
+	if match.HomeTeam == match.AwayTeam {
+		return nil, apperrors.ErrInvalidInput.WithMessage("home team and away team cannot be the same")
+	}
+
+	// Check the season exists and covers the match date
+	if match.SeasonID != nil {
+		season := s.activeSeason(*match.SeasonID)
+		if season == nil {
+			return nil, apperrors.ErrSeasonNotFound
+		}
+		if !season.Contains(match.MatchDate) {
+			return nil, apperrors.ErrInvalidInput.WithMessage("match date is outside the season")
+		}
+	}
+
+	// Parse the time string to time.Time
+	matchTime, err := time.Parse("15:04", match.MatchTime)
+	if err != nil {
+		return nil, apperrors.ErrInvalidInput.WithMessage("invalid time format. Use HH:MM")
+	}
+	match.MatchTime = matchTime.Format("15:04")
+
+	return &memoryMatch{Match: match, homeID: home.ID, awayID: away.ID}, nil
+}
+
+// Helper method to store a checked match as a new scheduled match
+func (r *MemoryMatchRepo) insert(stored *memoryMatch) int {
+	now := time.Now()
+	stored.ID = r.store.nextID("matches")
+	stored.Status = domain.MatchScheduled
+	stored.CreatedAt, stored.UpdatedAt, stored.DeletedAt = now, now, nil
+	r.store.matches = append(r.store.matches, stored)
+	return stored.ID
+}
+
+// Helper method listing the matches shown by deleted that pass filter and include, in kick-off order
+func (r *MemoryMatchRepo) list(filter domain.MatchFilter, deleted domain.DeletedFilter, include func(*memoryMatch) bool) []domain.Match {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var matches []domain.Match
+	for _, m := range s.matches {
+		if deleted.Shows(m.DeletedAt) && r.passes(m, filter) && include(m) {
+			matches = append(matches, s.match(m))
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].PlayedBefore(matches[j])
+	})
+	return matches
+}
+
+// Helper method applying a domain.MatchFilter the way matchFilterClause does
+func (r *MemoryMatchRepo) passes(m *memoryMatch, filter domain.MatchFilter) bool {
+	if filter.SeasonID != nil && (m.SeasonID == nil || *m.SeasonID != *filter.SeasonID) {
+		return false
+	}
+	if filter.CompetitionID != nil {
+		inCompetition := false
+		for _, season := range r.store.seasons {
+			if m.SeasonID != nil && season.ID == *m.SeasonID && season.CompetitionID == *filter.CompetitionID {
+				inCompetition = true
+			}
+		}
+		if !inCompetition {
+			return false
+		}
+	}
+	if filter.From != nil && m.MatchDate.Before(*filter.From) {
+		return false
+	}
+	if filter.To != nil && m.MatchDate.After(*filter.To) {
+		return false
+	}
+	return true
+}
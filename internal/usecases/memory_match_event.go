@@ -0,0 +1,204 @@
+package usecases
+
+import (
+	"context"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"sort"
+	"time"
+)
+
+type MemoryMatchEventRepo struct {
+	store *MemoryStore
+}
+
+func NewMemoryMatchEventRepo(store *MemoryStore) *MemoryMatchEventRepo {
+	return &MemoryMatchEventRepo{store: store}
+}
+
+// Register records an event. A goal also updates the score of the match result, if there is one
+func (r *MemoryMatchEventRepo) Register(ctx context.Context, event domain.MatchEvent) (int, error) {
+	if err := event.Validate(); err != nil {
+		return 0, apperrors.Invalid(err)
+	}
+
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Check the match exists and has kicked off
+	if err := s.checkMatchPlayed(event.MatchID); err != nil {
+		return 0, err
+	}
+
+	// Check the scorer plays for the side the goal belongs to
+	if event.Type == domain.EventGoal {
+		if err := s.checkScorers(event.MatchID, []domain.Goal{event.ToGoal()}); err != nil {
+			return 0, err
+		}
+	}
+
+	now := time.Now()
+	stored, err := newMemoryEvent(event, now)
+	if err != nil {
+		return 0, err
+	}
+	stored.ID = s.nextID("match_events")
+	s.events = append(s.events, stored)
+
+	if event.Type == domain.EventGoal {
+		if err := s.syncResultScore(event.MatchID, now); err != nil {
+			s.events = s.events[:len(s.events)-1]
+			return 0, err
+		}
+	}
+	return stored.ID, nil
+}
+
+// Delete removes an event. Removing a goal also updates the score of the match result, if there is one
+func (r *MemoryMatchEventRepo) Delete(ctx context.Context, id int) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	event := r.activeByID(id)
+	if event == nil {
+		return apperrors.ErrMatchEventNotFound
+	}
+
+	now := time.Now()
+	updatedAt := event.UpdatedAt
+	event.DeletedAt, event.UpdatedAt = &now, now
+
+	if event.Type == domain.EventGoal {
+		if err := s.syncResultScore(event.MatchID, now); err != nil {
+			event.DeletedAt, event.UpdatedAt = nil, updatedAt
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *MemoryMatchEventRepo) GetByID(ctx context.Context, id int) (*domain.MatchEvent, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	event := r.activeByID(id)
+	if event == nil {
+		return nil, apperrors.ErrMatchEventNotFound
+	}
+	found := event.MatchEvent
+	return &found, nil
+}
+
+// ListByMatch returns the timeline of a match in the order the events happened
+func (r *MemoryMatchEventRepo) ListByMatch(ctx context.Context, matchID int) ([]domain.MatchEvent, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.matchEvents(matchID, ""), nil
+}
+
+// ListByMatches returns the events of all given matches, ordered by match and then as ListByMatch
+func (r *MemoryMatchEventRepo) ListByMatches(ctx context.Context, matchIDs []int) ([]domain.MatchEvent, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ids := append([]int(nil), matchIDs...)
+	sort.Ints(ids)
+
+	var events []domain.MatchEvent
+	for i, matchID := range ids {
+		if i > 0 && ids[i-1] == matchID {
+			continue
+		}
+		events = append(events, s.matchEvents(matchID, "")...)
+	}
+	return events, nil
+}
+
+// Helper method to find an active event by its ID
+func (r *MemoryMatchEventRepo) activeByID(id int) *memoryEvent {
+	for _, event := range r.store.events {
+		if event.ID == id && event.DeletedAt == nil {
+			return event
+		}
+	}
+	return nil
+}
+
+// newMemoryEvent prepares an already validated event for storing, it still needs an ID
+func newMemoryEvent(event domain.MatchEvent, now time.Time) (*memoryEvent, error) {
+	seconds, err := event.Seconds()
+	if err != nil {
+		return nil, err
+	}
+	event.CreatedAt, event.UpdatedAt, event.DeletedAt = now, now, nil
+	return &memoryEvent{MatchEvent: event, seconds: seconds}, nil
+}
+
+// matchEvents returns the active events of a match in the order they happened, only those of
+// eventType unless it is empty. The order is the one of matchEventOrder
+func (s *MemoryStore) matchEvents(matchID int, eventType string) []domain.MatchEvent {
+	var stored []*memoryEvent
+	for _, event := range s.events {
+		if event.MatchID == matchID && (eventType == "" || event.Type == eventType) && event.DeletedAt == nil {
+			stored = append(stored, event)
+		}
+	}
+
+	periods := []string{domain.PeriodFirstHalf, domain.PeriodSecondHalf, domain.PeriodExtraTime}
+	rank := func(period string) int {
+		for i, p := range periods {
+			if p == period {
+				return i
+			}
+		}
+		return len(periods)
+	}
+	sort.SliceStable(stored, func(i, j int) bool {
+		a, b := stored[i], stored[j]
+		if rank(a.Period) != rank(b.Period) {
+			return rank(a.Period) < rank(b.Period)
+		}
+		if a.seconds != b.seconds {
+			return a.seconds < b.seconds
+		}
+		return a.ID < b.ID
+	})
+
+	var events []domain.MatchEvent
+	for _, event := range stored {
+		events = append(events, event.MatchEvent)
+	}
+	return events
+}
+
+// syncResultScore recounts the score of a match result from its goal events, see syncResultScore.
+// Nothing is changed when it returns an error
+func (s *MemoryStore) syncResultScore(matchID int, now time.Time) error {
+	result := s.activeResult(matchID)
+	if result == nil {
+		// No result reported yet, it takes over the goal events once it is
+		return nil
+	}
+
+	var homeScore, awayScore int
+	for _, goal := range s.goals(matchID) {
+		switch goal.Team {
+		case "home":
+			homeScore++
+		case "away":
+			awayScore++
+		}
+	}
+	if homeScore != awayScore && len(s.shootout(matchID)) > 0 {
+		return apperrors.ErrInvalidInput.WithMessage("the match was decided on penalties, goals cannot break the level score")
+	}
+
+	result.HomeScore, result.AwayScore, result.UpdatedAt = homeScore, awayScore, now
+	return nil
+}
@@ -0,0 +1,415 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"sort"
+	"time"
+)
+
+type MemoryMatchResultRepo struct {
+	store *MemoryStore
+}
+
+func NewMemoryMatchResultRepo(store *MemoryStore) *MemoryMatchResultRepo {
+	return &MemoryMatchResultRepo{store: store}
+}
+
+func (r *MemoryMatchResultRepo) Register(ctx context.Context, result domain.MatchResult) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Check the match exists and has kicked off
+	if err := s.checkMatchPlayed(result.MatchID); err != nil {
+		return err
+	}
+
+	// Check if result already exists for this match. Like the unique constraint of the match_results
+	// table, a deleted result still counts, it can be restored instead
+	for _, existing := range s.results {
+		if existing.MatchID == result.MatchID {
+			return apperrors.ErrMatchResultAlreadyExists
+		}
+	}
+
+	// Without goals in the request, the goals already recorded on the match timeline make up the score
+	fromTimeline := len(result.Goals) == 0
+	if fromTimeline {
+		result.Goals = s.goals(result.MatchID)
+	}
+
+	// Validate the goals against the score, and the penalty shootout
+	if err := result.Validate(); err != nil {
+		return apperrors.Invalid(err)
+	}
+
+	// Check every scorer plays for the side the goal belongs to
+	if err := s.checkScorers(result.MatchID, result.Goals); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	goals, err := s.goalEvents(result, now)
+	if err != nil {
+		return err
+	}
+
+	s.results = append(s.results, &domain.MatchResult{
+		ID:        s.nextID("match_results"),
+		MatchID:   result.MatchID,
+		HomeScore: result.HomeScore,
+		AwayScore: result.AwayScore,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+
+	// The goals of the result replace the goal events recorded so far
+	s.replaceResultDetails(result, fromTimeline, goals, now)
+	return nil
+}
+
+func (r *MemoryMatchResultRepo) Update(ctx context.Context, id int, result domain.MatchResult) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Check if result exists
+	existing := r.activeByID(id)
+	if existing == nil {
+		return apperrors.ErrMatchResultNotFound
+	}
+	if result.MatchID != existing.MatchID {
+		return errResultMoved
+	}
+
+	// Check the match exists and has kicked off
+	if err := s.checkMatchPlayed(result.MatchID); err != nil {
+		return err
+	}
+
+	// Without goals in the request, the goals already recorded on the match timeline make up the score
+	fromTimeline := len(result.Goals) == 0
+	if fromTimeline {
+		result.Goals = s.goals(result.MatchID)
+	}
+
+	// Validate the goals against the score, and the penalty shootout
+	if err := result.Validate(); err != nil {
+		return apperrors.Invalid(err)
+	}
+
+	// Check every scorer plays for the side the goal belongs to
+	if err := s.checkScorers(result.MatchID, result.Goals); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	goals, err := s.goalEvents(result, now)
+	if err != nil {
+		return err
+	}
+
+	existing.HomeScore, existing.AwayScore, existing.UpdatedAt = result.HomeScore, result.AwayScore, now
+
+	// Replace the goals and shootout kicks of the match
+	s.replaceResultDetails(result, fromTimeline, goals, now)
+	return nil
+}
+
+func (r *MemoryMatchResultRepo) Delete(ctx context.Context, id int) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := r.activeByID(id)
+	if result == nil {
+		return apperrors.ErrMatchResultNotFound
+	}
+	now := time.Now()
+	result.DeletedAt, result.UpdatedAt = &now, now
+	return nil
+}
+
+func (r *MemoryMatchResultRepo) List(ctx context.Context) ([]domain.MatchResult, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var results []domain.MatchResult
+	for _, result := range s.results {
+		if result.DeletedAt == nil {
+			results = append(results, r.result(result))
+		}
+	}
+	// Newest first
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].ID > results[j].ID
+	})
+	return results, nil
+}
+
+// ListByMatches returns the active results of the given matches, ordered by match
+func (r *MemoryMatchResultRepo) ListByMatches(ctx context.Context, matchIDs []int) ([]domain.MatchResult, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	wanted := make(map[int]bool, len(matchIDs))
+	for _, matchID := range matchIDs {
+		wanted[matchID] = true
+	}
+	var results []domain.MatchResult
+	for _, result := range s.results {
+		if wanted[result.MatchID] && result.DeletedAt == nil {
+			results = append(results, r.result(result))
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].MatchID < results[j].MatchID
+	})
+	return results, nil
+}
+
+// memoryMatchResultSorts compares results by the sort fields of domain.MatchResultSortFields, see
+// matchResultSortColumns
+var memoryMatchResultSorts = map[string]func(a, b domain.MatchResult) int{
+	"id":         func(a, b domain.MatchResult) int { return cmp.Compare(a.ID, b.ID) },
+	"match_id":   func(a, b domain.MatchResult) int { return cmp.Compare(a.MatchID, b.MatchID) },
+	"created_at": func(a, b domain.MatchResult) int { return a.CreatedAt.Compare(b.CreatedAt) },
+}
+
+func (r *MemoryMatchResultRepo) Search(ctx context.Context, query domain.MatchResultQuery) (*domain.Page[domain.MatchResult], error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var results []domain.MatchResult
+	for _, result := range s.results {
+		if query.Deleted.Shows(result.DeletedAt) {
+			results = append(results, r.result(result))
+		}
+	}
+	page := query.PageRequest.Normalized(domain.DefaultMatchResultSort)
+	return memoryPage(results, page, memoryMatchResultSorts, func(result domain.MatchResult) int { return result.ID }), nil
+}
+
+func (r *MemoryMatchResultRepo) GetByMatchID(ctx context.Context, matchID int) (*domain.MatchResult, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stored := s.activeResult(matchID)
+	if stored == nil {
+		return nil, apperrors.ErrMatchResultNotFound
+	}
+	result := r.result(stored)
+	return &result, nil
+}
+
+func (r *MemoryMatchResultRepo) GetByID(ctx context.Context, id int) (*domain.MatchResult, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stored := r.activeByID(id)
+	if stored == nil {
+		return nil, apperrors.ErrMatchResultNotFound
+	}
+	result := r.result(stored)
+	return &result, nil
+}
+
+func (r *MemoryMatchResultRepo) Restore(ctx context.Context, id int) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, result := range s.results {
+		if result.ID == id && result.DeletedAt != nil {
+			result.DeletedAt, result.UpdatedAt = nil, time.Now()
+			return nil
+		}
+	}
+	return apperrors.ErrMatchResultNotFound.WithMessage("match result not found or not deleted")
+}
+
+// ListBetween returns the finished matches between two teams with their results, most recent first
+func (r *MemoryMatchResultRepo) ListBetween(ctx context.Context, team, opponent string) ([]domain.HeadToHeadMatch, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var played []domain.Match
+	for _, stored := range s.matches {
+		match := s.match(stored)
+		if match.DeletedAt != nil || match.Status != domain.MatchFinished || s.activeResult(match.ID) == nil {
+			continue
+		}
+		if (match.HomeTeam == team && match.AwayTeam == opponent) || (match.HomeTeam == opponent && match.AwayTeam == team) {
+			played = append(played, match)
+		}
+	}
+	sort.SliceStable(played, func(i, j int) bool {
+		return played[j].PlayedBefore(played[i])
+	})
+
+	var matches []domain.HeadToHeadMatch
+	for _, match := range played {
+		result := s.activeResult(match.ID)
+		m := domain.HeadToHeadMatch{
+			MatchID:   match.ID,
+			MatchDate: match.MatchDate.Format("2006-01-02"),
+			HomeTeam:  match.HomeTeam,
+			AwayTeam:  match.AwayTeam,
+			HomeScore: result.HomeScore,
+			AwayScore: result.AwayScore,
+		}
+		if shootout := s.shootout(match.ID); len(shootout) > 0 {
+			full := domain.MatchResult{Shootout: shootout}
+			homePenalties, awayPenalties := full.ShootoutScore()
+			m.HomePenalties, m.AwayPenalties = &homePenalties, &awayPenalties
+		}
+		matches = append(matches, m)
+	}
+	return matches, nil
+}
+
+// Helper method to find an active result by its ID
+func (r *MemoryMatchResultRepo) activeByID(id int) *domain.MatchResult {
+	for _, result := range r.store.results {
+		if result.ID == id && result.DeletedAt == nil {
+			return result
+		}
+	}
+	return nil
+}
+
+// Helper method returning a copy of a stored result with its goals and shootout kicks
+func (r *MemoryMatchResultRepo) result(stored *domain.MatchResult) domain.MatchResult {
+	result := *stored
+	result.Goals = r.store.goals(result.MatchID)
+	result.Shootout = r.store.shootout(result.MatchID)
+	return result
+}
+
+func (s *MemoryStore) activeResult(matchID int) *domain.MatchResult {
+	for _, result := range s.results {
+		if result.MatchID == matchID && result.DeletedAt == nil {
+			return result
+		}
+	}
+	return nil
+}
+
+// checkMatchPlayed verifies that a match exists and is live or finished, see checkMatchPlayed
+func (s *MemoryStore) checkMatchPlayed(matchID int) error {
+	match := s.activeMatch(matchID)
+	if match == nil {
+		return apperrors.ErrMatchNotFound
+	}
+	if !match.Status.AcceptsResult() {
+		return apperrors.ErrMatchNotPlayed.WithMessage(fmt.Sprintf("match is %s, results and events can only be recorded once it is live or finished", match.Status))
+	}
+	return nil
+}
+
+// checkScorers verifies that every scorer and assist is a registered player of the side the goal
+// belongs to, see checkScorers
+func (s *MemoryStore) checkScorers(matchID int, goals []domain.Goal) error {
+	if len(goals) == 0 {
+		return nil
+	}
+
+	stored := s.activeMatch(matchID)
+	if stored == nil {
+		return apperrors.ErrMatchNotFound
+	}
+	match := s.match(stored)
+
+	playerTeams := make(map[string]string)
+	for _, p := range s.players {
+		if p.DeletedAt == nil && (p.teamID == stored.homeID || p.teamID == stored.awayID) {
+			playerTeams[p.Name] = s.teamName(p.teamID)
+		}
+	}
+
+	if invalid := findInvalidGoals(goals, match.HomeTeam, match.AwayTeam, playerTeams); len(invalid) > 0 {
+		return invalidGoals(invalid)
+	}
+	return nil
+}
+
+// goals returns the goal events of a match as goals, in the order they were scored
+func (s *MemoryStore) goals(matchID int) []domain.Goal {
+	var goals []domain.Goal
+	for _, event := range s.matchEvents(matchID, domain.EventGoal) {
+		goals = append(goals, event.ToGoal())
+	}
+	return goals
+}
+
+// shootout returns the penalty shootout kicks of a match in the order they were taken
+func (s *MemoryStore) shootout(matchID int) []domain.ShootoutKick {
+	var kicks []domain.ShootoutKick
+	for _, kick := range s.kicks {
+		if kick.MatchID == matchID && kick.DeletedAt == nil {
+			kicks = append(kicks, *kick)
+		}
+	}
+	sort.SliceStable(kicks, func(i, j int) bool {
+		return kicks[i].Order < kicks[j].Order
+	})
+	return kicks
+}
+
+// goalEvents turns the goals of a result into events, before anything is stored so a goal with an
+// invalid time of play leaves no trace
+func (s *MemoryStore) goalEvents(result domain.MatchResult, now time.Time) ([]*memoryEvent, error) {
+	events := make([]*memoryEvent, 0, len(result.Goals))
+	for _, goal := range result.Goals {
+		event, err := newMemoryEvent(goal.ToMatchEvent(result.MatchID), now)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// replaceResultDetails soft-deletes the goal events and shootout kicks of the result's match and stores
+// those of the result instead. Goals taken from the timeline are already there and stay as they are
+func (s *MemoryStore) replaceResultDetails(result domain.MatchResult, fromTimeline bool, goals []*memoryEvent, now time.Time) {
+	if !fromTimeline {
+		for _, event := range s.events {
+			if event.MatchID == result.MatchID && event.Type == domain.EventGoal && event.DeletedAt == nil {
+				event.DeletedAt, event.UpdatedAt = &now, now
+			}
+		}
+		for _, event := range goals {
+			event.ID = s.nextID("match_events")
+			s.events = append(s.events, event)
+		}
+	}
+
+	for _, kick := range s.kicks {
+		if kick.MatchID == result.MatchID && kick.DeletedAt == nil {
+			kick.DeletedAt, kick.UpdatedAt = &now, now
+		}
+	}
+	for _, kick := range result.Shootout {
+		s.kicks = append(s.kicks, &domain.ShootoutKick{
+			ID:        s.nextID("shootout_kicks"),
+			MatchID:   result.MatchID,
+			Order:     kick.Order,
+			Team:      kick.Team,
+			Taker:     kick.Taker,
+			Scored:    kick.Scored,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"strings"
+	"time"
+)
+
+type MemoryPlayerRepo struct {
+	store *MemoryStore
+}
+
+func NewMemoryPlayerRepo(store *MemoryStore) *MemoryPlayerRepo {
+	return &MemoryPlayerRepo{store: store}
+}
+
+func (r *MemoryPlayerRepo) Register(ctx context.Context, player domain.Player) (int, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Check if team exists
+	team := s.activeTeamByName(player.TeamName)
+	if team == nil {
+		return 0, apperrors.ErrTeamNotFound
+	}
+
+	// Check if jersey number is already taken in the team
+	if s.jerseyTaken(team.ID, player.JerseyNumber, 0) {
+		return 0, apperrors.ErrJerseyNumberTaken
+	}
+
+	// Check if player already exists
+	for _, p := range s.players {
+		if p.Name == player.Name {
+			return 0, apperrors.ErrPlayerAlreadyExists
+		}
+	}
+
+	now := time.Now()
+	player.ID = s.nextID("players")
+	player.Slug = uniqueMemorySlug(domain.Slugify(player.Name, "player"), func(slug string) bool {
+		for _, p := range s.players {
+			if p.Slug == slug {
+				return true
+			}
+		}
+		return false
+	})
+	player.CreatedAt, player.UpdatedAt, player.DeletedAt = now, now, nil
+	contractStart := player.FirstContractStart(now)
+	player.ContractStart = ""
+	s.players = append(s.players, &memoryPlayer{Player: player, teamID: team.ID})
+
+	// The player starts on a permanent contract with the team they are registered with
+	s.contracts = append(s.contracts, &memoryContract{
+		Contract: domain.Contract{ID: s.nextID("contracts"), Type: domain.ContractPermanent, StartDate: contractStart, CreatedAt: now, UpdatedAt: now},
+		playerID: player.ID,
+		teamID:   team.ID,
+	})
+
+	return player.ID, nil
+}
+
+// Update changes the player's details, and writes a new name onto the match sheets like the Postgres
+// repository does
+func (r *MemoryPlayerRepo) Update(ctx context.Context, key string, player domain.Player) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Check the player stays with their team, moving to another team takes a transfer
+	existing := r.activeByKey(key)
+	if existing == nil {
+		return apperrors.ErrPlayerNotFound
+	}
+	if player.TeamName != s.teamName(existing.teamID) {
+		return apperrors.ErrInvalidInput.WithMessage("player team cannot be changed here. Use a transfer to move the player to another team")
+	}
+
+	// Check if the name is taken by another player
+	for _, p := range s.players {
+		if p.Name == player.Name && p.ID != existing.ID {
+			return apperrors.ErrPlayerNameTaken
+		}
+	}
+
+	// Check if jersey number is already taken by another player in the team
+	if s.jerseyTaken(existing.teamID, player.JerseyNumber, existing.ID) {
+		return apperrors.ErrJerseyNumberTaken
+	}
+
+	if player.Name != existing.Name {
+		s.renameOnMatchSheets(existing.Name, player.Name)
+	}
+	existing.Name, existing.Height, existing.Weight = player.Name, player.Height, player.Weight
+	existing.Position, existing.JerseyNumber, existing.UpdatedAt = player.Position, player.JerseyNumber, time.Now()
+	return nil
+}
+
+func (r *MemoryPlayerRepo) Delete(ctx context.Context, key string) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	player := r.activeByKey(key)
+	if player == nil {
+		return apperrors.ErrPlayerNotFound
+	}
+	now := time.Now()
+	player.DeletedAt, player.UpdatedAt = &now, now
+	return nil
+}
+
+func (r *MemoryPlayerRepo) List(ctx context.Context) ([]domain.Player, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var players []domain.Player
+	for _, p := range s.players {
+		if p.DeletedAt == nil {
+			players = append(players, r.player(p))
+		}
+	}
+	return players, nil
+}
+
+// ListByTeam returns the players of the team with the given ID or slug
+func (r *MemoryPlayerRepo) ListByTeam(ctx context.Context, teamKey string) ([]domain.Player, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var players []domain.Player
+	for _, p := range s.players {
+		team := s.teamByID(p.teamID)
+		if p.DeletedAt == nil && matchesKey(team.ID, team.Slug, teamKey) {
+			players = append(players, r.player(p))
+		}
+	}
+	return players, nil
+}
+
+// memoryPlayerSorts compares players by the sort fields of domain.PlayerSortFields, see playerSortColumns
+var memoryPlayerSorts = map[string]func(a, b domain.Player) int{
+	"id":            func(a, b domain.Player) int { return cmp.Compare(a.ID, b.ID) },
+	"name":          func(a, b domain.Player) int { return strings.Compare(a.Name, b.Name) },
+	"position":      func(a, b domain.Player) int { return strings.Compare(string(a.Position), string(b.Position)) },
+	"jersey_number": func(a, b domain.Player) int { return cmp.Compare(a.JerseyNumber, b.JerseyNumber) },
+	"height":        func(a, b domain.Player) int { return cmp.Compare(a.Height, b.Height) },
+	"weight":        func(a, b domain.Player) int { return cmp.Compare(a.Weight, b.Weight) },
+	"team":          func(a, b domain.Player) int { return strings.Compare(a.TeamName, b.TeamName) },
+	"created_at":    func(a, b domain.Player) int { return a.CreatedAt.Compare(b.CreatedAt) },
+}
+
+func (r *MemoryPlayerRepo) Search(ctx context.Context, query domain.PlayerQuery) (*domain.Page[domain.Player], error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var players []domain.Player
+	for _, p := range s.players {
+		if !query.Deleted.Shows(p.DeletedAt) {
+			continue
+		}
+		if team := s.teamByID(p.teamID); query.Team != "" && !matchesKey(team.ID, team.Slug, query.Team) {
+			continue
+		}
+		if query.Position != "" && p.Position != query.Position {
+			continue
+		}
+		if query.JerseyNumber != nil && p.JerseyNumber != *query.JerseyNumber {
+			continue
+		}
+		players = append(players, r.player(p))
+	}
+	page := query.PageRequest.Normalized(domain.DefaultPlayerSort)
+	return memoryPage(players, page, memoryPlayerSorts, func(p domain.Player) int { return p.ID }), nil
+}
+
+func (r *MemoryPlayerRepo) GetByKey(ctx context.Context, key string) (*domain.Player, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p := r.activeByKey(key)
+	if p == nil {
+		return nil, apperrors.ErrPlayerNotFound
+	}
+	player := r.player(p)
+	return &player, nil
+}
+
+func (r *MemoryPlayerRepo) Restore(ctx context.Context, key string) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, p := range s.players {
+		if matchesKey(p.ID, p.Slug, key) && p.DeletedAt != nil {
+			p.DeletedAt, p.UpdatedAt = nil, time.Now()
+			return nil
+		}
+	}
+	return apperrors.ErrPlayerNotFound.WithMessage("player not found or not deleted")
+}
+
+// Helper method to find the active player with the given ID or slug
+func (r *MemoryPlayerRepo) activeByKey(key string) *memoryPlayer {
+	for _, p := range r.store.players {
+		if matchesKey(p.ID, p.Slug, key) && p.DeletedAt == nil {
+			return p
+		}
+	}
+	return nil
+}
+
+// renameOnMatchSheets rewrites the events and shootout kicks recording a player by their old name
+func (s *MemoryStore) renameOnMatchSheets(oldName, newName string) {
+	rename := func(name *string) {
+		if *name == oldName {
+			*name = newName
+		}
+	}
+	for _, event := range s.events {
+		rename(&event.Player)
+		rename(&event.Assist)
+		rename(&event.PlayerIn)
+	}
+	for _, kick := range s.kicks {
+		rename(&kick.Taker)
+	}
+}
+
+// Helper method returning a copy of a stored player with its team name filled in
+func (r *MemoryPlayerRepo) player(p *memoryPlayer) domain.Player {
+	player := p.Player
+	player.TeamName = r.store.teamName(p.teamID)
+	return player
+}
+
+// jerseyTaken reports whether a player of the team other than exceptID wears number. Like the unique
+// constraint of the players table, deleted players keep their number so they can be restored
+func (s *MemoryStore) jerseyTaken(teamID, number, exceptID int) bool {
+	for _, p := range s.players {
+		if p.teamID == teamID && p.JerseyNumber == number && p.ID != exceptID {
+			return true
+		}
+	}
+	return false
+}
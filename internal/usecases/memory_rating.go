@@ -0,0 +1,141 @@
+package usecases
+
+import (
+	"context"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"sort"
+	"time"
+)
+
+type MemoryRatingRepo struct {
+	store *MemoryStore
+}
+
+func NewMemoryRatingRepo(store *MemoryStore) *MemoryRatingRepo {
+	return &MemoryRatingRepo{store: store}
+}
+
+// List returns the current rating of every rated team, the one after its latest rated match
+func (r *MemoryRatingRepo) List(ctx context.Context) ([]domain.TeamRating, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	byTeam := make(map[int]*domain.TeamRating)
+	var ratings []*domain.TeamRating
+	for _, change := range r.changes(func(*memoryRatingChange) bool { return true }) {
+		rating, ok := byTeam[change.teamID]
+		if !ok {
+			rating = &domain.TeamRating{Team: s.teamName(change.teamID)}
+			byTeam[change.teamID] = rating
+			ratings = append(ratings, rating)
+		}
+		createdAt := change.CreatedAt
+		rating.Rating, rating.UpdatedAt = change.After, &createdAt
+		rating.Played++
+	}
+
+	sort.SliceStable(ratings, func(i, j int) bool {
+		if ratings[i].Rating != ratings[j].Rating {
+			return ratings[i].Rating > ratings[j].Rating
+		}
+		return ratings[i].Team < ratings[j].Team
+	})
+	var list []domain.TeamRating
+	for _, rating := range ratings {
+		list = append(list, *rating)
+	}
+	return list, nil
+}
+
+// ListChanges returns the whole rating history in the order the matches were played
+func (r *MemoryRatingRepo) ListChanges(ctx context.Context) ([]domain.RatingChange, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return r.named(r.changes(func(*memoryRatingChange) bool { return true })), nil
+}
+
+// History returns the rating changes of one team in the order the matches were played
+func (r *MemoryRatingRepo) History(ctx context.Context, team string) ([]domain.RatingChange, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return r.named(r.changes(func(change *memoryRatingChange) bool { return s.teamName(change.teamID) == team })), nil
+}
+
+// ReplaceFrom replaces the history of from and every later match with changes. A nil from replaces
+// the whole history
+func (r *MemoryRatingRepo) ReplaceFrom(ctx context.Context, from *domain.Match, changes []domain.RatingChange) error {
+	if from != nil {
+		if _, err := time.Parse("15:04", from.MatchTime); err != nil {
+			return apperrors.ErrInvalidInput.WithMessage("invalid time format. Use HH:MM")
+		}
+	}
+
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	replacements := make([]*memoryRatingChange, 0, len(changes))
+	for _, change := range changes {
+		matchTime, err := time.Parse("15:04", change.MatchTime)
+		if err != nil {
+			return apperrors.ErrInvalidInput.WithMessage("invalid time format. Use HH:MM")
+		}
+		change.MatchTime = matchTime.Format("15:04")
+		change.CreatedAt = now
+		replacement := &memoryRatingChange{RatingChange: change}
+		if team := s.teamByName(change.Team); team != nil {
+			replacement.teamID = team.ID
+		}
+		if opponent := s.teamByName(change.Opponent); opponent != nil {
+			replacement.opponentID = opponent.ID
+		}
+		replacements = append(replacements, replacement)
+	}
+
+	kept := s.ratings[:0]
+	for _, change := range s.ratings {
+		rated := changedMatch(change.RatingChange)
+		if from != nil && rated.PlayedBefore(*from) {
+			kept = append(kept, change)
+		}
+	}
+	s.ratings = kept
+	for _, replacement := range replacements {
+		replacement.ID = s.nextID("rating_history")
+		s.ratings = append(s.ratings, replacement)
+	}
+	return nil
+}
+
+// Helper method returning the stored changes passing include in the order the matches were played
+func (r *MemoryRatingRepo) changes(include func(*memoryRatingChange) bool) []*memoryRatingChange {
+	var changes []*memoryRatingChange
+	for _, change := range r.store.ratings {
+		if include(change) {
+			changes = append(changes, change)
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		a, b := changedMatch(changes[i].RatingChange), changedMatch(changes[j].RatingChange)
+		return a.PlayedBefore(b)
+	})
+	return changes
+}
+
+// Helper method returning copies of stored changes with the team names filled in
+func (r *MemoryRatingRepo) named(stored []*memoryRatingChange) []domain.RatingChange {
+	var changes []domain.RatingChange
+	for _, change := range stored {
+		named := change.RatingChange
+		named.Team, named.Opponent = r.store.teamName(change.teamID), r.store.teamName(change.opponentID)
+		changes = append(changes, named)
+	}
+	return changes
+}
@@ -0,0 +1,153 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"strings"
+	"time"
+)
+
+type MemoryTeamRepo struct {
+	store *MemoryStore
+}
+
+func NewMemoryTeamRepo(store *MemoryStore) *MemoryTeamRepo {
+	return &MemoryTeamRepo{store: store}
+}
+
+func (r *MemoryTeamRepo) Register(ctx context.Context, team domain.Team) (int, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Check if team already exists
+	if s.teamByName(team.Name) != nil {
+		return 0, apperrors.ErrTeamAlreadyExists
+	}
+
+	now := time.Now()
+	team.ID = s.nextID("teams")
+	team.Slug = uniqueMemorySlug(domain.Slugify(team.Name, "team"), func(slug string) bool {
+		for _, t := range s.teams {
+			if t.Slug == slug {
+				return true
+			}
+		}
+		return false
+	})
+	team.CreatedAt, team.UpdatedAt, team.DeletedAt = now, now, nil
+	s.teams = append(s.teams, &team)
+	return team.ID, nil
+}
+
+// Update changes the team's details. Tables refer to the team by its ID, so a new name shows everywhere.
+// Access tokens list the manager's teams by name and only pick it up when they are refreshed
+func (r *MemoryTeamRepo) Update(ctx context.Context, key string, team domain.Team) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Check if the name is taken by another team
+	if taken := s.teamByName(team.Name); taken != nil && !matchesKey(taken.ID, taken.Slug, key) {
+		return apperrors.ErrTeamNameTaken
+	}
+
+	existing := r.activeByKey(key)
+	if existing == nil {
+		return apperrors.ErrTeamNotFound
+	}
+	existing.Name, existing.Logo, existing.YearFounded = team.Name, team.Logo, team.YearFounded
+	existing.StadiumAddr, existing.City, existing.UpdatedAt = team.StadiumAddr, team.City, time.Now()
+	return nil
+}
+
+func (r *MemoryTeamRepo) Delete(ctx context.Context, key string) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	team := r.activeByKey(key)
+	if team == nil {
+		return apperrors.ErrTeamNotFound
+	}
+	now := time.Now()
+	team.DeletedAt, team.UpdatedAt = &now, now
+	return nil
+}
+
+func (r *MemoryTeamRepo) List(ctx context.Context) ([]domain.Team, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var teams []domain.Team
+	for _, team := range s.teams {
+		if team.DeletedAt == nil {
+			teams = append(teams, *team)
+		}
+	}
+	return teams, nil
+}
+
+// memoryTeamSorts compares teams by the sort fields of domain.TeamSortFields, see teamSortColumns
+var memoryTeamSorts = map[string]func(a, b domain.Team) int{
+	"id":           func(a, b domain.Team) int { return cmp.Compare(a.ID, b.ID) },
+	"name":         func(a, b domain.Team) int { return strings.Compare(a.Name, b.Name) },
+	"city":         func(a, b domain.Team) int { return strings.Compare(a.City, b.City) },
+	"year_founded": func(a, b domain.Team) int { return cmp.Compare(a.YearFounded, b.YearFounded) },
+	"created_at":   func(a, b domain.Team) int { return a.CreatedAt.Compare(b.CreatedAt) },
+}
+
+func (r *MemoryTeamRepo) Search(ctx context.Context, query domain.TeamQuery) (*domain.Page[domain.Team], error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var teams []domain.Team
+	for _, team := range s.teams {
+		if query.Deleted.Shows(team.DeletedAt) && (query.City == "" || strings.EqualFold(team.City, query.City)) {
+			teams = append(teams, *team)
+		}
+	}
+	page := query.PageRequest.Normalized(domain.DefaultTeamSort)
+	return memoryPage(teams, page, memoryTeamSorts, func(t domain.Team) int { return t.ID }), nil
+}
+
+func (r *MemoryTeamRepo) GetByKey(ctx context.Context, key string) (*domain.Team, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	team := r.activeByKey(key)
+	if team == nil {
+		return nil, apperrors.ErrTeamNotFound
+	}
+	found := *team
+	return &found, nil
+}
+
+func (r *MemoryTeamRepo) Restore(ctx context.Context, key string) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, team := range s.teams {
+		if matchesKey(team.ID, team.Slug, key) && team.DeletedAt != nil {
+			team.DeletedAt, team.UpdatedAt = nil, time.Now()
+			return nil
+		}
+	}
+	return apperrors.ErrTeamNotFound.WithMessage("team not found or not deleted")
+}
+
+// Helper method to find the active team with the given ID or slug
+func (r *MemoryTeamRepo) activeByKey(key string) *domain.Team {
+	for _, team := range r.store.teams {
+		if matchesKey(team.ID, team.Slug, key) && team.DeletedAt == nil {
+			return team
+		}
+	}
+	return nil
+}
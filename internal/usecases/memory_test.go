@@ -0,0 +1,211 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMemoryFixture returns memory repositories holding the teams Persija and Persib with one player each
+// and a finished match between them without a result
+func newMemoryFixture(t *testing.T) (*Repositories, int) {
+	ctx := context.Background()
+	repos := NewMemoryRepositories()
+	for _, name := range []string{"Persija", "Persib"} {
+		_, err := repos.Teams.Register(ctx, domain.Team{Name: name})
+		require.NoError(t, err)
+	}
+	_, err := repos.Players.Register(ctx, domain.Player{Name: "Riko", JerseyNumber: 10, TeamName: "Persija"})
+	require.NoError(t, err)
+	_, err = repos.Players.Register(ctx, domain.Player{Name: "Ciro", JerseyNumber: 9, TeamName: "Persib"})
+	require.NoError(t, err)
+
+	matchID, err := repos.Matches.Register(ctx, domain.Match{MatchDate: time.Date(2024, 8, 10, 0, 0, 0, 0, time.UTC), MatchTime: "19:00", HomeTeam: "Persija", AwayTeam: "Persib"})
+	require.NoError(t, err)
+	for _, status := range []domain.MatchStatus{domain.MatchLive, domain.MatchFinished} {
+		_, err := repos.Matches.UpdateStatus(ctx, matchID, status)
+		require.NoError(t, err)
+	}
+	return repos, matchID
+}
+
+func TestMemoryTeamRepo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Names are unique and slugs are derived from them", func(t *testing.T) {
+		repo := NewMemoryTeamRepo(NewMemoryStore())
+		id, err := repo.Register(ctx, domain.Team{Name: "Bali United"})
+		require.NoError(t, err)
+		_, err = repo.Register(ctx, domain.Team{Name: "Bali United"})
+		assert.EqualError(t, err, "team already exists")
+
+		team, err := repo.GetByKey(ctx, "bali-united")
+		require.NoError(t, err)
+		assert.Equal(t, id, team.ID)
+	})
+
+	t.Run("Soft delete and restore", func(t *testing.T) {
+		repo := NewMemoryTeamRepo(NewMemoryStore())
+		_, err := repo.Register(ctx, domain.Team{Name: "Persija"})
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Delete(ctx, "persija"))
+		_, err = repo.GetByKey(ctx, "persija")
+		assert.EqualError(t, err, "team not found")
+		assert.EqualError(t, repo.Delete(ctx, "persija"), "team not found")
+
+		require.NoError(t, repo.Restore(ctx, "1"))
+		assert.EqualError(t, repo.Restore(ctx, "1"), "team not found or not deleted")
+		team, err := repo.GetByKey(ctx, "persija")
+		require.NoError(t, err)
+		assert.Nil(t, team.DeletedAt)
+	})
+}
+
+func TestMemoryPlayerRepo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("The team must exist", func(t *testing.T) {
+		repos, _ := newMemoryFixture(t)
+		_, err := repos.Players.Register(ctx, domain.Player{Name: "Andi", JerseyNumber: 8, TeamName: "Arema"})
+		assert.EqualError(t, err, "team not found")
+
+		require.NoError(t, repos.Teams.Delete(ctx, "persib"))
+		_, err = repos.Players.Register(ctx, domain.Player{Name: "Andi", JerseyNumber: 8, TeamName: "Persib"})
+		assert.EqualError(t, err, "team not found")
+	})
+
+	t.Run("Jersey numbers are unique within a team", func(t *testing.T) {
+		repos, _ := newMemoryFixture(t)
+		_, err := repos.Players.Register(ctx, domain.Player{Name: "Andi", JerseyNumber: 10, TeamName: "Persija"})
+		assert.EqualError(t, err, "jersey number already taken in this team")
+
+		_, err = repos.Players.Register(ctx, domain.Player{Name: "Andi", JerseyNumber: 10, TeamName: "Persib"})
+		require.NoError(t, err)
+		err = repos.Players.Update(ctx, "andi", domain.Player{Name: "Andi", JerseyNumber: 9, TeamName: "Persib"})
+		assert.EqualError(t, err, "jersey number already taken in this team")
+	})
+
+	t.Run("Renaming the team shows on its players", func(t *testing.T) {
+		repos, _ := newMemoryFixture(t)
+		require.NoError(t, repos.Teams.Update(ctx, "persija", domain.Team{Name: "Persija Jakarta"}))
+
+		players, err := repos.Players.ListByTeam(ctx, "persija")
+		require.NoError(t, err)
+		require.Len(t, players, 1)
+		assert.Equal(t, "Persija Jakarta", players[0].TeamName)
+	})
+
+	t.Run("Registering signs a permanent contract", func(t *testing.T) {
+		repos, _ := newMemoryFixture(t)
+		contracts, err := repos.Transfers.ListContracts(ctx, "riko")
+		require.NoError(t, err)
+		require.Len(t, contracts, 1)
+		assert.Equal(t, domain.ContractPermanent, contracts[0].Type)
+		assert.Equal(t, "Persija", contracts[0].Team)
+	})
+}
+
+func TestMemoryMatchResultRepo(t *testing.T) {
+	ctx := context.Background()
+	goal := domain.Goal{Scorer: "Riko", GoalTime: "10:00", Team: "home"}
+
+	t.Run("One result per match", func(t *testing.T) {
+		repos, matchID := newMemoryFixture(t)
+		require.NoError(t, repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: matchID, HomeScore: 1, Goals: []domain.Goal{goal}}))
+		err := repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: matchID, HomeScore: 1, Goals: []domain.Goal{goal}})
+		assert.EqualError(t, err, "result already exists for this match")
+	})
+
+	t.Run("The match must have kicked off", func(t *testing.T) {
+		repos, _ := newMemoryFixture(t)
+		matchID, err := repos.Matches.Register(ctx, domain.Match{MatchDate: time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC), MatchTime: "19:00", HomeTeam: "Persib", AwayTeam: "Persija"})
+		require.NoError(t, err)
+
+		err = repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: matchID})
+		assert.EqualError(t, err, "match is scheduled, results and events can only be recorded once it is live or finished")
+	})
+
+	t.Run("Scorers must play for their side", func(t *testing.T) {
+		repos, matchID := newMemoryFixture(t)
+		err := repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: matchID, AwayScore: 1, Goals: []domain.Goal{{Scorer: "Riko", GoalTime: "10:00", Team: "away"}}})
+		var invalid *domain.InvalidGoalsError
+		assert.ErrorAs(t, err, &invalid)
+	})
+
+	t.Run("Goal events make up the score", func(t *testing.T) {
+		repos, matchID := newMemoryFixture(t)
+		_, err := repos.MatchEvents.Register(ctx, domain.MatchEvent{MatchID: matchID, Type: domain.EventGoal, EventTime: "30:00", Team: "away", Player: "Ciro"})
+		require.NoError(t, err)
+		require.NoError(t, repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: matchID, AwayScore: 1}))
+
+		eventID, err := repos.MatchEvents.Register(ctx, domain.MatchEvent{MatchID: matchID, Type: domain.EventGoal, EventTime: "12:00", Team: "home", Player: "Riko"})
+		require.NoError(t, err)
+		result, err := repos.MatchResults.GetByMatchID(ctx, matchID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.HomeScore)
+		require.Len(t, result.Goals, 2)
+		assert.Equal(t, "Riko", result.Goals[0].Scorer)
+
+		require.NoError(t, repos.MatchEvents.Delete(ctx, eventID))
+		result, err = repos.MatchResults.GetByMatchID(ctx, matchID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.HomeScore)
+	})
+
+	t.Run("Soft delete and restore", func(t *testing.T) {
+		repos, matchID := newMemoryFixture(t)
+		require.NoError(t, repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: matchID, HomeScore: 1, Goals: []domain.Goal{goal}}))
+		result, err := repos.MatchResults.GetByMatchID(ctx, matchID)
+		require.NoError(t, err)
+
+		require.NoError(t, repos.MatchResults.Delete(ctx, result.ID))
+		_, err = repos.MatchResults.GetByMatchID(ctx, matchID)
+		assert.ErrorIs(t, err, apperrors.ErrMatchResultNotFound)
+
+		require.NoError(t, repos.MatchResults.Restore(ctx, result.ID))
+		restored, err := repos.MatchResults.GetByID(ctx, result.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, restored.HomeScore)
+		assert.Len(t, restored.Goals, 1)
+	})
+}
+
+func TestMemoryStore_Concurrency(t *testing.T) {
+	ctx := context.Background()
+	repos, _ := newMemoryFixture(t)
+
+	// Every number is wanted by two players at once, exactly one of them gets it
+	var wg sync.WaitGroup
+	errs := make(chan error, 40)
+	for i := 0; i < 40; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := repos.Players.Register(ctx, domain.Player{Name: fmt.Sprintf("Player %d", i), JerseyNumber: 20 + i/2, TeamName: "Persija"})
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	failed := 0
+	for err := range errs {
+		if err != nil {
+			assert.EqualError(t, err, "jersey number already taken in this team")
+			failed++
+		}
+	}
+	assert.Equal(t, 20, failed)
+
+	players, err := repos.Players.ListByTeam(ctx, "persija")
+	require.NoError(t, err)
+	assert.Len(t, players, 21)
+}
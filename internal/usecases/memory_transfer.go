@@ -0,0 +1,275 @@
+package usecases
+
+import (
+	"context"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"sort"
+	"time"
+)
+
+type MemoryTransferRepo struct {
+	store *MemoryStore
+}
+
+func NewMemoryTransferRepo(store *MemoryStore) *MemoryTransferRepo {
+	return &MemoryTransferRepo{store: store}
+}
+
+// Register moves the player, given by ID or slug, to the new team, takes the new jersey number and updates
+// the contracts, all at once. It returns the transfer with the player's name and the selling team filled in
+func (r *MemoryTransferRepo) Register(ctx context.Context, transfer domain.Transfer) (*domain.Transfer, error) {
+	if err := transfer.Validate(); err != nil {
+		return nil, apperrors.Invalid(err)
+	}
+
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var player *memoryPlayer
+	for _, p := range s.players {
+		if matchesKey(p.ID, p.Slug, transfer.Player) && p.DeletedAt == nil {
+			player = p
+		}
+	}
+	if player == nil {
+		return nil, apperrors.ErrPlayerNotFound
+	}
+	transfer.Player = player.Name
+
+	var current []domain.Contract
+	for _, contract := range r.contracts(player.ID) {
+		if contract.EndDate == nil {
+			current = append(current, contract)
+		}
+	}
+	ended, signed, err := planTransfer(&transfer, s.teamName(player.teamID), current)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if the new team exists
+	toTeam := s.activeTeamByName(transfer.ToTeam)
+	if toTeam == nil {
+		return nil, apperrors.ErrTeamNotFound
+	}
+
+	// Check the transfer falls within a transfer window of a season the transfer date belongs to. Loans end
+	// on the agreed terms, whenever that is
+	if transfer.Type != domain.TransferLoanReturn && !r.windowOpen(transfer.TransferDate) {
+		return nil, apperrors.ErrInvalidInput.WithMessage("transfer date is outside every transfer window")
+	}
+
+	// Check if jersey number is already taken by another player in the new team
+	if s.jerseyTaken(toTeam.ID, transfer.JerseyNumber, player.ID) {
+		return nil, apperrors.ErrJerseyNumberTaken
+	}
+
+	now := time.Now()
+	fromID := player.teamID
+	if fromTeam := s.teamByName(transfer.FromTeam); fromTeam != nil {
+		fromID = fromTeam.ID
+	}
+	player.teamID, player.JerseyNumber, player.UpdatedAt = toTeam.ID, transfer.JerseyNumber, now
+
+	transfer.ID = s.nextID("transfers")
+	transfer.CreatedAt = now
+	s.transfers = append(s.transfers, &memoryTransfer{Transfer: transfer, playerID: player.ID, fromID: fromID, toID: toTeam.ID})
+
+	// End the contracts the player leaves and sign the new one
+	for _, contract := range ended {
+		for _, stored := range s.contracts {
+			if stored.ID == contract.ID {
+				endDate := transfer.TransferDate
+				stored.EndDate, stored.UpdatedAt = &endDate, now
+			}
+		}
+	}
+	if signed != nil {
+		transferID := transfer.ID
+		s.contracts = append(s.contracts, &memoryContract{
+			Contract: domain.Contract{
+				ID:          s.nextID("contracts"),
+				Type:        signed.Type,
+				StartDate:   signed.StartDate,
+				LoanEndDate: signed.LoanEndDate,
+				TransferID:  &transferID,
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			},
+			playerID: player.ID,
+			teamID:   toTeam.ID,
+		})
+	}
+
+	return &transfer, nil
+}
+
+func (r *MemoryTransferRepo) List(ctx context.Context) ([]domain.Transfer, error) {
+	return r.list(func(*memoryTransfer) bool { return true }), nil
+}
+
+// ListByTeam returns the transfers the team with the given ID or slug sold or bought a player in, most recent first
+func (r *MemoryTransferRepo) ListByTeam(ctx context.Context, teamKey string) ([]domain.Transfer, error) {
+	return r.list(func(t *memoryTransfer) bool {
+		from, to := r.store.teamByID(t.fromID), r.store.teamByID(t.toID)
+		return matchesKey(from.ID, from.Slug, teamKey) || matchesKey(to.ID, to.Slug, teamKey)
+	}), nil
+}
+
+func (r *MemoryTransferRepo) GetByID(ctx context.Context, id int) (*domain.Transfer, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, t := range s.transfers {
+		if t.ID == id {
+			transfer := r.transfer(t)
+			return &transfer, nil
+		}
+	}
+	return nil, apperrors.ErrTransferNotFound
+}
+
+// ListContracts returns every contract of a player, looked up by ID or slug, oldest first
+func (r *MemoryTransferRepo) ListContracts(ctx context.Context, playerKey string) ([]domain.Contract, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, p := range s.players {
+		if matchesKey(p.ID, p.Slug, playerKey) && p.DeletedAt == nil {
+			return r.contracts(p.ID), nil
+		}
+	}
+	return nil, apperrors.ErrPlayerNotFound
+}
+
+// Helper method returning a copy of a stored transfer with its player and team names filled in
+func (r *MemoryTransferRepo) transfer(t *memoryTransfer) domain.Transfer {
+	transfer := t.Transfer
+	transfer.Player = r.store.playerName(t.playerID)
+	transfer.FromTeam, transfer.ToTeam = r.store.teamName(t.fromID), r.store.teamName(t.toID)
+	return transfer
+}
+
+// Helper method listing the transfers passing include, most recent first
+func (r *MemoryTransferRepo) list(include func(*memoryTransfer) bool) []domain.Transfer {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var transfers []domain.Transfer
+	for _, t := range s.transfers {
+		if include(t) {
+			transfers = append(transfers, r.transfer(t))
+		}
+	}
+	sort.SliceStable(transfers, func(i, j int) bool {
+		if !transfers[i].TransferDate.Equal(transfers[j].TransferDate) {
+			return transfers[i].TransferDate.After(transfers[j].TransferDate)
+		}
+		return transfers[i].ID > transfers[j].ID
+	})
+	return transfers
+}
+
+// Helper method returning the contracts of a player with names filled in, oldest first
+func (r *MemoryTransferRepo) contracts(playerID int) []domain.Contract {
+	s := r.store
+	var contracts []domain.Contract
+	for _, c := range s.contracts {
+		if c.playerID == playerID {
+			contract := c.Contract
+			contract.Player, contract.Team = s.playerName(c.playerID), s.teamName(c.teamID)
+			contracts = append(contracts, contract)
+		}
+	}
+	sort.SliceStable(contracts, func(i, j int) bool {
+		if !contracts[i].StartDate.Equal(contracts[j].StartDate) {
+			return contracts[i].StartDate.Before(contracts[j].StartDate)
+		}
+		return contracts[i].ID < contracts[j].ID
+	})
+	return contracts
+}
+
+// Helper method reporting whether date falls within a window of an active season that contains date
+func (r *MemoryTransferRepo) windowOpen(date time.Time) bool {
+	for _, window := range r.store.windows {
+		if window.DeletedAt != nil || date.Before(window.StartDate) || date.After(window.EndDate) {
+			continue
+		}
+		if season := r.store.activeSeason(window.SeasonID); season != nil && season.Contains(date) {
+			return true
+		}
+	}
+	return false
+}
+
+type MemoryTransferWindowRepo struct {
+	store *MemoryStore
+}
+
+func NewMemoryTransferWindowRepo(store *MemoryStore) *MemoryTransferWindowRepo {
+	return &MemoryTransferWindowRepo{store: store}
+}
+
+func (r *MemoryTransferWindowRepo) Register(ctx context.Context, window domain.TransferWindow) (int, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Check if season exists
+	if s.activeSeason(window.SeasonID) == nil {
+		return 0, apperrors.ErrSeasonNotFound
+	}
+
+	// Check the window does not overlap another window of the season
+	for _, existing := range s.windows {
+		if existing.SeasonID == window.SeasonID && existing.DeletedAt == nil &&
+			!existing.StartDate.After(window.EndDate) && !existing.EndDate.Before(window.StartDate) {
+			return 0, apperrors.ErrTransferWindowOverlap
+		}
+	}
+
+	now := time.Now()
+	window.ID = s.nextID("transfer_windows")
+	window.CreatedAt, window.UpdatedAt, window.DeletedAt = now, now, nil
+	s.windows = append(s.windows, &window)
+	return window.ID, nil
+}
+
+func (r *MemoryTransferWindowRepo) Delete(ctx context.Context, id int) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, window := range s.windows {
+		if window.ID == id && window.DeletedAt == nil {
+			now := time.Now()
+			window.DeletedAt, window.UpdatedAt = &now, now
+			return nil
+		}
+	}
+	return apperrors.ErrTransferWindowNotFound
+}
+
+// List returns the windows of all seasons, or of one season if seasonID is given, in date order
+func (r *MemoryTransferWindowRepo) List(ctx context.Context, seasonID *int) ([]domain.TransferWindow, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var windows []domain.TransferWindow
+	for _, window := range s.windows {
+		if window.DeletedAt == nil && (seasonID == nil || window.SeasonID == *seasonID) {
+			windows = append(windows, *window)
+		}
+	}
+	sort.SliceStable(windows, func(i, j int) bool {
+		return windows[i].StartDate.Before(windows[j].StartDate)
+	})
+	return windows, nil
+}
@@ -0,0 +1,241 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"strings"
+	"time"
+)
+
+type MemoryTrashRepo struct {
+	store *MemoryStore
+}
+
+func NewMemoryTrashRepo(store *MemoryStore) *MemoryTrashRepo {
+	return &MemoryTrashRepo{store: store}
+}
+
+// memoryTrashSorts compares trash items by the sort fields of domain.TrashSortFields, see trashSortColumns
+var memoryTrashSorts = map[string]func(a, b domain.TrashItem) int{
+	"deleted_at": func(a, b domain.TrashItem) int {
+		return cmp.Or(a.DeletedAt.Compare(b.DeletedAt), strings.Compare(a.Type, b.Type))
+	},
+	"type": func(a, b domain.TrashItem) int { return strings.Compare(a.Type, b.Type) },
+	"name": func(a, b domain.TrashItem) int {
+		return cmp.Or(strings.Compare(a.Name, b.Name), strings.Compare(a.Type, b.Type))
+	},
+}
+
+func (r *MemoryTrashRepo) List(ctx context.Context, query domain.TrashQuery) (*domain.Page[domain.TrashItem], error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var items []domain.TrashItem
+	add := func(itemType string, id int, name string, deletedAt *time.Time) {
+		if deletedAt != nil && (query.Type == "" || query.Type == itemType) {
+			items = append(items, domain.TrashItem{Type: itemType, ID: id, Name: name, DeletedAt: *deletedAt})
+		}
+	}
+	for _, team := range s.teams {
+		add(domain.TrashTeam, team.ID, team.Name, team.DeletedAt)
+	}
+	for _, player := range s.players {
+		add(domain.TrashPlayer, player.ID, player.Name, player.DeletedAt)
+	}
+	for _, match := range s.matches {
+		add(domain.TrashMatch, match.ID, s.teamName(match.homeID)+" vs "+s.teamName(match.awayID), match.DeletedAt)
+	}
+	for _, result := range s.results {
+		if match := s.matchByID(result.MatchID); match != nil {
+			add(domain.TrashMatchResult, result.ID, s.teamName(match.homeID)+" vs "+s.teamName(match.awayID), result.DeletedAt)
+		}
+	}
+	page := query.PageRequest.Normalized(domain.DefaultTrashSort)
+	return memoryPage(items, page, memoryTrashSorts, func(item domain.TrashItem) int { return item.ID }), nil
+}
+
+// Purge removes a soft-deleted record and the rows that only exist for it, the way PostgresTrashRepo.Purge does
+func (r *MemoryTrashRepo) Purge(ctx context.Context, itemType string, id int) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch itemType {
+	case domain.TrashTeam:
+		return r.purgeTeam(id)
+	case domain.TrashPlayer:
+		return r.purgePlayer(id)
+	case domain.TrashMatch:
+		return r.purgeMatch(id)
+	case domain.TrashMatchResult:
+		return r.purgeResult(id)
+	}
+	return apperrors.ErrInvalidInput.WithMessage("invalid type " + itemType)
+}
+
+func (r *MemoryTrashRepo) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (*domain.PurgeReport, error) {
+	return purgeDeletedBefore(ctx, r, cutoff)
+}
+
+func (r *MemoryTrashRepo) deletedBefore(ctx context.Context, itemType string, cutoff time.Time) ([]int, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var ids []int
+	add := func(id int, deletedAt *time.Time) {
+		if deletedAt != nil && deletedAt.Before(cutoff) {
+			ids = append(ids, id)
+		}
+	}
+	switch itemType {
+	case domain.TrashTeam:
+		for _, team := range s.teams {
+			add(team.ID, team.DeletedAt)
+		}
+	case domain.TrashPlayer:
+		for _, player := range s.players {
+			add(player.ID, player.DeletedAt)
+		}
+	case domain.TrashMatch:
+		for _, match := range s.matches {
+			add(match.ID, match.DeletedAt)
+		}
+	case domain.TrashMatchResult:
+		for _, result := range s.results {
+			add(result.ID, result.DeletedAt)
+		}
+	}
+	return ids, nil
+}
+
+// Helper methods below expect the caller to hold the lock. Like the foreign keys of the Postgres schema,
+// they refuse to purge records other records still refer to
+
+func (r *MemoryTrashRepo) purgeTeam(id int) error {
+	s := r.store
+	team := s.teamByID(id)
+	if team == nil || team.DeletedAt == nil {
+		return notInTrash(domain.TrashTeam)
+	}
+
+	referred := false
+	for _, player := range s.players {
+		referred = referred || player.teamID == id
+	}
+	for _, match := range s.matches {
+		referred = referred || match.homeID == id || match.awayID == id
+	}
+	for _, tie := range s.ties {
+		referred = referred || isID(tie.homeID, id) || isID(tie.awayID, id) || isID(tie.winnerID, id)
+	}
+	for _, change := range s.ratings {
+		referred = referred || change.teamID == id || change.opponentID == id
+	}
+	for _, transfer := range s.transfers {
+		referred = referred || transfer.fromID == id || transfer.toID == id
+	}
+	for _, contract := range s.contracts {
+		referred = referred || contract.teamID == id
+	}
+	if referred {
+		return apperrors.ErrReferenceViolation
+	}
+
+	for username, teamIDs := range s.userTeams {
+		s.userTeams[username] = without(teamIDs, func(teamID int) bool { return teamID == id })
+	}
+	s.teams = without(s.teams, func(t *domain.Team) bool { return t.ID == id })
+	return nil
+}
+
+func (r *MemoryTrashRepo) purgePlayer(id int) error {
+	s := r.store
+	var player *memoryPlayer
+	for _, p := range s.players {
+		if p.ID == id {
+			player = p
+		}
+	}
+	if player == nil || player.DeletedAt == nil {
+		return notInTrash(domain.TrashPlayer)
+	}
+
+	s.contracts = without(s.contracts, func(c *memoryContract) bool { return c.playerID == id })
+	s.transfers = without(s.transfers, func(t *memoryTransfer) bool { return t.playerID == id })
+	s.players = without(s.players, func(p *memoryPlayer) bool { return p.ID == id })
+	return nil
+}
+
+func (r *MemoryTrashRepo) purgeMatch(id int) error {
+	s := r.store
+	match := s.matchByID(id)
+	if match == nil || match.DeletedAt == nil {
+		return notInTrash(domain.TrashMatch)
+	}
+	referred := s.activeResult(id) != nil
+	for _, tie := range s.ties {
+		referred = referred || isID(tie.MatchID, id)
+	}
+	for _, change := range s.ratings {
+		referred = referred || change.MatchID == id
+	}
+	if referred {
+		return apperrors.ErrReferenceViolation
+	}
+
+	s.events = without(s.events, func(e *memoryEvent) bool { return e.MatchID == id })
+	s.kicks = without(s.kicks, func(k *domain.ShootoutKick) bool { return k.MatchID == id })
+	s.results = without(s.results, func(result *domain.MatchResult) bool { return result.MatchID == id })
+	s.matches = without(s.matches, func(m *memoryMatch) bool { return m.ID == id })
+	return nil
+}
+
+func (r *MemoryTrashRepo) purgeResult(id int) error {
+	s := r.store
+	var result *domain.MatchResult
+	for _, stored := range s.results {
+		if stored.ID == id {
+			result = stored
+		}
+	}
+	if result == nil || result.DeletedAt == nil {
+		return notInTrash(domain.TrashMatchResult)
+	}
+
+	// The goals stay on the match timeline, the shootout kicks go unless another result uses them
+	if s.activeResult(result.MatchID) == nil {
+		s.kicks = without(s.kicks, func(k *domain.ShootoutKick) bool { return k.MatchID == result.MatchID })
+	}
+	s.results = without(s.results, func(stored *domain.MatchResult) bool { return stored.ID == id })
+	return nil
+}
+
+// matchByID returns the match with the given ID, deleted or not
+func (s *MemoryStore) matchByID(id int) *memoryMatch {
+	for _, match := range s.matches {
+		if match.ID == id {
+			return match
+		}
+	}
+	return nil
+}
+
+// isID reports whether the optional reference ref points at id
+func isID(ref *int, id int) bool {
+	return ref != nil && *ref == id
+}
+
+// without returns items without those for which remove is true, reusing the backing array
+func without[T any](items []T, remove func(T) bool) []T {
+	kept := items[:0]
+	for _, item := range items {
+		if !remove(item) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,275 @@
+package usecases
+
+import (
+	"context"
+	"football-team-management/internal/domain/user"
+	apperrors "football-team-management/internal/pkg/errors"
+	"sort"
+	"time"
+)
+
+type MemoryUserRepo struct {
+	store *MemoryStore
+}
+
+func NewMemoryUserRepo(store *MemoryStore) *MemoryUserRepo {
+	return &MemoryUserRepo{store: store}
+}
+
+func (r *MemoryUserRepo) Register(ctx context.Context, u user.User) error {
+	if !user.ValidRole(u.Role) {
+		return apperrors.ErrInvalidInput.WithMessage("invalid role")
+	}
+	if err := validateUserTeams(u.Role, u.Teams); err != nil {
+		return err
+	}
+
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Check if username is already taken
+	if _, ok := s.users[u.Username]; ok {
+		return apperrors.ErrUserAlreadyExists
+	}
+
+	teamIDs, err := r.teamIDs(u.Teams)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	s.users[u.Username] = &user.User{
+		Username:  u.Username,
+		Password:  u.Password,
+		Role:      u.Role,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	s.userTeams[u.Username] = teamIDs
+	return nil
+}
+
+func (r *MemoryUserRepo) List(ctx context.Context) ([]user.User, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var users []user.User
+	for _, u := range s.users {
+		if u.DeletedAt == nil {
+			users = append(users, r.user(u))
+		}
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].Username < users[j].Username
+	})
+	return users, nil
+}
+
+func (r *MemoryUserRepo) GetByUsername(ctx context.Context, username string) (*user.User, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	u, ok := s.users[username]
+	if !ok || u.DeletedAt != nil {
+		return nil, apperrors.ErrUserNotFound
+	}
+	found := r.user(u)
+	return &found, nil
+}
+
+func (r *MemoryUserRepo) SetTeams(ctx context.Context, username string, teams []string) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[username]
+	if !ok || u.DeletedAt != nil {
+		return apperrors.ErrUserNotFound
+	}
+	if err := validateUserTeams(u.Role, teams); err != nil {
+		return err
+	}
+
+	teamIDs, err := r.teamIDs(teams)
+	if err != nil {
+		return err
+	}
+	s.userTeams[username] = teamIDs
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+func (r *MemoryUserRepo) SetDisabled(ctx context.Context, username string, disabled bool) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[username]
+	if !ok || u.DeletedAt != nil {
+		return apperrors.ErrUserNotFound
+	}
+	u.Disabled, u.UpdatedAt = disabled, time.Now()
+	return nil
+}
+
+func (r *MemoryUserRepo) Delete(ctx context.Context, username string) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[username]
+	if !ok || u.DeletedAt != nil {
+		return apperrors.ErrUserNotFound
+	}
+	now := time.Now()
+	u.DeletedAt, u.UpdatedAt = &now, now
+	return nil
+}
+
+// Helper method resolving team names to IDs, checking every team exists
+func (r *MemoryUserRepo) teamIDs(teams []string) ([]int, error) {
+	var ids []int
+	seen := make(map[int]bool, len(teams))
+	for _, name := range teams {
+		team := r.store.activeTeamByName(name)
+		if team == nil {
+			return nil, apperrors.ErrTeamNotFound.WithMessage("team not found: " + name)
+		}
+		if !seen[team.ID] {
+			seen[team.ID] = true
+			ids = append(ids, team.ID)
+		}
+	}
+	return ids, nil
+}
+
+// Helper method returning a copy of a stored user with the names of their teams in name order
+func (r *MemoryUserRepo) user(u *user.User) user.User {
+	found := *u
+	found.Teams = nil
+	for _, id := range r.store.userTeams[u.Username] {
+		found.Teams = append(found.Teams, r.store.teamName(id))
+	}
+	sort.Strings(found.Teams)
+	return found
+}
+
+type MemorySessionRepo struct {
+	store *MemoryStore
+}
+
+func NewMemorySessionRepo(store *MemoryStore) *MemorySessionRepo {
+	return &MemorySessionRepo{store: store}
+}
+
+func (r *MemorySessionRepo) Create(ctx context.Context, session user.Session, refreshTokenHash string, expiresAt time.Time) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.sessions[session.ID]; ok {
+		return apperrors.ErrDuplicate.WithMessage("session already exists")
+	}
+	if _, ok := s.refreshTokens[refreshTokenHash]; ok {
+		return apperrors.ErrDuplicate.WithMessage("refresh token already exists")
+	}
+
+	now := time.Now()
+	s.sessions[session.ID] = &user.Session{ID: session.ID, Username: session.Username, CreatedAt: now}
+	s.refreshTokens[refreshTokenHash] = &memoryRefreshToken{sessionID: session.ID, expiresAt: expiresAt}
+	return nil
+}
+
+// Rotate marks the presented refresh token as used and stores its replacement in the same session.
+// Presenting a token that was already used revokes the whole session, since it means the token leaked
+func (r *MemorySessionRepo) Rotate(ctx context.Context, oldTokenHash, newTokenHash string, expiresAt time.Time) (*user.Session, error) {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, ok := s.refreshTokens[oldTokenHash]
+	if !ok {
+		return nil, errInvalidRefreshToken
+	}
+	session := s.sessions[token.sessionID]
+
+	now := time.Now()
+	if session.RevokedAt != nil || now.After(token.expiresAt) {
+		return nil, errInvalidRefreshToken
+	}
+	if token.usedAt != nil {
+		session.RevokedAt = &now
+		return nil, errRefreshTokenReused
+	}
+	if _, ok := s.refreshTokens[newTokenHash]; ok {
+		return nil, apperrors.ErrDuplicate.WithMessage("refresh token already exists")
+	}
+
+	token.usedAt = &now
+	s.refreshTokens[newTokenHash] = &memoryRefreshToken{sessionID: session.ID, expiresAt: expiresAt}
+	found := *session
+	return &found, nil
+}
+
+func (r *MemorySessionRepo) Revoke(ctx context.Context, sessionID string) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[sessionID]
+	if !ok || session.RevokedAt != nil {
+		return apperrors.ErrSessionNotFound
+	}
+	now := time.Now()
+	session.RevokedAt = &now
+	return nil
+}
+
+func (r *MemorySessionRepo) RevokeAllForUser(ctx context.Context, username string) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for _, session := range s.sessions {
+		if session.Username == username && session.RevokedAt == nil {
+			session.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (r *MemorySessionRepo) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	s := r.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Revoked tokens only need to be remembered until they would have expired anyway
+	now := time.Now()
+	for revoked, revokedExpiresAt := range s.revokedTokens {
+		if revokedExpiresAt.Before(now) {
+			delete(s.revokedTokens, revoked)
+		}
+	}
+
+	if _, ok := s.revokedTokens[jti]; !ok {
+		s.revokedTokens[jti] = expiresAt
+	}
+	return nil
+}
+
+func (r *MemorySessionRepo) IsActive(ctx context.Context, sessionID, jti string) (bool, error) {
+	s := r.store
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	session, ok := s.sessions[sessionID]
+	if !ok || session.RevokedAt != nil {
+		return false, nil
+	}
+	_, revoked := s.revokedTokens[jti]
+	return !revoked, nil
+}
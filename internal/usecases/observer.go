@@ -0,0 +1,176 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+)
+
+// MatchResultObserver is notified after the result of a match was registered, updated, deleted or restored,
+// or after the match changed status, was deleted or restored, since a result only counts once its match
+// is finished and only as long as the match is not deleted.
+// Observers read the current state themselves, so they cope with any kind of change the same way
+type MatchResultObserver interface {
+	ResultChanged(ctx context.Context, matchID int) error
+}
+
+// ObservedMatchResultRepo wraps a MatchResultRepository and notifies observers after every successful change
+type ObservedMatchResultRepo struct {
+	MatchResultRepository
+	observers []MatchResultObserver
+}
+
+func NewObservedMatchResultRepo(repo MatchResultRepository, observers ...MatchResultObserver) *ObservedMatchResultRepo {
+	return &ObservedMatchResultRepo{MatchResultRepository: repo, observers: observers}
+}
+
+func (r *ObservedMatchResultRepo) Register(ctx context.Context, result domain.MatchResult) error {
+	if err := r.MatchResultRepository.Register(ctx, result); err != nil {
+		return err
+	}
+	return r.notify(ctx, result.MatchID)
+}
+
+func (r *ObservedMatchResultRepo) Update(ctx context.Context, id int, result domain.MatchResult) error {
+	if err := r.MatchResultRepository.Update(ctx, id, result); err != nil {
+		return err
+	}
+	return r.notify(ctx, result.MatchID)
+}
+
+func (r *ObservedMatchResultRepo) Delete(ctx context.Context, id int) error {
+	existing, err := r.MatchResultRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.MatchResultRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	return r.notify(ctx, existing.MatchID)
+}
+
+func (r *ObservedMatchResultRepo) Restore(ctx context.Context, id int) error {
+	if err := r.MatchResultRepository.Restore(ctx, id); err != nil {
+		return err
+	}
+	restored, err := r.MatchResultRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return r.notify(ctx, restored.MatchID)
+}
+
+func (r *ObservedMatchResultRepo) notify(ctx context.Context, matchID int) error {
+	return notifyResultChanged(ctx, r.observers, matchID, "match result saved")
+}
+
+// ObservedMatchRepo wraps a MatchRepository and notifies observers after every status change, after a played
+// match is moved, and after a match is deleted or restored since its result stops or starts counting with it
+type ObservedMatchRepo struct {
+	MatchRepository
+	observers []MatchResultObserver
+}
+
+func NewObservedMatchRepo(repo MatchRepository, observers ...MatchResultObserver) *ObservedMatchRepo {
+	return &ObservedMatchRepo{MatchRepository: repo, observers: observers}
+}
+
+func (r *ObservedMatchRepo) UpdateStatus(ctx context.Context, id int, status domain.MatchStatus) (*domain.Match, error) {
+	match, err := r.MatchRepository.UpdateStatus(ctx, id, status)
+	if err != nil {
+		return nil, err
+	}
+	return match, notifyResultChanged(ctx, r.observers, id, "match status saved")
+}
+
+// Update notifies the observers when the match may have a result, since a new date or new teams change
+// what the result counts for
+func (r *ObservedMatchRepo) Update(ctx context.Context, id int, match domain.Match) error {
+	existing, err := r.MatchRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.MatchRepository.Update(ctx, id, match); err != nil {
+		return err
+	}
+	if !existing.Status.AcceptsResult() {
+		return nil
+	}
+	return notifyResultChanged(ctx, r.observers, id, "match saved")
+}
+
+func (r *ObservedMatchRepo) Delete(ctx context.Context, id int) error {
+	if err := r.MatchRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	return notifyResultChanged(ctx, r.observers, id, "match deleted")
+}
+
+func (r *ObservedMatchRepo) Restore(ctx context.Context, id int) error {
+	if err := r.MatchRepository.Restore(ctx, id); err != nil {
+		return err
+	}
+	return notifyResultChanged(ctx, r.observers, id, "match restored")
+}
+
+// ObservedMatchEventRepo wraps a MatchEventRepository and notifies observers after goal events change the score
+type ObservedMatchEventRepo struct {
+	MatchEventRepository
+	observers []MatchResultObserver
+}
+
+func NewObservedMatchEventRepo(repo MatchEventRepository, observers ...MatchResultObserver) *ObservedMatchEventRepo {
+	return &ObservedMatchEventRepo{MatchEventRepository: repo, observers: observers}
+}
+
+func (r *ObservedMatchEventRepo) Register(ctx context.Context, event domain.MatchEvent) (int, error) {
+	id, err := r.MatchEventRepository.Register(ctx, event)
+	if err != nil {
+		return 0, err
+	}
+	if event.Type != domain.EventGoal {
+		return id, nil
+	}
+	return id, notifyResultChanged(ctx, r.observers, event.MatchID, "match event saved")
+}
+
+func (r *ObservedMatchEventRepo) Delete(ctx context.Context, id int) error {
+	existing, err := r.MatchEventRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := r.MatchEventRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	if existing.Type != domain.EventGoal {
+		return nil
+	}
+	return notifyResultChanged(ctx, r.observers, existing.MatchID, "match event deleted")
+}
+
+// notifyResultChanged tells every observer about the change, even when an earlier one failed. The change
+// is already saved by then, so failures are reported as DEPENDENT_UPDATE_FAILED with the messages of
+// those that are meant for clients as details, never as a problem with the request
+func notifyResultChanged(ctx context.Context, observers []MatchResultObserver, matchID int, saved string) error {
+	var errs []error
+	var reasons []string
+	for _, observer := range observers {
+		if err := observer.ResultChanged(ctx, matchID); err != nil {
+			errs = append(errs, err)
+			var appErr *apperrors.AppError
+			if errors.As(err, &appErr) {
+				reasons = append(reasons, appErr.Message)
+			}
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+
+	failed := apperrors.ErrDependentUpdateFailed.WithMessage(saved + ", but updating dependent data failed")
+	if len(reasons) > 0 {
+		failed = failed.WithDetails(reasons)
+	}
+	return failed.Wrap(errors.Join(errs...))
+}
@@ -0,0 +1,113 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingObserver remembers the matches it was told about and answers with err
+type recordingObserver struct {
+	matchIDs []int
+	err      error
+}
+
+func (o *recordingObserver) ResultChanged(ctx context.Context, matchID int) error {
+	o.matchIDs = append(o.matchIDs, matchID)
+	return o.err
+}
+
+func TestObservedMatchResultRepo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Observer failures after the change are not reported as invalid input", func(t *testing.T) {
+		repos, matchID := newMemoryFixture(t)
+		bracket := &recordingObserver{err: apperrors.ErrInvalidInput.WithMessage("the next round match is already live")}
+		ratings := &recordingObserver{err: errors.New("connection refused")}
+		repo := NewObservedMatchResultRepo(repos.MatchResults, bracket, ratings)
+
+		err := repo.Register(ctx, domain.MatchResult{MatchID: matchID, HomeScore: 1, Goals: []domain.Goal{{Scorer: "Riko", GoalTime: "10:00", Team: "home"}}})
+		assert.EqualError(t, err, "match result saved, but updating dependent data failed")
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, "DEPENDENT_UPDATE_FAILED", appErr.Code)
+		assert.Equal(t, http.StatusInternalServerError, appErr.HTTPStatus)
+		assert.Equal(t, []string{"the next round match is already live"}, appErr.Details)
+
+		// Every observer heard of the change, and the change itself was kept
+		assert.Equal(t, []int{matchID}, bracket.matchIDs)
+		assert.Equal(t, []int{matchID}, ratings.matchIDs)
+		result, err := repos.MatchResults.GetByMatchID(ctx, matchID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.HomeScore)
+	})
+
+	t.Run("Nothing is reported when every observer succeeds", func(t *testing.T) {
+		repos, matchID := newMemoryFixture(t)
+		observer := &recordingObserver{}
+		repo := NewObservedMatchResultRepo(repos.MatchResults, observer)
+
+		require.NoError(t, repo.Register(ctx, domain.MatchResult{MatchID: matchID}))
+		assert.Equal(t, []int{matchID}, observer.matchIDs)
+	})
+}
+
+func TestObservedMatchRepo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Deleting and restoring a match notifies the observers", func(t *testing.T) {
+		repos, matchID := newMemoryFixture(t)
+		observer := &recordingObserver{}
+		repo := NewObservedMatchRepo(repos.Matches, observer)
+
+		require.NoError(t, repo.Delete(ctx, matchID))
+		require.NoError(t, repo.Restore(ctx, matchID))
+		assert.Equal(t, []int{matchID, matchID}, observer.matchIDs)
+
+		// Nothing changed, nobody is told
+		assert.ErrorIs(t, repo.Restore(ctx, matchID), apperrors.ErrMatchNotFound)
+		assert.Len(t, observer.matchIDs, 2)
+	})
+
+	t.Run("Moving a played match notifies the observers", func(t *testing.T) {
+		repos, matchID := newMemoryFixture(t)
+		observer := &recordingObserver{}
+		repo := NewObservedMatchRepo(repos.Matches, observer)
+		match, err := repos.Matches.GetByID(ctx, matchID)
+		require.NoError(t, err)
+
+		match.MatchDate = match.MatchDate.AddDate(0, 0, 1)
+		require.NoError(t, repo.Update(ctx, matchID, *match))
+		assert.Equal(t, []int{matchID}, observer.matchIDs)
+
+		// A match that has not kicked off has no result to count
+		scheduled, err := repos.Matches.Register(ctx, domain.Match{MatchDate: match.MatchDate, MatchTime: "15:00", HomeTeam: "Persib", AwayTeam: "Persija"})
+		require.NoError(t, err)
+		require.NoError(t, repo.Update(ctx, scheduled, domain.Match{MatchDate: match.MatchDate, MatchTime: "16:00", HomeTeam: "Persib", AwayTeam: "Persija"}))
+		assert.Equal(t, []int{matchID}, observer.matchIDs)
+	})
+
+	t.Run("Ratings follow a moved match", func(t *testing.T) {
+		repos, matchID := newMemoryFixture(t)
+		ratings := NewRatingService(repos.Ratings, repos.Teams, repos.Matches, repos.MatchResults, DefaultRatingConfig())
+		results := NewObservedMatchResultRepo(repos.MatchResults, ratings)
+		matches := NewObservedMatchRepo(repos.Matches, ratings)
+		require.NoError(t, results.Register(ctx, domain.MatchResult{MatchID: matchID, HomeScore: 1, Goals: []domain.Goal{{Scorer: "Riko", GoalTime: "10:00", Team: "home"}}}))
+
+		match, err := repos.Matches.GetByID(ctx, matchID)
+		require.NoError(t, err)
+		match.MatchDate = match.MatchDate.AddDate(0, 0, 2)
+		require.NoError(t, matches.Update(ctx, matchID, *match))
+
+		changes, err := repos.Ratings.ListChanges(ctx)
+		require.NoError(t, err)
+		require.Len(t, changes, 2)
+		assert.Equal(t, "2024-08-12", changes[0].MatchDate.Format("2006-01-02"))
+	})
+}
@@ -4,21 +4,26 @@ import (
 	"context"
 	"errors"
 	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type PlayerRepository interface {
-	Register(ctx context.Context, player domain.Player) error
-	Update(ctx context.Context, name string, player domain.Player) error
-	Delete(ctx context.Context, name string) error
+	Register(ctx context.Context, player domain.Player) (int, error)
+	Update(ctx context.Context, key string, player domain.Player) error
+	Delete(ctx context.Context, key string) error
 	List(ctx context.Context) ([]domain.Player, error)
-	ListByTeam(ctx context.Context, teamName string) ([]domain.Player, error)
-	GetByName(ctx context.Context, name string) (*domain.Player, error)
-	Restore(ctx context.Context, name string) error
+	ListByTeam(ctx context.Context, teamKey string) ([]domain.Player, error)
+	Search(ctx context.Context, query domain.PlayerQuery) (*domain.Page[domain.Player], error)
+	GetByKey(ctx context.Context, key string) (*domain.Player, error)
+	Restore(ctx context.Context, key string) error
 }
 
+// Players are looked up by a key holding either their ID or their slug, see keyID
+
 type PostgresPlayerRepo struct {
 	pool *pgxpool.Pool
 }
@@ -27,133 +32,206 @@ func NewPostgresPlayerRepo(pool *pgxpool.Pool) *PostgresPlayerRepo {
 	return &PostgresPlayerRepo{pool: pool}
 }
 
-func (r *PostgresPlayerRepo) Register(ctx context.Context, player domain.Player) error {
+func (r *PostgresPlayerRepo) Register(ctx context.Context, player domain.Player) (int, error) {
 	// Check if team exists
-	var teamExists bool
-	err := r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM teams WHERE name = $1 AND deleted_at IS NULL)`, player.TeamName).Scan(&teamExists)
-	if err != nil {
-		return err
+	var teamID int
+	err := r.pool.QueryRow(ctx, `SELECT id FROM teams WHERE name = $1 AND deleted_at IS NULL`, player.TeamName).Scan(&teamID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, apperrors.ErrTeamNotFound
 	}
-	if !teamExists {
-		return errors.New("team not found")
+	if err != nil {
+		return 0, err
 	}
 
 	// Check if jersey number is already taken in the team
 	var jerseyExists bool
-	err = r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM players WHERE team_name = $1 AND jersey_number = $2 AND deleted_at IS NULL)`,
-		player.TeamName, player.JerseyNumber).Scan(&jerseyExists)
+	err = r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM players WHERE team_id = $1 AND jersey_number = $2 AND deleted_at IS NULL)`,
+		teamID, player.JerseyNumber).Scan(&jerseyExists)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	if jerseyExists {
-		return errors.New("jersey number already taken in this team")
+		return 0, apperrors.ErrJerseyNumberTaken
 	}
 
 	// Check if player already exists
 	var playerExists bool
-	err = r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM players WHERE name = $1 AND deleted_at IS NULL)`, player.Name).Scan(&playerExists)
+	err = r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM players WHERE name = $1)`, player.Name).Scan(&playerExists)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	if playerExists {
-		return errors.New("player already exists")
+		return 0, apperrors.ErrPlayerAlreadyExists
+	}
+
+	// Start transaction
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	slug, err := uniqueSlug(ctx, tx, "players", domain.Slugify(player.Name, "player"))
+	if err != nil {
+		return 0, err
 	}
 
 	now := time.Now()
-	_, err = r.pool.Exec(ctx, `INSERT INTO players (name, height, weight, position, jersey_number, team_name, created_at, updated_at, deleted_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULL)`,
-		player.Name, player.Height, player.Weight, player.Position, player.JerseyNumber, player.TeamName, now, now)
-	return err
+	var id int
+	err = tx.QueryRow(ctx, `INSERT INTO players (slug, name, height, weight, position, jersey_number, team_id, created_at, updated_at, deleted_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULL) RETURNING id`,
+		slug, player.Name, player.Height, player.Weight, player.Position, player.JerseyNumber, teamID, now, now).Scan(&id)
+	if err != nil {
+		return 0, dbError(err)
+	}
+
+	// The player starts on a permanent contract with the team they are registered with
+	_, err = tx.Exec(ctx, `INSERT INTO contracts (player_id, team_id, type, start_date, end_date, loan_end_date, transfer_id, created_at, updated_at) VALUES ($1, $2, 'permanent', $3, NULL, NULL, NULL, $4, $5)`,
+		id, teamID, player.FirstContractStart(now), now, now)
+	if err != nil {
+		return 0, dbError(err)
+	}
+
+	return id, tx.Commit(ctx)
 }
 
-func (r *PostgresPlayerRepo) Update(ctx context.Context, name string, player domain.Player) error {
-	// Check if team exists
-	var teamExists bool
-	err := r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM teams WHERE name = $1 AND deleted_at IS NULL)`, player.TeamName).Scan(&teamExists)
+// Update changes the player's details. A new name is also written onto the match sheets the player
+// appears on, in the same transaction, since match events and shootout kicks record players by name
+func (r *PostgresPlayerRepo) Update(ctx context.Context, key string, player domain.Player) error {
+	// Check the player stays with their team, moving to another team takes a transfer
+	var id, teamID int
+	var currentName, currentTeam string
+	err := r.pool.QueryRow(ctx, `SELECT p.id, p.team_id, p.name, t.name FROM players p JOIN teams t ON t.id = p.team_id WHERE (p.id = $1 OR p.slug = $2) AND p.deleted_at IS NULL`,
+		keyID(key), key).Scan(&id, &teamID, &currentName, &currentTeam)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apperrors.ErrPlayerNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if player.TeamName != currentTeam {
+		return apperrors.ErrInvalidInput.WithMessage("player team cannot be changed here. Use a transfer to move the player to another team")
+	}
+
+	// Check if the name is taken by another player
+	var nameTaken bool
+	err = r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM players WHERE name = $1 AND id != $2)`, player.Name, id).Scan(&nameTaken)
 	if err != nil {
 		return err
 	}
-	if !teamExists {
-		return errors.New("team not found")
+	if nameTaken {
+		return apperrors.ErrPlayerNameTaken
 	}
 
 	// Check if jersey number is already taken by another player in the team
 	var jerseyExists bool
-	err = r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM players WHERE team_name = $1 AND jersey_number = $2 AND name != $3 AND deleted_at IS NULL)`,
-		player.TeamName, player.JerseyNumber, name).Scan(&jerseyExists)
+	err = r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM players WHERE team_id = $1 AND jersey_number = $2 AND id != $3 AND deleted_at IS NULL)`,
+		teamID, player.JerseyNumber, id).Scan(&jerseyExists)
 	if err != nil {
 		return err
 	}
 	if jerseyExists {
-		return errors.New("jersey number already taken in this team")
+		return apperrors.ErrJerseyNumberTaken
 	}
 
-	now := time.Now()
-	cmd, err := r.pool.Exec(ctx, `UPDATE players SET name=$1, height=$2, weight=$3, position=$4, jersey_number=$5, team_name=$6, updated_at=$7 WHERE name=$8 AND deleted_at IS NULL`,
-		player.Name, player.Height, player.Weight, player.Position, player.JerseyNumber, player.TeamName, now, name)
+	// Start transaction
+	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback(ctx)
+
+	now := time.Now()
+	cmd, err := tx.Exec(ctx, `UPDATE players SET name=$1, height=$2, weight=$3, position=$4, jersey_number=$5, updated_at=$6 WHERE id=$7 AND deleted_at IS NULL`,
+		player.Name, player.Height, player.Weight, player.Position, player.JerseyNumber, now, id)
+	if err != nil {
+		return dbError(err)
+	}
 	if cmd.RowsAffected() == 0 {
-		return errors.New("player not found")
+		return apperrors.ErrPlayerNotFound
 	}
-	return nil
+
+	if player.Name != currentName {
+		for _, statement := range renamePlayerStatements {
+			if _, err := tx.Exec(ctx, statement, player.Name, currentName); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit(ctx)
 }
 
-func (r *PostgresPlayerRepo) Delete(ctx context.Context, name string) error {
+// renamePlayerStatements rewrite the columns recording a player by name from the old name $2 to the new
+// name $1. Player names are unique, so the old name belongs to nobody else
+var renamePlayerStatements = []string{
+	`UPDATE match_events SET player = $1 WHERE player = $2`,
+	`UPDATE match_events SET assist = $1 WHERE assist = $2`,
+	`UPDATE match_events SET player_in = $1 WHERE player_in = $2`,
+	`UPDATE shootout_kicks SET taker = $1 WHERE taker = $2`,
+}
+
+func (r *PostgresPlayerRepo) Delete(ctx context.Context, key string) error {
 	now := time.Now()
-	cmd, err := r.pool.Exec(ctx, `UPDATE players SET deleted_at=$1, updated_at=$2 WHERE name=$3 AND deleted_at IS NULL`, now, now, name)
+	cmd, err := r.pool.Exec(ctx, `UPDATE players SET deleted_at=$1, updated_at=$2 WHERE (id = $3 OR slug = $4) AND deleted_at IS NULL`, now, now, keyID(key), key)
 	if err != nil {
 		return err
 	}
 	if cmd.RowsAffected() == 0 {
-		return errors.New("player not found")
+		return apperrors.ErrPlayerNotFound
 	}
 	return nil
 }
 
+const playerFrom = ` FROM players p JOIN teams t ON t.id = p.team_id`
+
+const playerSelect = `SELECT p.id, p.slug, p.name, p.height, p.weight, p.position, p.jersey_number, t.name, p.created_at, p.updated_at, p.deleted_at` + playerFrom
+
+// playerSortColumns maps the sort fields of domain.PlayerSortFields to columns
+var playerSortColumns = map[string][]string{
+	"id":            {"p.id"},
+	"name":          {"p.name"},
+	"position":      {"p.position"},
+	"jersey_number": {"p.jersey_number"},
+	"height":        {"p.height"},
+	"weight":        {"p.weight"},
+	"team":          {"t.name"},
+	"created_at":    {"p.created_at"},
+}
+
 func (r *PostgresPlayerRepo) List(ctx context.Context) ([]domain.Player, error) {
-	rows, err := r.pool.Query(ctx, `SELECT name, height, weight, position, jersey_number, team_name, created_at, updated_at, deleted_at FROM players WHERE deleted_at IS NULL`)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var players []domain.Player
-	for rows.Next() {
-		var p domain.Player
-		var deletedAt *time.Time
-		if err := rows.Scan(&p.Name, &p.Height, &p.Weight, &p.Position, &p.JerseyNumber, &p.TeamName, &p.CreatedAt, &p.UpdatedAt, &deletedAt); err != nil {
-			return nil, err
-		}
-		p.DeletedAt = deletedAt
-		players = append(players, p)
-	}
-	return players, nil
+	return r.listPlayers(ctx, playerSelect+` WHERE p.deleted_at IS NULL ORDER BY p.id`)
 }
 
-func (r *PostgresPlayerRepo) ListByTeam(ctx context.Context, teamName string) ([]domain.Player, error) {
-	rows, err := r.pool.Query(ctx, `SELECT name, height, weight, position, jersey_number, team_name, created_at, updated_at, deleted_at FROM players WHERE team_name = $1 AND deleted_at IS NULL`, teamName)
-	if err != nil {
+// ListByTeam returns the players of the team with the given ID or slug
+func (r *PostgresPlayerRepo) ListByTeam(ctx context.Context, teamKey string) ([]domain.Player, error) {
+	return r.listPlayers(ctx, playerSelect+` WHERE (t.id = $1 OR t.slug = $2) AND p.deleted_at IS NULL ORDER BY p.id`, keyID(teamKey), teamKey)
+}
+
+// Search returns a page of the players, filtered, sorted and cut by the database
+func (r *PostgresPlayerRepo) Search(ctx context.Context, query domain.PlayerQuery) (*domain.Page[domain.Player], error) {
+	page := query.PageRequest.Normalized(domain.DefaultPlayerSort)
+	where := ` WHERE ` + deletedClause(query.Deleted, "p.deleted_at") + ` AND ($1::text IS NULL OR t.id = $2 OR t.slug = $1)` +
+		` AND ($3::text IS NULL OR p.position = $3) AND ($4::int IS NULL OR p.jersey_number = $4)`
+	args := []any{optional(query.Team), keyID(query.Team), optional(string(query.Position)), query.JerseyNumber}
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT count(*)`+playerFrom+where, args...).Scan(&total); err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-	var players []domain.Player
-	for rows.Next() {
-		var p domain.Player
-		var deletedAt *time.Time
-		if err := rows.Scan(&p.Name, &p.Height, &p.Weight, &p.Position, &p.JerseyNumber, &p.TeamName, &p.CreatedAt, &p.UpdatedAt, &deletedAt); err != nil {
-			return nil, err
-		}
-		p.DeletedAt = deletedAt
-		players = append(players, p)
+	players, err := r.listPlayers(ctx, playerSelect+where+orderBy(page, playerSortColumns, "p.id")+` LIMIT $5 OFFSET $6`, append(args, page.Limit, page.Offset)...)
+	if err != nil {
+		return nil, err
 	}
-	return players, nil
+	return domain.NewPage(players, total, page), nil
 }
 
-func (r *PostgresPlayerRepo) GetByName(ctx context.Context, name string) (*domain.Player, error) {
+func (r *PostgresPlayerRepo) GetByKey(ctx context.Context, key string) (*domain.Player, error) {
 	var p domain.Player
 	var deletedAt *time.Time
-	err := r.pool.QueryRow(ctx, `SELECT name, height, weight, position, jersey_number, team_name, created_at, updated_at, deleted_at FROM players WHERE name = $1 AND deleted_at IS NULL`, name).
-		Scan(&p.Name, &p.Height, &p.Weight, &p.Position, &p.JerseyNumber, &p.TeamName, &p.CreatedAt, &p.UpdatedAt, &deletedAt)
+	err := r.pool.QueryRow(ctx, playerSelect+` WHERE (p.id = $1 OR p.slug = $2) AND p.deleted_at IS NULL`, keyID(key), key).
+		Scan(&p.ID, &p.Slug, &p.Name, &p.Height, &p.Weight, &p.Position, &p.JerseyNumber, &p.TeamName, &p.CreatedAt, &p.UpdatedAt, &deletedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperrors.ErrPlayerNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -161,14 +239,33 @@ func (r *PostgresPlayerRepo) GetByName(ctx context.Context, name string) (*domai
 	return &p, nil
 }
 
-func (r *PostgresPlayerRepo) Restore(ctx context.Context, name string) error {
+func (r *PostgresPlayerRepo) Restore(ctx context.Context, key string) error {
 	now := time.Now()
-	cmd, err := r.pool.Exec(ctx, `UPDATE players SET deleted_at=NULL, updated_at=$1 WHERE name=$2 AND deleted_at IS NOT NULL`, now, name)
+	cmd, err := r.pool.Exec(ctx, `UPDATE players SET deleted_at=NULL, updated_at=$1 WHERE (id = $2 OR slug = $3) AND deleted_at IS NOT NULL`, now, keyID(key), key)
 	if err != nil {
 		return err
 	}
 	if cmd.RowsAffected() == 0 {
-		return errors.New("player not found or not deleted")
+		return apperrors.ErrPlayerNotFound.WithMessage("player not found or not deleted")
 	}
 	return nil
 }
+
+func (r *PostgresPlayerRepo) listPlayers(ctx context.Context, query string, args ...any) ([]domain.Player, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var players []domain.Player
+	for rows.Next() {
+		var p domain.Player
+		var deletedAt *time.Time
+		if err := rows.Scan(&p.ID, &p.Slug, &p.Name, &p.Height, &p.Weight, &p.Position, &p.JerseyNumber, &p.TeamName, &p.CreatedAt, &p.UpdatedAt, &deletedAt); err != nil {
+			return nil, err
+		}
+		p.DeletedAt = deletedAt
+		players = append(players, p)
+	}
+	return players, rows.Err()
+}
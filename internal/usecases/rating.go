@@ -0,0 +1,410 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RatingRepository interface {
+	List(ctx context.Context) ([]domain.TeamRating, error)
+	ListChanges(ctx context.Context) ([]domain.RatingChange, error)
+	History(ctx context.Context, team string) ([]domain.RatingChange, error)
+	ReplaceFrom(ctx context.Context, from *domain.Match, changes []domain.RatingChange) error
+}
+
+type PostgresRatingRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresRatingRepo(pool *pgxpool.Pool) *PostgresRatingRepo {
+	return &PostgresRatingRepo{pool: pool}
+}
+
+func (r *PostgresRatingRepo) List(ctx context.Context) ([]domain.TeamRating, error) {
+	rows, err := r.pool.Query(ctx, `SELECT t.name, r.rating, r.played, r.updated_at FROM team_ratings r JOIN teams t ON t.id = r.team_id ORDER BY r.rating DESC, t.name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ratings []domain.TeamRating
+	for rows.Next() {
+		var tr domain.TeamRating
+		var updatedAt time.Time
+		if err := rows.Scan(&tr.Team, &tr.Rating, &tr.Played, &updatedAt); err != nil {
+			return nil, err
+		}
+		tr.UpdatedAt = &updatedAt
+		ratings = append(ratings, tr)
+	}
+	return ratings, rows.Err()
+}
+
+// ListChanges returns the whole rating history in the order the matches were played
+func (r *PostgresRatingRepo) ListChanges(ctx context.Context) ([]domain.RatingChange, error) {
+	return r.listChanges(ctx, ratingChangeSelect+` ORDER BY h.match_date, h.match_time, h.match_id`)
+}
+
+// History returns the rating changes of one team in the order the matches were played
+func (r *PostgresRatingRepo) History(ctx context.Context, team string) ([]domain.RatingChange, error) {
+	return r.listChanges(ctx, ratingChangeSelect+` WHERE t.name = $1 ORDER BY h.match_date, h.match_time, h.match_id`, team)
+}
+
+// ReplaceFrom replaces the history of from and every later match with changes and refreshes the
+// current ratings. A nil from replaces the whole history
+func (r *PostgresRatingRepo) ReplaceFrom(ctx context.Context, from *domain.Match, changes []domain.RatingChange) error {
+	// Start transaction
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if from == nil {
+		_, err = tx.Exec(ctx, `DELETE FROM rating_history`)
+	} else {
+		var fromTime time.Time
+		fromTime, err = time.Parse("15:04", from.MatchTime)
+		if err != nil {
+			return apperrors.ErrInvalidInput.WithMessage("invalid time format. Use HH:MM")
+		}
+		_, err = tx.Exec(ctx, `DELETE FROM rating_history WHERE (match_date, match_time, match_id) >= ($1, $2, $3)`, from.MatchDate, fromTime, from.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, change := range changes {
+		matchTime, err := time.Parse("15:04", change.MatchTime)
+		if err != nil {
+			return apperrors.ErrInvalidInput.WithMessage("invalid time format. Use HH:MM")
+		}
+		_, err = tx.Exec(ctx, `INSERT INTO rating_history (match_id, match_date, match_time, team_id, opponent_id, venue, goals_for, goals_against, outcome, rating_before, rating_after, created_at) VALUES ($1, $2, $3, (SELECT id FROM teams WHERE name = $4), (SELECT id FROM teams WHERE name = $5), $6, $7, $8, $9, $10, $11, $12)`,
+			change.MatchID, change.MatchDate, matchTime, change.Team, change.Opponent, change.Venue, change.GoalsFor, change.GoalsAgainst, change.Outcome, change.Before, change.After, now)
+		if err != nil {
+			return dbError(err)
+		}
+	}
+
+	// The current rating of a team is the one after its latest rated match
+	if _, err := tx.Exec(ctx, `DELETE FROM team_ratings`); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO team_ratings (team_id, rating, played, updated_at)
+		SELECT DISTINCT ON (team_id) team_id, rating_after, COUNT(*) OVER (PARTITION BY team_id), created_at FROM rating_history ORDER BY team_id, match_date DESC, match_time DESC, match_id DESC`)
+	if err != nil {
+		return dbError(err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+const ratingChangeSelect = `SELECT h.id, h.match_id, h.match_date, h.match_time, t.name, o.name, h.venue, h.goals_for, h.goals_against, h.outcome, h.rating_before, h.rating_after, h.created_at` +
+	` FROM rating_history h JOIN teams t ON t.id = h.team_id JOIN teams o ON o.id = h.opponent_id`
+
+func (r *PostgresRatingRepo) listChanges(ctx context.Context, query string, args ...any) ([]domain.RatingChange, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var changes []domain.RatingChange
+	for rows.Next() {
+		var rc domain.RatingChange
+		var matchTime time.Time
+		if err := rows.Scan(&rc.ID, &rc.MatchID, &rc.MatchDate, &matchTime, &rc.Team, &rc.Opponent, &rc.Venue, &rc.GoalsFor, &rc.GoalsAgainst, &rc.Outcome, &rc.Before, &rc.After, &rc.CreatedAt); err != nil {
+			return nil, err
+		}
+		rc.MatchTime = matchTime.Format("15:04")
+		changes = append(changes, rc)
+	}
+	return changes, rows.Err()
+}
+
+// RatingConfig holds the parameters of the Elo calculation
+type RatingConfig struct {
+	InitialRating float64 // Rating of a team before its first match
+	KFactor       float64 // Largest change a single match can cause before the goal difference multiplier
+	HomeAdvantage float64 // Rating points added to the home side when computing the expected result
+}
+
+// DefaultRatingConfig starts every team at 1500 and gives the home side a 100 point head start
+func DefaultRatingConfig() RatingConfig {
+	return RatingConfig{
+		InitialRating: 1500,
+		KFactor:       20,
+		HomeAdvantage: 100,
+	}
+}
+
+type RatingService interface {
+	Ratings(ctx context.Context) ([]domain.TeamRating, error)
+	History(ctx context.Context, teamKey string) ([]domain.RatingChange, error)
+	Recalculate(ctx context.Context) error
+	MatchResultObserver
+}
+
+type ratingService struct {
+	repo       RatingRepository
+	teamRepo   TeamRepository
+	matchRepo  MatchRepository
+	resultRepo MatchResultRepository
+	config     RatingConfig
+
+	// Replays read the history and then rewrite it, so they must not interleave
+	mu sync.Mutex
+}
+
+func NewRatingService(repo RatingRepository, teamRepo TeamRepository, matchRepo MatchRepository, resultRepo MatchResultRepository, config RatingConfig) RatingService {
+	return &ratingService{repo: repo, teamRepo: teamRepo, matchRepo: matchRepo, resultRepo: resultRepo, config: config}
+}
+
+// Ratings lists every active team by rating, teams without rated matches at the initial rating
+func (s *ratingService) Ratings(ctx context.Context) ([]domain.TeamRating, error) {
+	teams, err := s.teamRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byTeam := make(map[string]domain.TeamRating, len(stored))
+	for _, rating := range stored {
+		byTeam[rating.Team] = rating
+	}
+
+	ratings := make([]domain.TeamRating, 0, len(teams))
+	for _, team := range teams {
+		rating, ok := byTeam[team.Name]
+		if !ok {
+			rating = domain.TeamRating{Team: team.Name, Rating: s.config.InitialRating}
+		}
+		ratings = append(ratings, rating)
+	}
+	sort.SliceStable(ratings, func(i, j int) bool {
+		if ratings[i].Rating != ratings[j].Rating {
+			return ratings[i].Rating > ratings[j].Rating
+		}
+		return ratings[i].Team < ratings[j].Team
+	})
+	for i := range ratings {
+		ratings[i].Rank = i + 1
+	}
+	return ratings, nil
+}
+
+// History returns the rating changes of the team with the given ID or slug
+func (s *ratingService) History(ctx context.Context, teamKey string) ([]domain.RatingChange, error) {
+	team, err := s.teamRepo.GetByKey(ctx, teamKey)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := s.repo.History(ctx, team.Name)
+	if err != nil {
+		return nil, err
+	}
+	if changes == nil {
+		changes = []domain.RatingChange{}
+	}
+	return changes, nil
+}
+
+// Recalculate rebuilds the whole rating history from the finished matches
+func (s *ratingService) Recalculate(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.replay(ctx, nil, nil)
+}
+
+// ResultChanged replays the ratings from the changed match onwards. If the match has been moved since
+// it was rated, the replay starts from whichever of its old and new dates comes first
+func (s *ratingService) ResultChanged(ctx context.Context, matchID int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var from *domain.Match
+	match, err := s.matchRepo.GetByID(ctx, matchID)
+	switch {
+	case err == nil:
+		if match.Status == domain.MatchFinished {
+			from = match
+		}
+	case !errors.Is(err, apperrors.ErrMatchNotFound):
+		return err
+	}
+
+	changes, err := s.repo.ListChanges(ctx)
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		if change.MatchID != matchID {
+			continue
+		}
+		rated := changedMatch(change)
+		if from == nil || rated.PlayedBefore(*from) {
+			from = &rated
+		}
+		break
+	}
+
+	// Neither rated before nor counting now, nothing changes
+	if from == nil {
+		return nil
+	}
+	return s.replay(ctx, changes, from)
+}
+
+// replay recomputes the ratings of from and every later match, starting from the ratings the
+// history holds just before from. A nil from replays every match
+func (s *ratingService) replay(ctx context.Context, changes []domain.RatingChange, from *domain.Match) error {
+	ratings := make(map[string]float64)
+	for _, change := range changes {
+		rated := changedMatch(change)
+		if from != nil && rated.PlayedBefore(*from) {
+			ratings[change.Team] = change.After
+		}
+	}
+
+	var filter domain.MatchFilter
+	if from != nil {
+		filter.From = &from.MatchDate
+	}
+	matches, err := s.matchRepo.List(ctx, filter)
+	if err != nil {
+		return err
+	}
+	var replayed []domain.Match
+	for _, match := range matches {
+		if from == nil || !match.PlayedBefore(*from) {
+			replayed = append(replayed, match)
+		}
+	}
+	results, err := s.resultRepo.ListByMatches(ctx, matchIDs(replayed))
+	if err != nil {
+		return err
+	}
+	return s.repo.ReplaceFrom(ctx, from, computeRatings(ratings, replayed, results, s.config))
+}
+
+// changedMatch returns the match a rating change was computed for, as far as its kick-off is concerned
+func changedMatch(change domain.RatingChange) domain.Match {
+	return domain.Match{ID: change.MatchID, MatchDate: change.MatchDate, MatchTime: change.MatchTime}
+}
+
+// computeRatings applies the finished matches in the order they were played to the given ratings,
+// and returns the change of both teams for every match. Teams missing from ratings start at the
+// initial rating. Matches that are not finished or have no result are skipped, a shootout counts as a draw
+func computeRatings(ratings map[string]float64, matches []domain.Match, results []domain.MatchResult, config RatingConfig) []domain.RatingChange {
+	resultsByMatch := make(map[int]domain.MatchResult, len(results))
+	for _, result := range results {
+		resultsByMatch[result.MatchID] = result
+	}
+
+	current := make(map[string]float64, len(ratings))
+	for team, rating := range ratings {
+		current[team] = rating
+	}
+	rating := func(team string) float64 {
+		if r, ok := current[team]; ok {
+			return r
+		}
+		return config.InitialRating
+	}
+
+	ordered := append([]domain.Match(nil), matches...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].PlayedBefore(ordered[j])
+	})
+
+	var changes []domain.RatingChange
+	for _, match := range ordered {
+		result, ok := resultsByMatch[match.ID]
+		if !ok || match.Status != domain.MatchFinished {
+			continue
+		}
+
+		home, away := rating(match.HomeTeam), rating(match.AwayTeam)
+		actual := 0.5
+		switch {
+		case result.HomeScore > result.AwayScore:
+			actual = 1
+		case result.HomeScore < result.AwayScore:
+			actual = 0
+		}
+		expected := expectedScore(home+config.HomeAdvantage, away)
+		delta := config.KFactor * goalDifferenceMultiplier(result.HomeScore-result.AwayScore) * (actual - expected)
+
+		homeChange := domain.RatingChange{
+			MatchID:      match.ID,
+			MatchDate:    match.MatchDate,
+			MatchTime:    match.MatchTime,
+			Team:         match.HomeTeam,
+			Opponent:     match.AwayTeam,
+			Venue:        "home",
+			GoalsFor:     result.HomeScore,
+			GoalsAgainst: result.AwayScore,
+			Before:       home,
+			After:        roundRating(home + delta),
+		}
+		awayChange := domain.RatingChange{
+			MatchID:      match.ID,
+			MatchDate:    match.MatchDate,
+			MatchTime:    match.MatchTime,
+			Team:         match.AwayTeam,
+			Opponent:     match.HomeTeam,
+			Venue:        "away",
+			GoalsFor:     result.AwayScore,
+			GoalsAgainst: result.HomeScore,
+			Before:       away,
+			After:        roundRating(away - delta),
+		}
+		for _, change := range []*domain.RatingChange{&homeChange, &awayChange} {
+			switch {
+			case change.GoalsFor > change.GoalsAgainst:
+				change.Outcome = "W"
+			case change.GoalsFor < change.GoalsAgainst:
+				change.Outcome = "L"
+			default:
+				change.Outcome = "D"
+			}
+			current[change.Team] = change.After
+		}
+		changes = append(changes, homeChange, awayChange)
+	}
+	return changes
+}
+
+// expectedScore is the result a team is expected to get against opponent, from 0 for a certain
+// loss to 1 for a certain win
+func expectedScore(rating, opponent float64) float64 {
+	return 1 / (1 + math.Pow(10, (opponent-rating)/400))
+}
+
+// goalDifferenceMultiplier makes wins by a larger margin count for more
+func goalDifferenceMultiplier(difference int) float64 {
+	if difference < 0 {
+		difference = -difference
+	}
+	switch difference {
+	case 0, 1:
+		return 1
+	case 2:
+		return 1.5
+	}
+	return (11 + float64(difference)) / 8
+}
+
+// roundRating keeps ratings to two decimals, so the stored history reads the same as the computed one
+func roundRating(rating float64) float64 {
+	return math.Round(rating*100) / 100
+}
@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"football-team-management/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeRatings(t *testing.T) {
+	config := DefaultRatingConfig()
+	kickOff := func(id, d int, home, away string) domain.Match {
+		m := fixture(id, home, away)
+		m.MatchDate = time.Date(2024, 8, d, 0, 0, 0, 0, time.UTC)
+		m.MatchTime = "15:00"
+		return m
+	}
+
+	t.Run("Home win by two goals", func(t *testing.T) {
+		changes := computeRatings(nil, []domain.Match{kickOff(1, 1, "Persija", "Persib")}, []domain.MatchResult{score(1, 2, 0)}, config)
+		require.Len(t, changes, 2)
+
+		// Expected home score with the home advantage is 0.64, the goal difference multiplier 1.5
+		assert.Equal(t, domain.RatingChange{
+			MatchID: 1, MatchDate: changes[0].MatchDate, MatchTime: "15:00", Team: "Persija", Opponent: "Persib", Venue: "home",
+			GoalsFor: 2, GoalsAgainst: 0, Outcome: "W", Before: 1500, After: 1510.8,
+		}, changes[0])
+		assert.Equal(t, "Persib", changes[1].Team)
+		assert.Equal(t, "L", changes[1].Outcome)
+		assert.Equal(t, 1489.2, changes[1].After)
+	})
+
+	t.Run("A home draw between equal teams costs the home side", func(t *testing.T) {
+		changes := computeRatings(nil, []domain.Match{kickOff(1, 1, "Persija", "Persib")}, []domain.MatchResult{score(1, 1, 1)}, config)
+		require.Len(t, changes, 2)
+		assert.Equal(t, 1497.2, changes[0].After)
+		assert.Equal(t, 1502.8, changes[1].After)
+	})
+
+	t.Run("Shootouts count as draws", func(t *testing.T) {
+		result := score(1, 1, 1)
+		result.Shootout = []domain.ShootoutKick{{Order: 1, Team: "home", Taker: "A", Scored: true}}
+		changes := computeRatings(nil, []domain.Match{kickOff(1, 1, "Persija", "Persib")}, []domain.MatchResult{result}, config)
+		require.Len(t, changes, 2)
+		assert.Equal(t, "D", changes[0].Outcome)
+		assert.Equal(t, 1497.2, changes[0].After)
+	})
+
+	t.Run("Replays matches in kick-off order from the given ratings", func(t *testing.T) {
+		late := kickOff(2, 1, "Persib", "Persija")
+		late.MatchTime = "19:00"
+		live := kickOff(4, 10, "Persija", "Persib")
+		live.Status = domain.MatchLive
+		matches := []domain.Match{kickOff(3, 8, "Persija", "PSM"), late, kickOff(1, 1, "Persija", "Persib"), live}
+		results := []domain.MatchResult{score(1, 0, 1), score(2, 0, 0), score(4, 3, 0)}
+		start := map[string]float64{"Persija": 1600}
+
+		changes := computeRatings(start, matches, results, config)
+		var order []int
+		for _, change := range changes {
+			order = append(order, change.MatchID)
+		}
+		// Match 3 has no result and match 4 is not finished
+		assert.Equal(t, []int{1, 1, 2, 2}, order)
+		assert.Equal(t, 1600.0, changes[0].Before)
+		assert.Equal(t, 1500.0, changes[1].Before)
+		assert.Equal(t, changes[0].After, changes[3].Before, "second match starts from the rating after the first")
+		assert.Equal(t, 1600.0, start["Persija"], "starting ratings are not modified")
+	})
+
+	t.Run("Rating points move between the two teams", func(t *testing.T) {
+		matches := []domain.Match{kickOff(1, 1, "Persija", "Persib"), kickOff(2, 2, "Persib", "PSM"), kickOff(3, 3, "PSM", "Persija")}
+		results := []domain.MatchResult{score(1, 5, 1), score(2, 0, 3), score(3, 2, 2)}
+
+		total := 0.0
+		for _, change := range computeRatings(nil, matches, results, config) {
+			total += change.After - change.Before
+		}
+		assert.InDelta(t, 0, total, 0.05)
+	})
+}
+
+func TestGoalDifferenceMultiplier(t *testing.T) {
+	assert.Equal(t, 1.0, goalDifferenceMultiplier(0))
+	assert.Equal(t, 1.0, goalDifferenceMultiplier(-1))
+	assert.Equal(t, 1.5, goalDifferenceMultiplier(2))
+	assert.Equal(t, 1.75, goalDifferenceMultiplier(3))
+	assert.Equal(t, 1.875, goalDifferenceMultiplier(-4))
+}
@@ -0,0 +1,62 @@
+package usecases
+
+import "github.com/jackc/pgx/v5/pgxpool"
+
+// Repositories holds one implementation of every repository, so the storage is chosen in one place
+type Repositories struct {
+	Users           UserRepository
+	Sessions        SessionRepository
+	Teams           TeamRepository
+	Players         PlayerRepository
+	Transfers       TransferRepository
+	TransferWindows TransferWindowRepository
+	Competitions    CompetitionRepository
+	Seasons         SeasonRepository
+	Matches         MatchRepository
+	MatchResults    MatchResultRepository
+	MatchEvents     MatchEventRepository
+	Brackets        BracketRepository
+	Ratings         RatingRepository
+	Trash           TrashRepository
+}
+
+// NewPostgresRepositories returns repositories storing everything in the database behind pool
+func NewPostgresRepositories(pool *pgxpool.Pool) *Repositories {
+	return &Repositories{
+		Users:           NewPostgresUserRepo(pool),
+		Sessions:        NewPostgresSessionRepo(pool),
+		Teams:           NewPostgresTeamRepo(pool),
+		Players:         NewPostgresPlayerRepo(pool),
+		Transfers:       NewPostgresTransferRepo(pool),
+		TransferWindows: NewPostgresTransferWindowRepo(pool),
+		Competitions:    NewPostgresCompetitionRepo(pool),
+		Seasons:         NewPostgresSeasonRepo(pool),
+		Matches:         NewPostgresMatchRepo(pool),
+		MatchResults:    NewPostgresMatchResultRepo(pool),
+		MatchEvents:     NewPostgresMatchEventRepo(pool),
+		Brackets:        NewPostgresBracketRepo(pool),
+		Ratings:         NewPostgresRatingRepo(pool),
+		Trash:           NewPostgresTrashRepo(pool),
+	}
+}
+
+// NewMemoryRepositories returns repositories sharing a new, empty MemoryStore
+func NewMemoryRepositories() *Repositories {
+	store := NewMemoryStore()
+	return &Repositories{
+		Users:           NewMemoryUserRepo(store),
+		Sessions:        NewMemorySessionRepo(store),
+		Teams:           NewMemoryTeamRepo(store),
+		Players:         NewMemoryPlayerRepo(store),
+		Transfers:       NewMemoryTransferRepo(store),
+		TransferWindows: NewMemoryTransferWindowRepo(store),
+		Competitions:    NewMemoryCompetitionRepo(store),
+		Seasons:         NewMemorySeasonRepo(store),
+		Matches:         NewMemoryMatchRepo(store),
+		MatchResults:    NewMemoryMatchResultRepo(store),
+		MatchEvents:     NewMemoryMatchEventRepo(store),
+		Brackets:        NewMemoryBracketRepo(store),
+		Ratings:         NewMemoryRatingRepo(store),
+		Trash:           NewMemoryTrashRepo(store),
+	}
+}
@@ -0,0 +1,176 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type SeasonRepository interface {
+	Register(ctx context.Context, season domain.Season) (int, error)
+	Update(ctx context.Context, id int, season domain.Season) error
+	Delete(ctx context.Context, id int) error
+	List(ctx context.Context) ([]domain.Season, error)
+	ListByCompetition(ctx context.Context, competitionID int) ([]domain.Season, error)
+	GetByID(ctx context.Context, id int) (*domain.Season, error)
+	Restore(ctx context.Context, id int) error
+}
+
+type PostgresSeasonRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresSeasonRepo(pool *pgxpool.Pool) *PostgresSeasonRepo {
+	return &PostgresSeasonRepo{pool: pool}
+}
+
+func (r *PostgresSeasonRepo) Register(ctx context.Context, season domain.Season) (int, error) {
+	// Check if competition exists
+	var competitionExists bool
+	err := r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM competitions WHERE id = $1 AND deleted_at IS NULL)`, season.CompetitionID).Scan(&competitionExists)
+	if err != nil {
+		return 0, err
+	}
+	if !competitionExists {
+		return 0, apperrors.ErrCompetitionNotFound
+	}
+
+	// Check if season already exists in this competition
+	var seasonExists bool
+	err = r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM seasons WHERE competition_id = $1 AND name = $2 AND deleted_at IS NULL)`,
+		season.CompetitionID, season.Name).Scan(&seasonExists)
+	if err != nil {
+		return 0, err
+	}
+	if seasonExists {
+		return 0, apperrors.ErrSeasonAlreadyExists
+	}
+
+	now := time.Now()
+	var id int
+	err = r.pool.QueryRow(ctx, `INSERT INTO seasons (competition_id, name, start_date, end_date, created_at, updated_at, deleted_at) VALUES ($1, $2, $3, $4, $5, $6, NULL) RETURNING id`,
+		season.CompetitionID, season.Name, season.StartDate, season.EndDate, now, now).Scan(&id)
+	return id, dbError(err)
+}
+
+func (r *PostgresSeasonRepo) Update(ctx context.Context, id int, season domain.Season) error {
+	// Check if competition exists
+	var competitionExists bool
+	err := r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM competitions WHERE id = $1 AND deleted_at IS NULL)`, season.CompetitionID).Scan(&competitionExists)
+	if err != nil {
+		return err
+	}
+	if !competitionExists {
+		return apperrors.ErrCompetitionNotFound
+	}
+
+	// Check if the name is taken by another season of the competition
+	var seasonExists bool
+	err = r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM seasons WHERE competition_id = $1 AND name = $2 AND id != $3 AND deleted_at IS NULL)`,
+		season.CompetitionID, season.Name, id).Scan(&seasonExists)
+	if err != nil {
+		return err
+	}
+	if seasonExists {
+		return apperrors.ErrSeasonAlreadyExists
+	}
+
+	now := time.Now()
+	cmd, err := r.pool.Exec(ctx, `UPDATE seasons SET competition_id=$1, name=$2, start_date=$3, end_date=$4, updated_at=$5 WHERE id=$6 AND deleted_at IS NULL`,
+		season.CompetitionID, season.Name, season.StartDate, season.EndDate, now, id)
+	if err != nil {
+		return dbError(err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return apperrors.ErrSeasonNotFound
+	}
+	return nil
+}
+
+func (r *PostgresSeasonRepo) Delete(ctx context.Context, id int) error {
+	now := time.Now()
+	cmd, err := r.pool.Exec(ctx, `UPDATE seasons SET deleted_at=$1, updated_at=$2 WHERE id=$3 AND deleted_at IS NULL`, now, now, id)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return apperrors.ErrSeasonNotFound
+	}
+	return nil
+}
+
+func (r *PostgresSeasonRepo) List(ctx context.Context) ([]domain.Season, error) {
+	rows, err := r.pool.Query(ctx, `SELECT id, competition_id, name, start_date, end_date, created_at, updated_at, deleted_at FROM seasons WHERE deleted_at IS NULL ORDER BY start_date DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var seasons []domain.Season
+	for rows.Next() {
+		var s domain.Season
+		var deletedAt *time.Time
+		if err := rows.Scan(&s.ID, &s.CompetitionID, &s.Name, &s.StartDate, &s.EndDate, &s.CreatedAt, &s.UpdatedAt, &deletedAt); err != nil {
+			return nil, err
+		}
+		s.DeletedAt = deletedAt
+		seasons = append(seasons, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return seasons, nil
+}
+
+func (r *PostgresSeasonRepo) ListByCompetition(ctx context.Context, competitionID int) ([]domain.Season, error) {
+	rows, err := r.pool.Query(ctx, `SELECT id, competition_id, name, start_date, end_date, created_at, updated_at, deleted_at FROM seasons WHERE competition_id = $1 AND deleted_at IS NULL ORDER BY start_date DESC`, competitionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var seasons []domain.Season
+	for rows.Next() {
+		var s domain.Season
+		var deletedAt *time.Time
+		if err := rows.Scan(&s.ID, &s.CompetitionID, &s.Name, &s.StartDate, &s.EndDate, &s.CreatedAt, &s.UpdatedAt, &deletedAt); err != nil {
+			return nil, err
+		}
+		s.DeletedAt = deletedAt
+		seasons = append(seasons, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return seasons, nil
+}
+
+func (r *PostgresSeasonRepo) GetByID(ctx context.Context, id int) (*domain.Season, error) {
+	var s domain.Season
+	var deletedAt *time.Time
+	err := r.pool.QueryRow(ctx, `SELECT id, competition_id, name, start_date, end_date, created_at, updated_at, deleted_at FROM seasons WHERE id = $1 AND deleted_at IS NULL`, id).
+		Scan(&s.ID, &s.CompetitionID, &s.Name, &s.StartDate, &s.EndDate, &s.CreatedAt, &s.UpdatedAt, &deletedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperrors.ErrSeasonNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.DeletedAt = deletedAt
+	return &s, nil
+}
+
+func (r *PostgresSeasonRepo) Restore(ctx context.Context, id int) error {
+	now := time.Now()
+	cmd, err := r.pool.Exec(ctx, `UPDATE seasons SET deleted_at=NULL, updated_at=$1 WHERE id=$2 AND deleted_at IS NOT NULL`, now, id)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return apperrors.ErrSeasonNotFound.WithMessage("season not found or not deleted")
+	}
+	return nil
+}
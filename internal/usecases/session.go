@@ -0,0 +1,151 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"football-team-management/internal/domain/user"
+	apperrors "football-team-management/internal/pkg/errors"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SessionRepository stores login sessions, their refresh tokens and revoked access tokens.
+// Refresh tokens are only ever stored as hashes
+type SessionRepository interface {
+	Create(ctx context.Context, session user.Session, refreshTokenHash string, expiresAt time.Time) error
+	Rotate(ctx context.Context, oldTokenHash, newTokenHash string, expiresAt time.Time) (*user.Session, error)
+	Revoke(ctx context.Context, sessionID string) error
+	RevokeAllForUser(ctx context.Context, username string) error
+	RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error
+	IsActive(ctx context.Context, sessionID, jti string) (bool, error)
+}
+
+var (
+	errInvalidRefreshToken = apperrors.ErrUnauthorized.WithMessage("invalid refresh token")
+	errRefreshTokenReused  = apperrors.NewAppError("REFRESH_TOKEN_REUSED", "refresh token reuse detected, session revoked", http.StatusUnauthorized)
+)
+
+type PostgresSessionRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresSessionRepo(pool *pgxpool.Pool) *PostgresSessionRepo {
+	return &PostgresSessionRepo{pool: pool}
+}
+
+func (r *PostgresSessionRepo) Create(ctx context.Context, session user.Session, refreshTokenHash string, expiresAt time.Time) error {
+	// Start transaction
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	now := time.Now()
+	_, err = tx.Exec(ctx, `INSERT INTO sessions (id, username, created_at, revoked_at) VALUES ($1, $2, $3, NULL)`,
+		session.ID, session.Username, now)
+	if err != nil {
+		return dbError(err)
+	}
+
+	_, err = tx.Exec(ctx, `INSERT INTO refresh_tokens (token_hash, session_id, expires_at, used_at, created_at) VALUES ($1, $2, $3, NULL, $4)`,
+		refreshTokenHash, session.ID, expiresAt, now)
+	if err != nil {
+		return dbError(err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Rotate marks the presented refresh token as used and stores its replacement in the same session.
+// Presenting a token that was already used revokes the whole session, since it means the token leaked
+func (r *PostgresSessionRepo) Rotate(ctx context.Context, oldTokenHash, newTokenHash string, expiresAt time.Time) (*user.Session, error) {
+	// Start transaction
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var session user.Session
+	var tokenExpiresAt time.Time
+	var usedAt *time.Time
+	err = tx.QueryRow(ctx, `SELECT s.id, s.username, s.created_at, s.revoked_at, rt.expires_at, rt.used_at FROM refresh_tokens rt JOIN sessions s ON s.id = rt.session_id WHERE rt.token_hash = $1 FOR UPDATE`, oldTokenHash).
+		Scan(&session.ID, &session.Username, &session.CreatedAt, &session.RevokedAt, &tokenExpiresAt, &usedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, errInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if session.RevokedAt != nil || now.After(tokenExpiresAt) {
+		return nil, errInvalidRefreshToken
+	}
+	if usedAt != nil {
+		if _, err := tx.Exec(ctx, `UPDATE sessions SET revoked_at=$1 WHERE id=$2`, now, session.ID); err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return nil, err
+		}
+		return nil, errRefreshTokenReused
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE refresh_tokens SET used_at=$1 WHERE token_hash=$2`, now, oldTokenHash)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `INSERT INTO refresh_tokens (token_hash, session_id, expires_at, used_at, created_at) VALUES ($1, $2, $3, NULL, $4)`,
+		newTokenHash, session.ID, expiresAt, now)
+	if err != nil {
+		return nil, dbError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *PostgresSessionRepo) Revoke(ctx context.Context, sessionID string) error {
+	cmd, err := r.pool.Exec(ctx, `UPDATE sessions SET revoked_at=$1 WHERE id=$2 AND revoked_at IS NULL`, time.Now(), sessionID)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return apperrors.ErrSessionNotFound
+	}
+	return nil
+}
+
+func (r *PostgresSessionRepo) RevokeAllForUser(ctx context.Context, username string) error {
+	_, err := r.pool.Exec(ctx, `UPDATE sessions SET revoked_at=$1 WHERE username=$2 AND revoked_at IS NULL`, time.Now(), username)
+	return err
+}
+
+func (r *PostgresSessionRepo) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	// Revoked tokens only need to be remembered until they would have expired anyway
+	_, err := r.pool.Exec(ctx, `DELETE FROM revoked_tokens WHERE expires_at < $1`, time.Now())
+	if err != nil {
+		return err
+	}
+
+	_, err = r.pool.Exec(ctx, `INSERT INTO revoked_tokens (jti, expires_at) VALUES ($1, $2) ON CONFLICT (jti) DO NOTHING`, jti, expiresAt)
+	return err
+}
+
+func (r *PostgresSessionRepo) IsActive(ctx context.Context, sessionID, jti string) (bool, error) {
+	var active bool
+	err := r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM sessions WHERE id = $1 AND revoked_at IS NULL) AND NOT EXISTS(SELECT 1 FROM revoked_tokens WHERE jti = $2)`,
+		sessionID, jti).Scan(&active)
+	if err != nil {
+		return false, err
+	}
+	return active, nil
+}
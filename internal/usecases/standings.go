@@ -0,0 +1,210 @@
+package usecases
+
+import (
+	"context"
+	"football-team-management/internal/domain"
+	"sort"
+)
+
+// StandingsConfig controls how points are awarded and how teams level on points are ordered.
+// Tiebreakers are applied in order; teams still level afterwards are ordered by name
+type StandingsConfig struct {
+	PointsPerWin  int
+	PointsPerDraw int
+	PointsPerLoss int
+	Tiebreakers   []domain.Tiebreaker
+}
+
+// DefaultStandingsConfig awards three points for a win and one for a draw
+func DefaultStandingsConfig() StandingsConfig {
+	return StandingsConfig{
+		PointsPerWin:  3,
+		PointsPerDraw: 1,
+		PointsPerLoss: 0,
+		Tiebreakers: []domain.Tiebreaker{
+			domain.TiebreakerGoalDifference,
+			domain.TiebreakerGoalsFor,
+			domain.TiebreakerHeadToHead,
+		},
+	}
+}
+
+type StandingsService interface {
+	Table(ctx context.Context, filter domain.MatchFilter, config StandingsConfig) ([]domain.Standing, error)
+}
+
+type standingsService struct {
+	matchRepo  MatchRepository
+	resultRepo MatchResultRepository
+}
+
+func NewStandingsService(matchRepo MatchRepository, resultRepo MatchResultRepository) StandingsService {
+	return &standingsService{matchRepo: matchRepo, resultRepo: resultRepo}
+}
+
+func (s *standingsService) Table(ctx context.Context, filter domain.MatchFilter, config StandingsConfig) ([]domain.Standing, error) {
+	matches, err := s.matchRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	results, err := s.resultRepo.ListByMatches(ctx, matchIDs(matches))
+	if err != nil {
+		return nil, err
+	}
+	return computeStandings(matches, results, config), nil
+}
+
+// matchIDs returns the IDs of matches, to fetch their results in one go
+func matchIDs(matches []domain.Match) []int {
+	ids := make([]int, 0, len(matches))
+	for _, match := range matches {
+		ids = append(ids, match.ID)
+	}
+	return ids
+}
+
+// playedMatch is a match together with its final score
+type playedMatch struct {
+	homeTeam  string
+	awayTeam  string
+	homeScore int
+	awayScore int
+}
+
+// computeStandings builds the table from the given finished matches. Every team appearing in a match
+// gets a row, results of matches outside the list are ignored
+func computeStandings(matches []domain.Match, results []domain.MatchResult, config StandingsConfig) []domain.Standing {
+	resultsByMatch := make(map[int]domain.MatchResult, len(results))
+	for _, result := range results {
+		resultsByMatch[result.MatchID] = result
+	}
+
+	rows := make(map[string]*domain.Standing)
+	row := func(team string) *domain.Standing {
+		if _, ok := rows[team]; !ok {
+			rows[team] = &domain.Standing{Team: team}
+		}
+		return rows[team]
+	}
+
+	var played []playedMatch
+	for _, match := range matches {
+		home := row(match.HomeTeam)
+		away := row(match.AwayTeam)
+
+		// Live scores are not final yet, only finished matches count
+		result, ok := resultsByMatch[match.ID]
+		if !ok || match.Status != domain.MatchFinished {
+			continue
+		}
+		played = append(played, playedMatch{match.HomeTeam, match.AwayTeam, result.HomeScore, result.AwayScore})
+
+		addResult(home, result.HomeScore, result.AwayScore, config)
+		addResult(away, result.AwayScore, result.HomeScore, config)
+	}
+
+	teams := make([]string, 0, len(rows))
+	for team := range rows {
+		teams = append(teams, team)
+	}
+
+	// Order by points, then break ties group by group
+	sort.Slice(teams, func(i, j int) bool {
+		return rows[teams[i]].Points > rows[teams[j]].Points
+	})
+	var ordered []string
+	for _, group := range groupBy(teams, func(team string) int { return rows[team].Points }) {
+		ordered = append(ordered, breakTies(group, config.Tiebreakers, rows, played, config)...)
+	}
+
+	standings := make([]domain.Standing, 0, len(ordered))
+	for i, team := range ordered {
+		standing := *rows[team]
+		standing.Position = i + 1
+		standings = append(standings, standing)
+	}
+	return standings
+}
+
+func addResult(standing *domain.Standing, scored, conceded int, config StandingsConfig) {
+	standing.Played++
+	standing.GoalsFor += scored
+	standing.GoalsAgainst += conceded
+	standing.GoalDifference = standing.GoalsFor - standing.GoalsAgainst
+
+	switch {
+	case scored > conceded:
+		standing.Won++
+		standing.Points += config.PointsPerWin
+	case scored == conceded:
+		standing.Drawn++
+		standing.Points += config.PointsPerDraw
+	default:
+		standing.Lost++
+		standing.Points += config.PointsPerLoss
+	}
+}
+
+// breakTies orders a group of teams level on points by applying the tiebreakers in turn
+func breakTies(group []string, tiebreakers []domain.Tiebreaker, rows map[string]*domain.Standing, played []playedMatch, config StandingsConfig) []string {
+	if len(group) <= 1 || len(tiebreakers) == 0 {
+		sort.Strings(group)
+		return group
+	}
+
+	var key func(team string) int
+	switch tiebreakers[0] {
+	case domain.TiebreakerGoalDifference:
+		key = func(team string) int { return rows[team].GoalDifference }
+	case domain.TiebreakerGoalsFor:
+		key = func(team string) int { return rows[team].GoalsFor }
+	case domain.TiebreakerHeadToHead:
+		points := headToHeadPoints(group, played, config)
+		key = func(team string) int { return points[team] }
+	default:
+		return breakTies(group, tiebreakers[1:], rows, played, config)
+	}
+
+	sort.SliceStable(group, func(i, j int) bool {
+		return key(group[i]) > key(group[j])
+	})
+	var ordered []string
+	for _, subgroup := range groupBy(group, key) {
+		ordered = append(ordered, breakTies(subgroup, tiebreakers[1:], rows, played, config)...)
+	}
+	return ordered
+}
+
+// headToHeadPoints returns the points each team earned in matches among the teams of the group only
+func headToHeadPoints(group []string, played []playedMatch, config StandingsConfig) map[string]int {
+	inGroup := make(map[string]bool, len(group))
+	for _, team := range group {
+		inGroup[team] = true
+	}
+
+	points := make(map[string]int, len(group))
+	for _, match := range played {
+		if !inGroup[match.homeTeam] || !inGroup[match.awayTeam] {
+			continue
+		}
+		home := &domain.Standing{}
+		away := &domain.Standing{}
+		addResult(home, match.homeScore, match.awayScore, config)
+		addResult(away, match.awayScore, match.homeScore, config)
+		points[match.homeTeam] += home.Points
+		points[match.awayTeam] += away.Points
+	}
+	return points
+}
+
+// groupBy splits an already sorted slice into runs of equal key
+func groupBy(teams []string, key func(team string) int) [][]string {
+	var groups [][]string
+	for i, team := range teams {
+		if i == 0 || key(team) != key(teams[i-1]) {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], team)
+	}
+	return groups
+}
@@ -0,0 +1,89 @@
+package usecases
+
+import (
+	"football-team-management/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fixture(id int, home, away string) domain.Match {
+	return domain.Match{ID: id, HomeTeam: home, AwayTeam: away, Status: domain.MatchFinished}
+}
+
+func score(matchID, home, away int) domain.MatchResult {
+	return domain.MatchResult{MatchID: matchID, HomeScore: home, AwayScore: away}
+}
+
+func teamOrder(standings []domain.Standing) []string {
+	var teams []string
+	for _, s := range standings {
+		teams = append(teams, s.Team)
+	}
+	return teams
+}
+
+func TestComputeStandings(t *testing.T) {
+	t.Run("Counts points, goals and records", func(t *testing.T) {
+		matches := []domain.Match{fixture(1, "A", "B"), fixture(2, "B", "C"), fixture(3, "C", "A"), fixture(4, "A", "C")}
+		results := []domain.MatchResult{score(1, 2, 0), score(2, 1, 1), score(3, 0, 3)}
+
+		standings := computeStandings(matches, results, DefaultStandingsConfig())
+
+		assert.Equal(t, []string{"A", "B", "C"}, teamOrder(standings))
+		assert.Equal(t, domain.Standing{Position: 1, Team: "A", Played: 2, Won: 2, GoalsFor: 5, GoalDifference: 5, Points: 6}, standings[0])
+		assert.Equal(t, domain.Standing{Position: 2, Team: "B", Played: 2, Drawn: 1, Lost: 1, GoalsFor: 1, GoalsAgainst: 3, GoalDifference: -2, Points: 1}, standings[1])
+		assert.Equal(t, 1, standings[2].Points)
+		assert.Equal(t, -3, standings[2].GoalDifference)
+	})
+
+	t.Run("Configurable points per win", func(t *testing.T) {
+		matches := []domain.Match{fixture(1, "A", "B"), fixture(2, "C", "D"), fixture(3, "A", "C")}
+		results := []domain.MatchResult{score(1, 1, 0), score(2, 0, 0), score(3, 0, 0)}
+		config := DefaultStandingsConfig()
+		config.PointsPerWin = 2
+
+		standings := computeStandings(matches, results, config)
+
+		assert.Equal(t, 3, standings[0].Points)
+		assert.Equal(t, "A", standings[0].Team)
+	})
+
+	t.Run("Head to head decides when goals are level", func(t *testing.T) {
+		// B and C finish level on points, goal difference and goals scored, but C beat B
+		matches := []domain.Match{fixture(1, "C", "B"), fixture(2, "A", "C"), fixture(3, "B", "D")}
+		results := []domain.MatchResult{score(1, 1, 0), score(2, 1, 0), score(3, 1, 0)}
+
+		standings := computeStandings(matches, results, DefaultStandingsConfig())
+
+		assert.Equal(t, []string{"A", "C", "B", "D"}, teamOrder(standings))
+	})
+
+	t.Run("Tiebreakers apply in configured order", func(t *testing.T) {
+		// A has the better goal difference, B scored more goals
+		matches := []domain.Match{fixture(1, "A", "X"), fixture(2, "B", "Y")}
+		results := []domain.MatchResult{score(1, 2, 0), score(2, 4, 3)}
+		config := DefaultStandingsConfig()
+
+		config.Tiebreakers = []domain.Tiebreaker{domain.TiebreakerGoalDifference}
+		assert.Equal(t, []string{"A", "B"}, teamOrder(computeStandings(matches, results, config))[:2])
+
+		config.Tiebreakers = []domain.Tiebreaker{domain.TiebreakerGoalsFor}
+		assert.Equal(t, []string{"B", "A"}, teamOrder(computeStandings(matches, results, config))[:2])
+	})
+
+	t.Run("Teams without results are listed with zero games", func(t *testing.T) {
+		standings := computeStandings([]domain.Match{fixture(1, "A", "B")}, nil, DefaultStandingsConfig())
+
+		assert.Equal(t, []string{"A", "B"}, teamOrder(standings))
+		assert.Equal(t, 0, standings[0].Played)
+	})
+	t.Run("Live matches do not count yet", func(t *testing.T) {
+		live := fixture(1, "A", "B")
+		live.Status = domain.MatchLive
+		standings := computeStandings([]domain.Match{live}, []domain.MatchResult{score(1, 1, 0)}, DefaultStandingsConfig())
+
+		assert.Equal(t, 0, standings[0].Played)
+		assert.Equal(t, 0, standings[0].Points)
+	})
+}
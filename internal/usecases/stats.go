@@ -0,0 +1,276 @@
+package usecases
+
+import (
+	"context"
+	"football-team-management/internal/domain"
+	"math"
+	"sort"
+)
+
+// Match lengths without and with extra time, and the default size of the top scorers list
+const (
+	fullMatchMinutes  = 90
+	extraTimeMinutes  = 120
+	defaultTopScorers = 10
+)
+
+type StatsService interface {
+	TopScorers(ctx context.Context, filter domain.MatchFilter, limit int) ([]domain.PlayerStats, error)
+	PlayerStats(ctx context.Context, playerKey string, filter domain.MatchFilter) (*domain.PlayerStats, error)
+	TeamGoals(ctx context.Context, filter domain.MatchFilter) ([]domain.TeamGoals, error)
+}
+
+type statsService struct {
+	matchRepo  MatchRepository
+	eventRepo  MatchEventRepository
+	playerRepo PlayerRepository
+}
+
+func NewStatsService(matchRepo MatchRepository, eventRepo MatchEventRepository, playerRepo PlayerRepository) StatsService {
+	return &statsService{matchRepo: matchRepo, eventRepo: eventRepo, playerRepo: playerRepo}
+}
+
+// TopScorers ranks players by goals, then assists, then fewer minutes played. A limit of zero or less returns the top 10
+func (s *statsService) TopScorers(ctx context.Context, filter domain.MatchFilter, limit int) ([]domain.PlayerStats, error) {
+	matches, events, err := s.finishedMatches(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var scorers []domain.PlayerStats
+	for _, stats := range computePlayerStats(matches, events) {
+		if stats.Goals > 0 {
+			scorers = append(scorers, *stats)
+		}
+	}
+	sort.Slice(scorers, func(i, j int) bool {
+		a, b := scorers[i], scorers[j]
+		switch {
+		case a.Goals != b.Goals:
+			return a.Goals > b.Goals
+		case a.Assists != b.Assists:
+			return a.Assists > b.Assists
+		case a.Minutes != b.Minutes:
+			return a.Minutes < b.Minutes
+		}
+		return a.Player < b.Player
+	})
+
+	if limit <= 0 {
+		limit = defaultTopScorers
+	}
+	if len(scorers) > limit {
+		scorers = scorers[:limit]
+	}
+	return scorers, nil
+}
+
+func (s *statsService) PlayerStats(ctx context.Context, playerKey string, filter domain.MatchFilter) (*domain.PlayerStats, error) {
+	player, err := s.playerRepo.GetByKey(ctx, playerKey)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, events, err := s.finishedMatches(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if stats, ok := computePlayerStats(matches, events)[player.Name]; ok {
+		return stats, nil
+	}
+	return &domain.PlayerStats{Player: player.Name, Team: player.TeamName}, nil
+}
+
+// TeamGoals lists the goals scored and conceded by every team, most goals scored first
+func (s *statsService) TeamGoals(ctx context.Context, filter domain.MatchFilter) ([]domain.TeamGoals, error) {
+	matches, events, err := s.finishedMatches(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return computeTeamGoals(matches, events), nil
+}
+
+// finishedMatches loads the finished matches passing filter together with all their events
+func (s *statsService) finishedMatches(ctx context.Context, filter domain.MatchFilter) ([]domain.Match, []domain.MatchEvent, error) {
+	all, err := s.matchRepo.List(ctx, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var matches []domain.Match
+	var ids []int
+	for _, match := range all {
+		if match.Status == domain.MatchFinished {
+			matches = append(matches, match)
+			ids = append(ids, match.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil, nil
+	}
+
+	events, err := s.eventRepo.ListByMatches(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	return matches, events, nil
+}
+
+// computePlayerStats adds up the events of the given matches, which are expected in date order, per player.
+// Without line-ups a player counts as appearing in a match when they are on its timeline: they played from
+// kick-off unless they came on as a substitute, until the final whistle unless substituted or sent off
+func computePlayerStats(matches []domain.Match, events []domain.MatchEvent) map[string]*domain.PlayerStats {
+	eventsByMatch := make(map[int][]domain.MatchEvent)
+	for _, event := range events {
+		eventsByMatch[event.MatchID] = append(eventsByMatch[event.MatchID], event)
+	}
+
+	players := make(map[string]*domain.PlayerStats)
+	stats := func(player string) *domain.PlayerStats {
+		if _, ok := players[player]; !ok {
+			players[player] = &domain.PlayerStats{Player: player}
+		}
+		return players[player]
+	}
+
+	for _, match := range matches {
+		matchEvents := eventsByMatch[match.ID]
+
+		// The match lasts until its last event, at least 90 minutes or 120 after extra time
+		length := fullMatchMinutes
+		for _, event := range matchEvents {
+			if event.Period == domain.PeriodExtraTime && length < extraTimeMinutes {
+				length = extraTimeMinutes
+			}
+			if minute := eventMinute(event); minute > length {
+				length = minute
+			}
+		}
+
+		on := make(map[string]int)
+		off := make(map[string]int)
+		side := make(map[string]string)
+		appear := func(player, team string) {
+			if _, ok := side[player]; !ok {
+				side[player] = team
+				on[player] = 0
+				off[player] = length
+			}
+		}
+		teamOf := func(team string) string {
+			if team == "home" {
+				return match.HomeTeam
+			}
+			return match.AwayTeam
+		}
+		opponentOf := func(team string) string {
+			if team == "home" {
+				return match.AwayTeam
+			}
+			return match.HomeTeam
+		}
+
+		for _, event := range matchEvents {
+			minute := eventMinute(event)
+			switch event.Type {
+			case domain.EventGoal:
+				if event.GoalType == domain.GoalOwnGoal {
+					appear(event.Player, opponentOf(event.Team))
+					stats(event.Player).OwnGoals++
+					break
+				}
+				appear(event.Player, teamOf(event.Team))
+				stats(event.Player).Goals++
+				if event.GoalType == domain.GoalPenalty {
+					stats(event.Player).PenaltyGoals++
+				}
+				if event.Assist != "" {
+					appear(event.Assist, teamOf(event.Team))
+					stats(event.Assist).Assists++
+				}
+			case domain.EventYellowCard:
+				appear(event.Player, teamOf(event.Team))
+				stats(event.Player).YellowCards++
+			case domain.EventRedCard:
+				appear(event.Player, teamOf(event.Team))
+				stats(event.Player).RedCards++
+				off[event.Player] = minute
+			case domain.EventSubstitution:
+				appear(event.Player, teamOf(event.Team))
+				off[event.Player] = minute
+				if _, ok := side[event.PlayerIn]; !ok {
+					appear(event.PlayerIn, teamOf(event.Team))
+					on[event.PlayerIn] = minute
+				}
+			}
+		}
+
+		for player, team := range side {
+			s := stats(player)
+			s.Team = team
+			s.Appearances++
+			if minutes := off[player] - on[player]; minutes > 0 {
+				s.Minutes += minutes
+			}
+		}
+	}
+
+	for _, s := range players {
+		if s.Goals > 0 {
+			perGoal := math.Round(float64(s.Minutes)/float64(s.Goals)*10) / 10
+			s.MinutesPerGoal = &perGoal
+		}
+	}
+	return players
+}
+
+// computeTeamGoals counts the goal events of the given matches per team
+func computeTeamGoals(matches []domain.Match, events []domain.MatchEvent) []domain.TeamGoals {
+	matchesByID := make(map[int]domain.Match, len(matches))
+	rows := make(map[string]*domain.TeamGoals)
+	row := func(team string) *domain.TeamGoals {
+		if _, ok := rows[team]; !ok {
+			rows[team] = &domain.TeamGoals{Team: team}
+		}
+		return rows[team]
+	}
+	for _, match := range matches {
+		matchesByID[match.ID] = match
+		row(match.HomeTeam).Played++
+		row(match.AwayTeam).Played++
+	}
+
+	for _, event := range events {
+		match, ok := matchesByID[event.MatchID]
+		if !ok || event.Type != domain.EventGoal {
+			continue
+		}
+		scoring, conceding := match.HomeTeam, match.AwayTeam
+		if event.Team == "away" {
+			scoring, conceding = conceding, scoring
+		}
+		row(scoring).GoalsFor++
+		row(conceding).GoalsAgainst++
+	}
+
+	teamGoals := make([]domain.TeamGoals, 0, len(rows))
+	for _, r := range rows {
+		if r.Played > 0 {
+			r.GoalsPerMatch = math.Round(float64(r.GoalsFor)/float64(r.Played)*100) / 100
+		}
+		teamGoals = append(teamGoals, *r)
+	}
+	sort.Slice(teamGoals, func(i, j int) bool {
+		if teamGoals[i].GoalsFor != teamGoals[j].GoalsFor {
+			return teamGoals[i].GoalsFor > teamGoals[j].GoalsFor
+		}
+		return teamGoals[i].Team < teamGoals[j].Team
+	})
+	return teamGoals
+}
+
+// eventMinute returns the minute of play of an event, events with an unreadable time count as minute 0
+func eventMinute(event domain.MatchEvent) int {
+	seconds, _ := event.Seconds()
+	return seconds / 60
+}
@@ -0,0 +1,107 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func event(matchID int, eventType, eventTime, team, player string) domain.MatchEvent {
+	return domain.MatchEvent{MatchID: matchID, Type: eventType, EventTime: eventTime, Team: team, Player: player}
+}
+
+func TestComputePlayerStats(t *testing.T) {
+	matches := []domain.Match{fixture(1, "Persija", "Persib"), fixture(2, "Persib", "Persija")}
+
+	sub := event(1, domain.EventSubstitution, "60:00", "home", "Marko Simic")
+	sub.PlayerIn = "Riko Simanjuntak"
+	penalty := event(1, domain.EventGoal, "80:00", "home", "Riko Simanjuntak")
+	penalty.GoalType = domain.GoalPenalty
+	ownGoal := event(2, domain.EventGoal, "50:00", "away", "Marc Klok")
+	ownGoal.GoalType = domain.GoalOwnGoal
+	assisted := event(2, domain.EventGoal, "01:40:00", "away", "Marko Simic")
+	assisted.Assist = "Riko Simanjuntak"
+	assisted.Period = domain.PeriodExtraTime
+
+	events := []domain.MatchEvent{
+		event(1, domain.EventGoal, "10:00", "home", "Marko Simic"),
+		sub,
+		penalty,
+		event(1, domain.EventRedCard, "70:00", "away", "Marc Klok"),
+		ownGoal,
+		assisted,
+	}
+
+	stats := computePlayerStats(matches, events)
+
+	simic := stats["Marko Simic"]
+	require.NotNil(t, simic)
+	assert.Equal(t, 2, simic.Goals)
+	assert.Equal(t, 2, simic.Appearances)
+	assert.Equal(t, 60+120, simic.Minutes)
+	assert.Equal(t, 90.0, *simic.MinutesPerGoal)
+
+	riko := stats["Riko Simanjuntak"]
+	assert.Equal(t, 1, riko.Goals)
+	assert.Equal(t, 1, riko.PenaltyGoals)
+	assert.Equal(t, 1, riko.Assists)
+	assert.Equal(t, 30+120, riko.Minutes)
+	assert.Equal(t, "Persija", riko.Team)
+
+	klok := stats["Marc Klok"]
+	assert.Equal(t, 0, klok.Goals)
+	assert.Equal(t, 1, klok.OwnGoals)
+	assert.Equal(t, 1, klok.RedCards)
+	assert.Equal(t, 70+120, klok.Minutes)
+	assert.Equal(t, "Persib", klok.Team)
+	assert.Nil(t, klok.MinutesPerGoal)
+}
+
+func TestComputeTeamGoals(t *testing.T) {
+	matches := []domain.Match{fixture(1, "Persija", "Persib"), fixture(2, "Persib", "Arema")}
+	events := []domain.MatchEvent{
+		event(1, domain.EventGoal, "10:00", "home", "Marko Simic"),
+		event(1, domain.EventGoal, "20:00", "home", "Marko Simic"),
+		event(2, domain.EventGoal, "30:00", "away", "Dedik Setiawan"),
+		event(2, domain.EventYellowCard, "40:00", "home", "Marc Klok"),
+		event(3, domain.EventGoal, "30:00", "home", "Not counted"),
+	}
+
+	teamGoals := computeTeamGoals(matches, events)
+
+	require.Len(t, teamGoals, 3)
+	assert.Equal(t, domain.TeamGoals{Team: "Persija", Played: 1, GoalsFor: 2, GoalsAgainst: 0, GoalsPerMatch: 2}, teamGoals[0])
+	assert.Equal(t, domain.TeamGoals{Team: "Arema", Played: 1, GoalsFor: 1, GoalsAgainst: 0, GoalsPerMatch: 1}, teamGoals[1])
+	assert.Equal(t, domain.TeamGoals{Team: "Persib", Played: 2, GoalsFor: 0, GoalsAgainst: 3, GoalsPerMatch: 0}, teamGoals[2])
+}
+
+// unreachablePlayerRepo fails every lookup the way a repository does when the database is down
+type unreachablePlayerRepo struct {
+	PlayerRepository
+}
+
+func (r unreachablePlayerRepo) GetByKey(ctx context.Context, key string) (*domain.Player, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestPlayerStats(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Unknown players are not found", func(t *testing.T) {
+		repos, _ := newMemoryFixture(t)
+		_, err := NewStatsService(repos.Matches, repos.MatchEvents, repos.Players).PlayerStats(ctx, "andi", domain.MatchFilter{})
+		assert.ErrorIs(t, err, apperrors.ErrPlayerNotFound)
+	})
+
+	t.Run("Lookup failures are not mistaken for a missing player", func(t *testing.T) {
+		repos, _ := newMemoryFixture(t)
+		_, err := NewStatsService(repos.Matches, repos.MatchEvents, unreachablePlayerRepo{repos.Players}).PlayerStats(ctx, "riko", domain.MatchFilter{})
+		assert.EqualError(t, err, "connection refused")
+		assert.NotErrorIs(t, err, apperrors.ErrPlayerNotFound)
+	})
+}
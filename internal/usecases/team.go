@@ -4,19 +4,25 @@ import (
 	"context"
 	"errors"
 	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type TeamRepository interface {
-	Register(ctx context.Context, team domain.Team) error
-	Update(ctx context.Context, name string, team domain.Team) error
-	Delete(ctx context.Context, name string) error
+	Register(ctx context.Context, team domain.Team) (int, error)
+	Update(ctx context.Context, key string, team domain.Team) error
+	Delete(ctx context.Context, key string) error
 	List(ctx context.Context) ([]domain.Team, error)
-	Restore(ctx context.Context, name string) error
+	Search(ctx context.Context, query domain.TeamQuery) (*domain.Page[domain.Team], error)
+	GetByKey(ctx context.Context, key string) (*domain.Team, error)
+	Restore(ctx context.Context, key string) error
 }
 
+// Teams are looked up by a key holding either their ID or their slug, see keyID
+
 type PostgresTeamRepo struct {
 	pool *pgxpool.Pool
 }
@@ -25,40 +31,127 @@ func NewPostgresTeamRepo(pool *pgxpool.Pool) *PostgresTeamRepo {
 	return &PostgresTeamRepo{pool: pool}
 }
 
-func (r *PostgresTeamRepo) Register(ctx context.Context, team domain.Team) error {
+func (r *PostgresTeamRepo) Register(ctx context.Context, team domain.Team) (int, error) {
+	// Check if team already exists
+	var teamExists bool
+	err := r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM teams WHERE name = $1)`, team.Name).Scan(&teamExists)
+	if err != nil {
+		return 0, err
+	}
+	if teamExists {
+		return 0, apperrors.ErrTeamAlreadyExists
+	}
+
+	slug, err := uniqueSlug(ctx, r.pool, "teams", domain.Slugify(team.Name, "team"))
+	if err != nil {
+		return 0, err
+	}
+
 	now := time.Now()
-	_, err := r.pool.Exec(ctx, `INSERT INTO teams (name, logo, year_founded, stadium_addr, city, created_at, updated_at, deleted_at) VALUES ($1, $2, $3, $4, $5, $6, $7, NULL)`,
-		team.Name, team.Logo, team.YearFounded, team.StadiumAddr, team.City, now, now)
-	return err
+	var id int
+	err = r.pool.QueryRow(ctx, `INSERT INTO teams (slug, name, logo, year_founded, stadium_addr, city, created_at, updated_at, deleted_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULL) RETURNING id`,
+		slug, team.Name, team.Logo, team.YearFounded, team.StadiumAddr, team.City, now, now).Scan(&id)
+	return id, dbError(err)
 }
 
-func (r *PostgresTeamRepo) Update(ctx context.Context, name string, team domain.Team) error {
-	now := time.Now()
-	cmd, err := r.pool.Exec(ctx, `UPDATE teams SET name=$1, logo=$2, year_founded=$3, stadium_addr=$4, city=$5, updated_at=$6 WHERE name=$7 AND deleted_at IS NULL`,
-		team.Name, team.Logo, team.YearFounded, team.StadiumAddr, team.City, now, name)
+// Update changes the team's details. Tables refer to the team by its ID, so a new name shows everywhere.
+// Access tokens list the manager's teams by name and only pick it up when they are refreshed
+func (r *PostgresTeamRepo) Update(ctx context.Context, key string, team domain.Team) error {
+	// Check if the name is taken by another team
+	var nameTaken bool
+	err := r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM teams WHERE name = $1 AND id IS DISTINCT FROM $2 AND slug != $3)`, team.Name, keyID(key), key).Scan(&nameTaken)
 	if err != nil {
 		return err
 	}
+	if nameTaken {
+		return apperrors.ErrTeamNameTaken
+	}
+
+	now := time.Now()
+	cmd, err := r.pool.Exec(ctx, `UPDATE teams SET name=$1, logo=$2, year_founded=$3, stadium_addr=$4, city=$5, updated_at=$6 WHERE (id = $7 OR slug = $8) AND deleted_at IS NULL`,
+		team.Name, team.Logo, team.YearFounded, team.StadiumAddr, team.City, now, keyID(key), key)
+	if err != nil {
+		return dbError(err)
+	}
 	if cmd.RowsAffected() == 0 {
-		return errors.New("team not found")
+		return apperrors.ErrTeamNotFound
 	}
 	return nil
 }
 
-func (r *PostgresTeamRepo) Delete(ctx context.Context, name string) error {
+func (r *PostgresTeamRepo) Delete(ctx context.Context, key string) error {
 	now := time.Now()
-	cmd, err := r.pool.Exec(ctx, `UPDATE teams SET deleted_at=$1, updated_at=$2 WHERE name=$3 AND deleted_at IS NULL`, now, now, name)
+	cmd, err := r.pool.Exec(ctx, `UPDATE teams SET deleted_at=$1, updated_at=$2 WHERE (id = $3 OR slug = $4) AND deleted_at IS NULL`, now, now, keyID(key), key)
 	if err != nil {
 		return err
 	}
 	if cmd.RowsAffected() == 0 {
-		return errors.New("team not found")
+		return apperrors.ErrTeamNotFound
 	}
 	return nil
 }
 
+const teamSelect = `SELECT id, slug, name, logo, year_founded, stadium_addr, city, created_at, updated_at, deleted_at FROM teams`
+
+// teamSortColumns maps the sort fields of domain.TeamSortFields to columns
+var teamSortColumns = map[string][]string{
+	"id":           {"id"},
+	"name":         {"name"},
+	"city":         {"city"},
+	"year_founded": {"year_founded"},
+	"created_at":   {"created_at"},
+}
+
 func (r *PostgresTeamRepo) List(ctx context.Context) ([]domain.Team, error) {
-	rows, err := r.pool.Query(ctx, `SELECT name, logo, year_founded, stadium_addr, city, created_at, updated_at, deleted_at FROM teams WHERE deleted_at IS NULL`)
+	return r.listTeams(ctx, teamSelect+` WHERE deleted_at IS NULL ORDER BY id`)
+}
+
+// Search returns a page of the teams, filtered, sorted and cut by the database
+func (r *PostgresTeamRepo) Search(ctx context.Context, query domain.TeamQuery) (*domain.Page[domain.Team], error) {
+	page := query.PageRequest.Normalized(domain.DefaultTeamSort)
+	where := ` WHERE ` + deletedClause(query.Deleted, "deleted_at") + ` AND ($1::text IS NULL OR lower(city) = lower($1))`
+	city := optional(query.City)
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT count(*) FROM teams`+where, city).Scan(&total); err != nil {
+		return nil, err
+	}
+	teams, err := r.listTeams(ctx, teamSelect+where+orderBy(page, teamSortColumns, "id")+` LIMIT $2 OFFSET $3`, city, page.Limit, page.Offset)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewPage(teams, total, page), nil
+}
+
+func (r *PostgresTeamRepo) GetByKey(ctx context.Context, key string) (*domain.Team, error) {
+	var t domain.Team
+	var deletedAt *time.Time
+	err := r.pool.QueryRow(ctx, teamSelect+` WHERE (id = $1 OR slug = $2) AND deleted_at IS NULL`, keyID(key), key).
+		Scan(&t.ID, &t.Slug, &t.Name, &t.Logo, &t.YearFounded, &t.StadiumAddr, &t.City, &t.CreatedAt, &t.UpdatedAt, &deletedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperrors.ErrTeamNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	t.DeletedAt = deletedAt
+	return &t, nil
+}
+
+func (r *PostgresTeamRepo) Restore(ctx context.Context, key string) error {
+	now := time.Now()
+	cmd, err := r.pool.Exec(ctx, `UPDATE teams SET deleted_at=NULL, updated_at=$1 WHERE (id = $2 OR slug = $3) AND deleted_at IS NOT NULL`, now, keyID(key), key)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return apperrors.ErrTeamNotFound.WithMessage("team not found or not deleted")
+	}
+	return nil
+}
+
+func (r *PostgresTeamRepo) listTeams(ctx context.Context, query string, args ...any) ([]domain.Team, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -67,23 +160,29 @@ func (r *PostgresTeamRepo) List(ctx context.Context) ([]domain.Team, error) {
 	for rows.Next() {
 		var t domain.Team
 		var deletedAt *time.Time
-		if err := rows.Scan(&t.Name, &t.Logo, &t.YearFounded, &t.StadiumAddr, &t.City, &t.CreatedAt, &t.UpdatedAt, &deletedAt); err != nil {
+		if err := rows.Scan(&t.ID, &t.Slug, &t.Name, &t.Logo, &t.YearFounded, &t.StadiumAddr, &t.City, &t.CreatedAt, &t.UpdatedAt, &deletedAt); err != nil {
 			return nil, err
 		}
 		t.DeletedAt = deletedAt
 		teams = append(teams, t)
 	}
-	return teams, nil
+	return teams, rows.Err()
 }
 
-func (r *PostgresTeamRepo) Restore(ctx context.Context, name string) error {
-	now := time.Now()
-	cmd, err := r.pool.Exec(ctx, `UPDATE teams SET deleted_at=NULL, updated_at=$1 WHERE name=$2 AND deleted_at IS NOT NULL`, now, name)
+// ensureTeamsExist returns an error naming the first of names that is not an active team
+func ensureTeamsExist(ctx context.Context, repo TeamRepository, names []string) error {
+	teams, err := repo.List(ctx)
 	if err != nil {
 		return err
 	}
-	if cmd.RowsAffected() == 0 {
-		return errors.New("team not found or not deleted")
+	registered := make(map[string]bool, len(teams))
+	for _, team := range teams {
+		registered[team.Name] = true
+	}
+	for _, name := range names {
+		if !registered[name] {
+			return apperrors.ErrTeamNotFound.WithMessage("team not found: " + name)
+		}
 	}
 	return nil
 }
@@ -0,0 +1,270 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TransferRepository interface {
+	Register(ctx context.Context, transfer domain.Transfer) (*domain.Transfer, error)
+	List(ctx context.Context) ([]domain.Transfer, error)
+	ListByTeam(ctx context.Context, teamKey string) ([]domain.Transfer, error)
+	GetByID(ctx context.Context, id int) (*domain.Transfer, error)
+	ListContracts(ctx context.Context, playerKey string) ([]domain.Contract, error)
+}
+
+type PostgresTransferRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresTransferRepo(pool *pgxpool.Pool) *PostgresTransferRepo {
+	return &PostgresTransferRepo{pool: pool}
+}
+
+// Register moves the player, given by ID or slug, to the new team, takes the new jersey number and updates
+// the contracts, all in one transaction. It returns the transfer with the player's name and the selling
+// team filled in
+func (r *PostgresTransferRepo) Register(ctx context.Context, transfer domain.Transfer) (*domain.Transfer, error) {
+	if err := transfer.Validate(); err != nil {
+		return nil, apperrors.Invalid(err)
+	}
+
+	// Start transaction
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock the player, looked up by ID or slug, so concurrent transfers are applied one after the other
+	var playerID int
+	var currentTeam string
+	err = tx.QueryRow(ctx, `SELECT p.id, p.name, t.name FROM players p JOIN teams t ON t.id = p.team_id WHERE (p.id = $1 OR p.slug = $2) AND p.deleted_at IS NULL FOR UPDATE OF p`,
+		keyID(transfer.Player), transfer.Player).Scan(&playerID, &transfer.Player, &currentTeam)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperrors.ErrPlayerNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	contracts, err := listContracts(ctx, tx, contractSelect+` WHERE c.player_id = $1 AND c.end_date IS NULL ORDER BY c.start_date`, playerID)
+	if err != nil {
+		return nil, err
+	}
+	ended, signed, err := planTransfer(&transfer, currentTeam, contracts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if the new team exists
+	var toTeamID int
+	err = tx.QueryRow(ctx, `SELECT id FROM teams WHERE name = $1 AND deleted_at IS NULL`, transfer.ToTeam).Scan(&toTeamID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperrors.ErrTeamNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the transfer falls within a transfer window of a season the transfer date belongs to. Loans end
+	// on the agreed terms, whenever that is
+	if transfer.Type != domain.TransferLoanReturn {
+		var windowOpen bool
+		err = tx.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM transfer_windows w JOIN seasons s ON s.id = w.season_id WHERE $1 BETWEEN w.start_date AND w.end_date AND $1 BETWEEN s.start_date AND s.end_date AND w.deleted_at IS NULL AND s.deleted_at IS NULL)`,
+			transfer.TransferDate).Scan(&windowOpen)
+		if err != nil {
+			return nil, err
+		}
+		if !windowOpen {
+			return nil, apperrors.ErrInvalidInput.WithMessage("transfer date is outside every transfer window")
+		}
+	}
+
+	// Check if jersey number is already taken by another player in the new team
+	var jerseyExists bool
+	err = tx.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM players WHERE team_id = $1 AND jersey_number = $2 AND id != $3 AND deleted_at IS NULL)`,
+		toTeamID, transfer.JerseyNumber, playerID).Scan(&jerseyExists)
+	if err != nil {
+		return nil, err
+	}
+	if jerseyExists {
+		return nil, apperrors.ErrJerseyNumberTaken
+	}
+
+	now := time.Now()
+
+	// Moving the player frees the old jersey number in the same statement
+	_, err = tx.Exec(ctx, `UPDATE players SET team_id=$1, jersey_number=$2, updated_at=$3 WHERE id=$4`,
+		toTeamID, transfer.JerseyNumber, now, playerID)
+	if err != nil {
+		return nil, dbError(err)
+	}
+
+	err = tx.QueryRow(ctx, `INSERT INTO transfers (player_id, from_team_id, to_team_id, transfer_date, fee, type, loan_end_date, jersey_number, created_at) VALUES ($1, (SELECT id FROM teams WHERE name = $2), $3, $4, $5, $6, $7, $8, $9) RETURNING id`,
+		playerID, transfer.FromTeam, toTeamID, transfer.TransferDate, transfer.Fee, transfer.Type, transfer.LoanEndDate, transfer.JerseyNumber, now).Scan(&transfer.ID)
+	if err != nil {
+		return nil, dbError(err)
+	}
+	transfer.CreatedAt = now
+
+	// End the contracts the player leaves and sign the new one
+	for _, contract := range ended {
+		_, err = tx.Exec(ctx, `UPDATE contracts SET end_date=$1, updated_at=$2 WHERE id=$3`, transfer.TransferDate, now, contract.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if signed != nil {
+		_, err = tx.Exec(ctx, `INSERT INTO contracts (player_id, team_id, type, start_date, end_date, loan_end_date, transfer_id, created_at, updated_at) VALUES ($1, $2, $3, $4, NULL, $5, $6, $7, $8)`,
+			playerID, toTeamID, signed.Type, signed.StartDate, signed.LoanEndDate, transfer.ID, now, now)
+		if err != nil {
+			return nil, dbError(err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+func (r *PostgresTransferRepo) List(ctx context.Context) ([]domain.Transfer, error) {
+	return r.listTransfers(ctx, transferSelect+` ORDER BY tr.transfer_date DESC, tr.id DESC`)
+}
+
+// ListByTeam returns the transfers the team with the given ID or slug sold or bought a player in, most recent first
+func (r *PostgresTransferRepo) ListByTeam(ctx context.Context, teamKey string) ([]domain.Transfer, error) {
+	return r.listTransfers(ctx, transferSelect+` WHERE $1::int IN (f.id, t.id) OR $2 IN (f.slug, t.slug) ORDER BY tr.transfer_date DESC, tr.id DESC`, keyID(teamKey), teamKey)
+}
+
+func (r *PostgresTransferRepo) GetByID(ctx context.Context, id int) (*domain.Transfer, error) {
+	var t domain.Transfer
+	err := r.pool.QueryRow(ctx, transferSelect+` WHERE tr.id = $1`, id).
+		Scan(&t.ID, &t.Player, &t.FromTeam, &t.ToTeam, &t.TransferDate, &t.Fee, &t.Type, &t.LoanEndDate, &t.JerseyNumber, &t.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperrors.ErrTransferNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// ListContracts returns every contract of a player, looked up by ID or slug, oldest first
+func (r *PostgresTransferRepo) ListContracts(ctx context.Context, playerKey string) ([]domain.Contract, error) {
+	var playerID int
+	err := r.pool.QueryRow(ctx, `SELECT id FROM players WHERE (id = $1 OR slug = $2) AND deleted_at IS NULL`, keyID(playerKey), playerKey).Scan(&playerID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperrors.ErrPlayerNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return listContracts(ctx, r.pool, contractSelect+` WHERE c.player_id = $1 ORDER BY c.start_date, c.id`, playerID)
+}
+
+const transferSelect = `SELECT tr.id, p.name, f.name, t.name, tr.transfer_date, tr.fee, tr.type, tr.loan_end_date, tr.jersey_number, tr.created_at FROM transfers tr` +
+	` JOIN players p ON p.id = tr.player_id JOIN teams f ON f.id = tr.from_team_id JOIN teams t ON t.id = tr.to_team_id`
+
+func (r *PostgresTransferRepo) listTransfers(ctx context.Context, query string, args ...any) ([]domain.Transfer, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var transfers []domain.Transfer
+	for rows.Next() {
+		var t domain.Transfer
+		if err := rows.Scan(&t.ID, &t.Player, &t.FromTeam, &t.ToTeam, &t.TransferDate, &t.Fee, &t.Type, &t.LoanEndDate, &t.JerseyNumber, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, t)
+	}
+	return transfers, rows.Err()
+}
+
+const contractSelect = `SELECT c.id, p.name, t.name, c.type, c.start_date, c.end_date, c.loan_end_date, c.transfer_id, c.created_at, c.updated_at FROM contracts c` +
+	` JOIN players p ON p.id = c.player_id JOIN teams t ON t.id = c.team_id`
+
+func listContracts(ctx context.Context, q querier, query string, args ...any) ([]domain.Contract, error) {
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var contracts []domain.Contract
+	for rows.Next() {
+		var c domain.Contract
+		if err := rows.Scan(&c.ID, &c.Player, &c.Team, &c.Type, &c.StartDate, &c.EndDate, &c.LoanEndDate, &c.TransferID, &c.CreatedAt, &c.UpdatedAt); err != nil {
+			return nil, err
+		}
+		contracts = append(contracts, c)
+	}
+	return contracts, rows.Err()
+}
+
+// planTransfer works out, from the player's current team and contracts, which team the player leaves,
+// which contracts end and which contract is signed. It fills in the transfer's selling team, and the
+// parent team as the buying team of a loan return that does not name one
+func planTransfer(transfer *domain.Transfer, currentTeam string, current []domain.Contract) (ended []domain.Contract, signed *domain.Contract, err error) {
+	var parent, loan *domain.Contract
+	for i := range current {
+		contract := &current[i]
+		if transfer.TransferDate.Before(contract.StartDate) {
+			return nil, nil, apperrors.ErrInvalidInput.WithMessage(fmt.Sprintf("transfer date cannot be before the player joined %s on %s", contract.Team, contract.StartDate.Format("2006-01-02")))
+		}
+		switch contract.Type {
+		case domain.ContractPermanent:
+			parent = contract
+		case domain.ContractLoan:
+			loan = contract
+		}
+	}
+
+	switch transfer.Type {
+	case domain.TransferPermanent:
+		// A player on loan is sold by the parent team, possibly to the team they are on loan at
+		transfer.FromTeam = currentTeam
+		if loan != nil && parent != nil {
+			transfer.FromTeam = parent.Team
+		}
+		ended = current
+		signed = &domain.Contract{Player: transfer.Player, Team: transfer.ToTeam, Type: domain.ContractPermanent, StartDate: transfer.TransferDate}
+
+	case domain.TransferLoan:
+		if loan != nil {
+			return nil, nil, apperrors.ErrInvalidInput.WithMessage(fmt.Sprintf("player is already on loan at %s", loan.Team))
+		}
+		transfer.FromTeam = currentTeam
+		signed = &domain.Contract{Player: transfer.Player, Team: transfer.ToTeam, Type: domain.ContractLoan, StartDate: transfer.TransferDate, LoanEndDate: transfer.LoanEndDate}
+
+	case domain.TransferLoanReturn:
+		if loan == nil {
+			return nil, nil, apperrors.ErrInvalidInput.WithMessage("player is not on loan")
+		}
+		if parent == nil {
+			return nil, nil, apperrors.ErrInvalidInput.WithMessage("player has no parent team to return to")
+		}
+		if transfer.ToTeam == "" {
+			transfer.ToTeam = parent.Team
+		}
+		if transfer.ToTeam != parent.Team {
+			return nil, nil, apperrors.ErrInvalidInput.WithMessage(fmt.Sprintf("a loan return goes back to the parent team %s", parent.Team))
+		}
+		transfer.FromTeam = loan.Team
+		ended = []domain.Contract{*loan}
+	}
+
+	if transfer.ToTeam == transfer.FromTeam {
+		return nil, nil, apperrors.ErrInvalidInput.WithMessage(fmt.Sprintf("player already plays for %s", transfer.ToTeam))
+	}
+	return ended, signed, nil
+}
@@ -0,0 +1,87 @@
+package usecases
+
+import (
+	"football-team-management/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanTransfer(t *testing.T) {
+	day := func(m, d int) time.Time { return time.Date(2024, time.Month(m), d, 0, 0, 0, 0, time.UTC) }
+	loanEnd := day(12, 31)
+	permanent := domain.Contract{ID: 1, Player: "Andi", Team: "Persib", Type: domain.ContractPermanent, StartDate: day(1, 1)}
+	loan := domain.Contract{ID: 2, Player: "Andi", Team: "PSM", Type: domain.ContractLoan, StartDate: day(2, 1), LoanEndDate: &loanEnd}
+	transfer := func(transferType domain.TransferType, to string) *domain.Transfer {
+		return &domain.Transfer{Player: "Andi", ToTeam: to, TransferDate: day(7, 1), Type: transferType, JerseyNumber: 10}
+	}
+
+	t.Run("Permanent transfer ends the current contract", func(t *testing.T) {
+		tr := transfer(domain.TransferPermanent, "Persija")
+		ended, signed, err := planTransfer(tr, "Persib", []domain.Contract{permanent})
+		require.NoError(t, err)
+
+		assert.Equal(t, "Persib", tr.FromTeam)
+		assert.Equal(t, []domain.Contract{permanent}, ended)
+		assert.Equal(t, &domain.Contract{Player: "Andi", Team: "Persija", Type: domain.ContractPermanent, StartDate: day(7, 1)}, signed)
+	})
+
+	t.Run("Loan keeps the parent contract", func(t *testing.T) {
+		tr := transfer(domain.TransferLoan, "PSM")
+		tr.LoanEndDate = &loanEnd
+		ended, signed, err := planTransfer(tr, "Persib", []domain.Contract{permanent})
+		require.NoError(t, err)
+
+		assert.Empty(t, ended)
+		assert.Equal(t, domain.ContractLoan, signed.Type)
+		assert.Equal(t, &loanEnd, signed.LoanEndDate)
+	})
+
+	t.Run("Cannot loan a player who is already on loan", func(t *testing.T) {
+		tr := transfer(domain.TransferLoan, "Persija")
+		tr.LoanEndDate = &loanEnd
+		_, _, err := planTransfer(tr, "PSM", []domain.Contract{permanent, loan})
+		assert.EqualError(t, err, "player is already on loan at PSM")
+	})
+
+	t.Run("Loan return goes back to the parent team", func(t *testing.T) {
+		tr := transfer(domain.TransferLoanReturn, "")
+		ended, signed, err := planTransfer(tr, "PSM", []domain.Contract{permanent, loan})
+		require.NoError(t, err)
+
+		assert.Equal(t, "PSM", tr.FromTeam)
+		assert.Equal(t, "Persib", tr.ToTeam)
+		assert.Equal(t, []domain.Contract{loan}, ended)
+		assert.Nil(t, signed)
+
+		_, _, err = planTransfer(transfer(domain.TransferLoanReturn, "Persija"), "PSM", []domain.Contract{permanent, loan})
+		assert.EqualError(t, err, "a loan return goes back to the parent team Persib")
+
+		_, _, err = planTransfer(transfer(domain.TransferLoanReturn, ""), "Persib", []domain.Contract{permanent})
+		assert.EqualError(t, err, "player is not on loan")
+	})
+
+	t.Run("Loan team can buy the player from the parent team", func(t *testing.T) {
+		tr := transfer(domain.TransferPermanent, "PSM")
+		ended, signed, err := planTransfer(tr, "PSM", []domain.Contract{permanent, loan})
+		require.NoError(t, err)
+
+		assert.Equal(t, "Persib", tr.FromTeam)
+		assert.Equal(t, []domain.Contract{permanent, loan}, ended)
+		assert.Equal(t, "PSM", signed.Team)
+	})
+
+	t.Run("Cannot transfer to the current team", func(t *testing.T) {
+		_, _, err := planTransfer(transfer(domain.TransferPermanent, "Persib"), "Persib", []domain.Contract{permanent})
+		assert.EqualError(t, err, "player already plays for Persib")
+	})
+
+	t.Run("Cannot transfer before the current contract started", func(t *testing.T) {
+		tr := transfer(domain.TransferPermanent, "Persija")
+		tr.TransferDate = day(1, 15)
+		_, _, err := planTransfer(tr, "PSM", []domain.Contract{permanent, loan})
+		assert.EqualError(t, err, "transfer date cannot be before the player joined PSM on 2024-02-01")
+	})
+}
@@ -0,0 +1,88 @@
+package usecases
+
+import (
+	"context"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TransferWindowRepository interface {
+	Register(ctx context.Context, window domain.TransferWindow) (int, error)
+	Delete(ctx context.Context, id int) error
+	List(ctx context.Context, seasonID *int) ([]domain.TransferWindow, error)
+}
+
+type PostgresTransferWindowRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresTransferWindowRepo(pool *pgxpool.Pool) *PostgresTransferWindowRepo {
+	return &PostgresTransferWindowRepo{pool: pool}
+}
+
+func (r *PostgresTransferWindowRepo) Register(ctx context.Context, window domain.TransferWindow) (int, error) {
+	// Check if season exists
+	var seasonExists bool
+	err := r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM seasons WHERE id = $1 AND deleted_at IS NULL)`, window.SeasonID).Scan(&seasonExists)
+	if err != nil {
+		return 0, err
+	}
+	if !seasonExists {
+		return 0, apperrors.ErrSeasonNotFound
+	}
+
+	// Check the window does not overlap another window of the season
+	var overlaps bool
+	err = r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM transfer_windows WHERE season_id = $1 AND start_date <= $3 AND end_date >= $2 AND deleted_at IS NULL)`,
+		window.SeasonID, window.StartDate, window.EndDate).Scan(&overlaps)
+	if err != nil {
+		return 0, err
+	}
+	if overlaps {
+		return 0, apperrors.ErrTransferWindowOverlap
+	}
+
+	now := time.Now()
+	var id int
+	err = r.pool.QueryRow(ctx, `INSERT INTO transfer_windows (season_id, name, start_date, end_date, created_at, updated_at, deleted_at) VALUES ($1, $2, $3, $4, $5, $6, NULL) RETURNING id`,
+		window.SeasonID, window.Name, window.StartDate, window.EndDate, now, now).Scan(&id)
+	return id, dbError(err)
+}
+
+func (r *PostgresTransferWindowRepo) Delete(ctx context.Context, id int) error {
+	now := time.Now()
+	cmd, err := r.pool.Exec(ctx, `UPDATE transfer_windows SET deleted_at=$1, updated_at=$2 WHERE id=$3 AND deleted_at IS NULL`, now, now, id)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return apperrors.ErrTransferWindowNotFound
+	}
+	return nil
+}
+
+// List returns the windows of all seasons, or of one season if seasonID is given, in date order
+func (r *PostgresTransferWindowRepo) List(ctx context.Context, seasonID *int) ([]domain.TransferWindow, error) {
+	rows, err := r.pool.Query(ctx, `SELECT id, season_id, name, start_date, end_date, created_at, updated_at, deleted_at FROM transfer_windows WHERE ($1::int IS NULL OR season_id = $1) AND deleted_at IS NULL ORDER BY start_date`, seasonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var windows []domain.TransferWindow
+	for rows.Next() {
+		var w domain.TransferWindow
+		var deletedAt *time.Time
+		if err := rows.Scan(&w.ID, &w.SeasonID, &w.Name, &w.StartDate, &w.EndDate, &w.CreatedAt, &w.UpdatedAt, &deletedAt); err != nil {
+			return nil, err
+		}
+		w.DeletedAt = deletedAt
+		windows = append(windows, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return windows, nil
+}
@@ -0,0 +1,202 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TrashRepository lists the soft-deleted teams, players, matches and results together and removes them
+// for good. Only deleted records can be purged, active ones must be deleted first
+type TrashRepository interface {
+	List(ctx context.Context, query domain.TrashQuery) (*domain.Page[domain.TrashItem], error)
+	Purge(ctx context.Context, itemType string, id int) error
+	PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (*domain.PurgeReport, error)
+}
+
+type PostgresTrashRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresTrashRepo(pool *pgxpool.Pool) *PostgresTrashRepo {
+	return &PostgresTrashRepo{pool: pool}
+}
+
+// trashFrom selects every soft-deleted record as a row of type, id, name and deleted_at
+const trashFrom = ` FROM (
+	SELECT 'team' AS type, id, name, deleted_at FROM teams WHERE deleted_at IS NOT NULL
+	UNION ALL SELECT 'player', id, name, deleted_at FROM players WHERE deleted_at IS NOT NULL
+	UNION ALL SELECT 'match', m.id, home.name || ' vs ' || away.name, m.deleted_at` + matchFrom + ` WHERE m.deleted_at IS NOT NULL
+	UNION ALL SELECT 'match_result', r.id, home.name || ' vs ' || away.name, r.deleted_at FROM match_results r JOIN matches m ON m.id = r.match_id
+		JOIN teams home ON home.id = m.home_team_id JOIN teams away ON away.id = m.away_team_id WHERE r.deleted_at IS NOT NULL
+) trash WHERE ($1::text IS NULL OR type = $1)`
+
+// trashSortColumns maps the sort fields of domain.TrashSortFields to columns
+var trashSortColumns = map[string][]string{
+	"deleted_at": {"deleted_at", "type"},
+	"type":       {"type"},
+	"name":       {"name", "type"},
+	"id":         nil,
+}
+
+func (r *PostgresTrashRepo) List(ctx context.Context, query domain.TrashQuery) (*domain.Page[domain.TrashItem], error) {
+	page := query.PageRequest.Normalized(domain.DefaultTrashSort)
+	itemType := optional(query.Type)
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT count(*)`+trashFrom, itemType).Scan(&total); err != nil {
+		return nil, err
+	}
+	rows, err := r.pool.Query(ctx, `SELECT type, id, name, deleted_at`+trashFrom+orderBy(page, trashSortColumns, "id")+` LIMIT $2 OFFSET $3`, itemType, page.Limit, page.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []domain.TrashItem
+	for rows.Next() {
+		var item domain.TrashItem
+		if err := rows.Scan(&item.Type, &item.ID, &item.Name, &item.DeletedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return domain.NewPage(items, total, page), nil
+}
+
+// trashTables holds the table of each kind of record in the trash
+var trashTables = map[string]string{
+	domain.TrashTeam:        "teams",
+	domain.TrashPlayer:      "players",
+	domain.TrashMatch:       "matches",
+	domain.TrashMatchResult: "match_results",
+}
+
+// purgeDependents lists per kind the statements removing the rows that only exist for the purged record,
+// run with its ID before the record itself. Rows that still matter elsewhere, such as the matches of a
+// team, are left alone and keep the record from being purged
+var purgeDependents = map[string][]string{
+	domain.TrashTeam: {
+		`DELETE FROM user_teams WHERE team_id = $1`,
+		`DELETE FROM team_ratings WHERE team_id = $1`,
+	},
+	domain.TrashPlayer: {
+		`DELETE FROM contracts WHERE player_id = $1`,
+		`DELETE FROM transfers WHERE player_id = $1`,
+	},
+	// An active result still counts and its rating history belongs to the rating service, which drops it
+	// when the match is deleted. Both keep the match through their foreign keys until they are gone
+	domain.TrashMatch: {
+		`DELETE FROM match_events WHERE match_id = $1`,
+		`DELETE FROM shootout_kicks WHERE match_id = $1`,
+		`DELETE FROM match_results WHERE match_id = $1 AND deleted_at IS NOT NULL`,
+	},
+	// The goals stay on the match timeline, the shootout kicks go unless another result uses them
+	domain.TrashMatchResult: {
+		`DELETE FROM shootout_kicks k USING match_results r WHERE r.id = $1 AND k.match_id = r.match_id
+			AND NOT EXISTS(SELECT 1 FROM match_results a WHERE a.match_id = r.match_id AND a.deleted_at IS NULL)`,
+	},
+}
+
+// Purge removes a soft-deleted record for good, together with the rows that only exist for it. A record
+// other records still refer to is reported as REFERENCE_VIOLATION
+func (r *PostgresTrashRepo) Purge(ctx context.Context, itemType string, id int) error {
+	table, ok := trashTables[itemType]
+	if !ok {
+		return apperrors.ErrInvalidInput.WithMessage("invalid type " + itemType)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var deleted bool
+	if err := tx.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM `+table+` WHERE id = $1 AND deleted_at IS NOT NULL)`, id).Scan(&deleted); err != nil {
+		return err
+	}
+	if !deleted {
+		return notInTrash(itemType)
+	}
+
+	for _, statement := range purgeDependents[itemType] {
+		if _, err := tx.Exec(ctx, statement, id); err != nil {
+			return dbError(err)
+		}
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM `+table+` WHERE id = $1`, id); err != nil {
+		return dbError(err)
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *PostgresTrashRepo) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (*domain.PurgeReport, error) {
+	return purgeDeletedBefore(ctx, r, cutoff)
+}
+
+func (r *PostgresTrashRepo) deletedBefore(ctx context.Context, itemType string, cutoff time.Time) ([]int, error) {
+	rows, err := r.pool.Query(ctx, `SELECT id FROM `+trashTables[itemType]+` WHERE deleted_at < $1 ORDER BY id`, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// trashPurger is implemented by the trash repositories, so they share the retention run
+type trashPurger interface {
+	Purge(ctx context.Context, itemType string, id int) error
+	deletedBefore(ctx context.Context, itemType string, cutoff time.Time) ([]int, error)
+}
+
+// purgeDeletedBefore purges every record deleted before cutoff, kind by kind in the order of
+// domain.TrashTypes. Records still referred to are kept and counted, any other error stops the run
+func purgeDeletedBefore(ctx context.Context, trash trashPurger, cutoff time.Time) (*domain.PurgeReport, error) {
+	report := &domain.PurgeReport{Purged: make(map[string]int), Kept: make(map[string]int)}
+	for _, itemType := range domain.TrashTypes {
+		ids, err := trash.deletedBefore(ctx, itemType, cutoff)
+		if err != nil {
+			return report, err
+		}
+		for _, id := range ids {
+			err := trash.Purge(ctx, itemType, id)
+			switch {
+			case errors.Is(err, apperrors.ErrReferenceViolation):
+				report.Kept[itemType]++
+			case err != nil:
+				return report, err
+			default:
+				report.Purged[itemType]++
+			}
+		}
+	}
+	return report, nil
+}
+
+// notInTrash is the error of purging a record that does not exist or was not deleted
+func notInTrash(itemType string) error {
+	notFound := map[string]*apperrors.AppError{
+		domain.TrashTeam:        apperrors.ErrTeamNotFound,
+		domain.TrashPlayer:      apperrors.ErrPlayerNotFound,
+		domain.TrashMatch:       apperrors.ErrMatchNotFound,
+		domain.TrashMatchResult: apperrors.ErrMatchResultNotFound,
+	}[itemType]
+	return notFound.WithMessage(notFound.Message + " or not deleted")
+}
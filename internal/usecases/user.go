@@ -0,0 +1,268 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"football-team-management/internal/domain/user"
+	apperrors "football-team-management/internal/pkg/errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type UserRepository interface {
+	Register(ctx context.Context, u user.User) error
+	List(ctx context.Context) ([]user.User, error)
+	GetByUsername(ctx context.Context, username string) (*user.User, error)
+	SetTeams(ctx context.Context, username string, teams []string) error
+	SetDisabled(ctx context.Context, username string, disabled bool) error
+	Delete(ctx context.Context, username string) error
+}
+
+type PostgresUserRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresUserRepo(pool *pgxpool.Pool) *PostgresUserRepo {
+	return &PostgresUserRepo{pool: pool}
+}
+
+func (r *PostgresUserRepo) Register(ctx context.Context, u user.User) error {
+	if !user.ValidRole(u.Role) {
+		return apperrors.ErrInvalidInput.WithMessage("invalid role")
+	}
+	if err := validateUserTeams(u.Role, u.Teams); err != nil {
+		return err
+	}
+
+	// Check if username is already taken
+	var userExists bool
+	err := r.pool.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE username = $1)`, u.Username).Scan(&userExists)
+	if err != nil {
+		return err
+	}
+	if userExists {
+		return apperrors.ErrUserAlreadyExists
+	}
+
+	// Start transaction
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	now := time.Now()
+	_, err = tx.Exec(ctx, `INSERT INTO users (username, password_hash, role, disabled, created_at, updated_at, deleted_at) VALUES ($1, $2, $3, FALSE, $4, $5, NULL)`,
+		u.Username, u.Password, u.Role, now, now)
+	if err != nil {
+		return dbError(err)
+	}
+
+	if err := replaceUserTeams(ctx, tx, u.Username, u.Teams); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *PostgresUserRepo) List(ctx context.Context) ([]user.User, error) {
+	rows, err := r.pool.Query(ctx, `SELECT username, password_hash, role, disabled, created_at, updated_at, deleted_at FROM users WHERE deleted_at IS NULL ORDER BY username`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var users []user.User
+	for rows.Next() {
+		var u user.User
+		var deletedAt *time.Time
+		if err := rows.Scan(&u.Username, &u.Password, &u.Role, &u.Disabled, &u.CreatedAt, &u.UpdatedAt, &deletedAt); err != nil {
+			return nil, err
+		}
+		u.DeletedAt = deletedAt
+		users = append(users, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Attach assigned teams
+	teams, err := r.teamsByUsername(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range users {
+		users[i].Teams = teams[users[i].Username]
+	}
+	return users, nil
+}
+
+func (r *PostgresUserRepo) GetByUsername(ctx context.Context, username string) (*user.User, error) {
+	var u user.User
+	var deletedAt *time.Time
+	err := r.pool.QueryRow(ctx, `SELECT username, password_hash, role, disabled, created_at, updated_at, deleted_at FROM users WHERE username = $1 AND deleted_at IS NULL`, username).
+		Scan(&u.Username, &u.Password, &u.Role, &u.Disabled, &u.CreatedAt, &u.UpdatedAt, &deletedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, apperrors.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	u.DeletedAt = deletedAt
+
+	rows, err := r.pool.Query(ctx, `SELECT t.name FROM user_teams ut JOIN teams t ON t.id = ut.team_id WHERE ut.username = $1 ORDER BY t.name`, username)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var team string
+		if err := rows.Scan(&team); err != nil {
+			return nil, err
+		}
+		u.Teams = append(u.Teams, team)
+	}
+	return &u, rows.Err()
+}
+
+func (r *PostgresUserRepo) SetTeams(ctx context.Context, username string, teams []string) error {
+	// Start transaction
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var role string
+	err = tx.QueryRow(ctx, `SELECT role FROM users WHERE username = $1 AND deleted_at IS NULL FOR UPDATE`, username).Scan(&role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return apperrors.ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if err := validateUserTeams(role, teams); err != nil {
+		return err
+	}
+
+	if err := replaceUserTeams(ctx, tx, username, teams); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE users SET updated_at=$1 WHERE username=$2`, time.Now(), username)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *PostgresUserRepo) SetDisabled(ctx context.Context, username string, disabled bool) error {
+	now := time.Now()
+	cmd, err := r.pool.Exec(ctx, `UPDATE users SET disabled=$1, updated_at=$2 WHERE username=$3 AND deleted_at IS NULL`, disabled, now, username)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return apperrors.ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *PostgresUserRepo) Delete(ctx context.Context, username string) error {
+	now := time.Now()
+	cmd, err := r.pool.Exec(ctx, `UPDATE users SET deleted_at=$1, updated_at=$2 WHERE username=$3 AND deleted_at IS NULL`, now, now, username)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return apperrors.ErrUserNotFound
+	}
+	return nil
+}
+
+// Helper method to load the team assignments of every user in one query
+func (r *PostgresUserRepo) teamsByUsername(ctx context.Context) (map[string][]string, error) {
+	rows, err := r.pool.Query(ctx, `SELECT ut.username, t.name FROM user_teams ut JOIN teams t ON t.id = ut.team_id ORDER BY ut.username, t.name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	teams := make(map[string][]string)
+	for rows.Next() {
+		var username, team string
+		if err := rows.Scan(&username, &team); err != nil {
+			return nil, err
+		}
+		teams[username] = append(teams[username], team)
+	}
+	return teams, rows.Err()
+}
+
+// replaceUserTeams swaps the team assignments of a user inside tx, checking every team exists
+func replaceUserTeams(ctx context.Context, tx pgx.Tx, username string, teams []string) error {
+	_, err := tx.Exec(ctx, `DELETE FROM user_teams WHERE username = $1`, username)
+	if err != nil {
+		return err
+	}
+
+	for _, team := range teams {
+		var teamExists bool
+		err := tx.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM teams WHERE name = $1 AND deleted_at IS NULL)`, team).Scan(&teamExists)
+		if err != nil {
+			return err
+		}
+		if !teamExists {
+			return apperrors.ErrTeamNotFound.WithMessage("team not found: " + team)
+		}
+
+		_, err = tx.Exec(ctx, `INSERT INTO user_teams (username, team_id) VALUES ($1, (SELECT id FROM teams WHERE name = $2)) ON CONFLICT DO NOTHING`, username, team)
+		if err != nil {
+			return dbError(err)
+		}
+	}
+	return nil
+}
+
+// validateUserTeams checks that teams are only assigned to, and always assigned to, team managers
+func validateUserTeams(role string, teams []string) error {
+	if role == user.RoleTeamManager && len(teams) == 0 {
+		return apperrors.ErrInvalidInput.WithMessage("team managers must be assigned at least one team")
+	}
+	if role != user.RoleTeamManager && len(teams) > 0 {
+		return apperrors.ErrInvalidInput.WithMessage("only team managers can be assigned teams")
+	}
+	return nil
+}
+
+// BootstrapAdmin creates an admin account with the given credentials unless the
+// username is already taken, so a fresh database always has someone who can log in.
+// A username that belongs to a deleted account is reported as ErrUserAlreadyExists,
+// deleted accounts are never brought back by a restart
+func BootstrapAdmin(ctx context.Context, repo UserRepository, username, password string) error {
+	_, err := repo.GetByUsername(ctx, username)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, apperrors.ErrUserNotFound) {
+		return err
+	}
+
+	hash, err := HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	err = repo.Register(ctx, user.User{
+		Username: username,
+		Password: hash,
+		Role:     user.RoleAdmin,
+	})
+	if errors.Is(err, apperrors.ErrUserAlreadyExists) {
+		// Not found above but taken, so the account was deleted
+		return apperrors.ErrUserAlreadyExists.WithMessage("username " + username + " belongs to a deleted account")
+	}
+	return err
+}
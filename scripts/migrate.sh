@@ -0,0 +1,6 @@
+#!/bin/bash
+
+# Usage: ./scripts/migrate.sh up | down [steps] | status
+# Needs DATABASE_URL, see run.sh
+
+go run ./cmd/web migrate "$@"
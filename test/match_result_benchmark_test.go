@@ -0,0 +1,87 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"football-team-management/internal/domain"
+	"football-team-management/internal/usecases"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// BenchmarkPostgresMatchResultList lists the results of a double round-robin season of 20 teams, 380
+// matches with three goals each, from the database at TEST_DATABASE_URL. "PerResult" loads every
+// result on its own the way the list used to, for comparison with the batched loading of List and Search
+func BenchmarkPostgresMatchResultList(b *testing.B) {
+	pool := testPool(b)
+	emptyDatabase(b, pool)
+	repos := usecases.NewPostgresRepositories(pool)
+	matchIDs := seedSeason(b, repos, 20)
+
+	ctx := context.Background()
+	b.Run("List", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			results, err := repos.MatchResults.List(ctx)
+			require.NoError(b, err)
+			require.Len(b, results, len(matchIDs))
+		}
+	})
+
+	b.Run("Search", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			page, err := repos.MatchResults.Search(ctx, domain.MatchResultQuery{PageRequest: domain.PageRequest{Limit: domain.MaxPageLimit}})
+			require.NoError(b, err)
+			require.Len(b, page.Items, domain.MaxPageLimit)
+		}
+	})
+
+	b.Run("PerResult", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, matchID := range matchIDs {
+				_, err := repos.MatchResults.GetByMatchID(ctx, matchID)
+				require.NoError(b, err)
+			}
+		}
+	})
+}
+
+// seedSeason registers teams, each with one player, plays a double round-robin between them in which
+// the home side wins 2-1, and returns the IDs of the matches
+func seedSeason(b *testing.B, repos *usecases.Repositories, teams int) []int {
+	ctx := context.Background()
+	names := make([]string, teams)
+	for i := range names {
+		names[i] = fmt.Sprintf("Team %02d", i+1)
+		_, err := repos.Teams.Register(ctx, domain.Team{Name: names[i]})
+		require.NoError(b, err)
+		_, err = repos.Players.Register(ctx, domain.Player{Name: "Striker " + names[i], JerseyNumber: 9, TeamName: names[i]})
+		require.NoError(b, err)
+	}
+
+	var matchIDs []int
+	day := date(2024, 8, 1)
+	for _, home := range names {
+		for _, away := range names {
+			if home == away {
+				continue
+			}
+			matchID, err := repos.Matches.Register(ctx, domain.Match{MatchDate: day, MatchTime: "19:00", HomeTeam: home, AwayTeam: away})
+			require.NoError(b, err)
+			for _, status := range []domain.MatchStatus{domain.MatchLive, domain.MatchFinished} {
+				_, err := repos.Matches.UpdateStatus(ctx, matchID, status)
+				require.NoError(b, err)
+			}
+
+			goals := []domain.Goal{
+				{Scorer: "Striker " + home, GoalTime: "10:00", Team: "home"},
+				{Scorer: "Striker " + away, GoalTime: "40:00", Team: "away"},
+				{Scorer: "Striker " + home, GoalTime: "80:00", Team: "home"},
+			}
+			require.NoError(b, repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: matchID, HomeScore: 2, AwayScore: 1, Goals: goals}))
+			matchIDs = append(matchIDs, matchID)
+			day = day.AddDate(0, 0, 1)
+		}
+	}
+	return matchIDs
+}
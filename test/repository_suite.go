@@ -0,0 +1,1292 @@
+package test
+
+import (
+	"context"
+	"football-team-management/internal/domain"
+	apperrors "football-team-management/internal/pkg/errors"
+	"football-team-management/internal/usecases"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// NewRepositories returns repositories of the backend under test sharing one empty store. It is called
+// once per subtest, so every case starts from scratch
+type NewRepositories func(t *testing.T) *usecases.Repositories
+
+// RunRepositorySuite runs every repository contract against the backend returned by newRepos
+func RunRepositorySuite(t *testing.T, newRepos NewRepositories) {
+	t.Run("Teams", func(t *testing.T) { RunTeamRepositorySuite(t, newRepos) })
+	t.Run("Players", func(t *testing.T) { RunPlayerRepositorySuite(t, newRepos) })
+	t.Run("Matches", func(t *testing.T) { RunMatchRepositorySuite(t, newRepos) })
+	t.Run("MatchResults", func(t *testing.T) { RunMatchResultRepositorySuite(t, newRepos) })
+	t.Run("Transfers", func(t *testing.T) { RunTransferRepositorySuite(t, newRepos) })
+	t.Run("Competitions", func(t *testing.T) { RunCompetitionRepositorySuite(t, newRepos) })
+	t.Run("Trash", func(t *testing.T) { RunTrashRepositorySuite(t, newRepos) })
+}
+
+// RunTeamRepositorySuite checks that a TeamRepository registers, updates, deletes and restores teams
+// the way every backend must
+func RunTeamRepositorySuite(t *testing.T, newRepos NewRepositories) {
+	ctx := context.Background()
+
+	t.Run("Register assigns an ID and a slug", func(t *testing.T) {
+		repo := newRepos(t).Teams
+		id, err := repo.Register(ctx, domain.Team{Name: "Bali United", Logo: "bali.png", YearFounded: 1989, StadiumAddr: "Dipta", City: "Gianyar"})
+		require.NoError(t, err)
+
+		for _, key := range []string{strconv.Itoa(id), "bali-united"} {
+			team, err := repo.GetByKey(ctx, key)
+			require.NoError(t, err)
+			assert.Equal(t, id, team.ID)
+			assert.Equal(t, "bali-united", team.Slug)
+			assert.Equal(t, "Bali United", team.Name)
+			assert.Equal(t, "bali.png", team.Logo)
+			assert.Equal(t, 1989, team.YearFounded)
+			assert.Equal(t, "Dipta", team.StadiumAddr)
+			assert.Equal(t, "Gianyar", team.City)
+			assert.False(t, team.CreatedAt.IsZero())
+			assert.Nil(t, team.DeletedAt)
+		}
+	})
+
+	t.Run("Register rejects a name in use", func(t *testing.T) {
+		repo := newRepos(t).Teams
+		registerTeams(t, repo, "Persija", "Persib")
+		_, err := repo.Register(ctx, domain.Team{Name: "Persija"})
+		assert.EqualError(t, err, "team already exists")
+		assert.ErrorIs(t, err, apperrors.ErrTeamAlreadyExists)
+
+		// Deleted teams keep their name
+		require.NoError(t, repo.Delete(ctx, "persib"))
+		_, err = repo.Register(ctx, domain.Team{Name: "Persib"})
+		assert.EqualError(t, err, "team already exists")
+		assert.ErrorIs(t, err, apperrors.ErrTeamAlreadyExists)
+	})
+
+	t.Run("Slugs stay unique", func(t *testing.T) {
+		repo := newRepos(t).Teams
+		registerTeams(t, repo, "Bali United", "Bali-United")
+		team, err := repo.GetByKey(ctx, "bali-united-2")
+		require.NoError(t, err)
+		assert.Equal(t, "Bali-United", team.Name)
+	})
+
+	t.Run("List returns active teams in order of registration", func(t *testing.T) {
+		repo := newRepos(t).Teams
+		registerTeams(t, repo, "Persija", "Persib", "Arema")
+		require.NoError(t, repo.Delete(ctx, "persib"))
+
+		teams, err := repo.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, teams, 2)
+		assert.Equal(t, "Persija", teams[0].Name)
+		assert.Equal(t, "Arema", teams[1].Name)
+	})
+
+	t.Run("Search filters by city and pages through the sorted teams", func(t *testing.T) {
+		repo := newRepos(t).Teams
+		for _, team := range []domain.Team{{Name: "Persija", City: "Jakarta"}, {Name: "Persib", City: "Bandung"}, {Name: "Bhayangkara", City: "jakarta"}, {Name: "Arema", City: "Malang"}} {
+			_, err := repo.Register(ctx, team)
+			require.NoError(t, err)
+		}
+		require.NoError(t, repo.Delete(ctx, "arema"))
+
+		page, err := repo.Search(ctx, domain.TeamQuery{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Persija", "Persib", "Bhayangkara"}, teamNames(page.Items))
+		assert.Equal(t, 3, page.Total)
+		assert.Equal(t, domain.DefaultPageLimit, page.Limit)
+
+		page, err = repo.Search(ctx, domain.TeamQuery{PageRequest: domain.PageRequest{Limit: 2, Offset: 1, Sort: "-name"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Persib", "Bhayangkara"}, teamNames(page.Items))
+		assert.Equal(t, 3, page.Total)
+
+		page, err = repo.Search(ctx, domain.TeamQuery{City: "JAKARTA", PageRequest: domain.PageRequest{Sort: "name"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Bhayangkara", "Persija"}, teamNames(page.Items))
+		assert.Equal(t, 2, page.Total)
+
+		page, err = repo.Search(ctx, domain.TeamQuery{PageRequest: domain.PageRequest{Offset: 5}})
+		require.NoError(t, err)
+		assert.Empty(t, page.Items)
+		assert.NotNil(t, page.Items)
+		assert.Equal(t, 3, page.Total)
+	})
+
+	t.Run("Search lists deleted teams on request", func(t *testing.T) {
+		repo := newRepos(t).Teams
+		registerTeams(t, repo, "Persija", "Persib", "Arema")
+		require.NoError(t, repo.Delete(ctx, "persib"))
+
+		page, err := repo.Search(ctx, domain.TeamQuery{Deleted: domain.IncludeDeleted})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Persija", "Persib", "Arema"}, teamNames(page.Items))
+
+		page, err = repo.Search(ctx, domain.TeamQuery{Deleted: domain.OnlyDeleted})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Persib"}, teamNames(page.Items))
+		assert.Equal(t, 1, page.Total)
+		assert.NotNil(t, page.Items[0].DeletedAt)
+	})
+
+	t.Run("Update changes details and keeps the ID and slug", func(t *testing.T) {
+		repo := newRepos(t).Teams
+		ids := registerTeams(t, repo, "Persija")
+		require.NoError(t, repo.Update(ctx, "persija", domain.Team{Name: "Persija Jakarta", City: "Jakarta"}))
+
+		team, err := repo.GetByKey(ctx, strconv.Itoa(ids[0]))
+		require.NoError(t, err)
+		assert.Equal(t, "Persija Jakarta", team.Name)
+		assert.Equal(t, "Jakarta", team.City)
+		assert.Equal(t, "persija", team.Slug)
+		assert.False(t, team.UpdatedAt.Before(team.CreatedAt))
+
+		// Keeping its own name is not a clash
+		require.NoError(t, repo.Update(ctx, "persija", domain.Team{Name: "Persija Jakarta", City: "Jakarta Utara"}))
+	})
+
+	t.Run("Update rejects a name taken by another team", func(t *testing.T) {
+		repo := newRepos(t).Teams
+		registerTeams(t, repo, "Persija", "Persib")
+		assert.EqualError(t, repo.Update(ctx, "persija", domain.Team{Name: "Persib"}), "team name already taken")
+	})
+
+	t.Run("Update fails for unknown and deleted teams", func(t *testing.T) {
+		repo := newRepos(t).Teams
+		registerTeams(t, repo, "Persija")
+		assert.EqualError(t, repo.Update(ctx, "arema", domain.Team{Name: "Arema FC"}), "team not found")
+
+		require.NoError(t, repo.Delete(ctx, "persija"))
+		assert.EqualError(t, repo.Update(ctx, "persija", domain.Team{Name: "Persija Jakarta"}), "team not found")
+	})
+
+	t.Run("Delete hides the team", func(t *testing.T) {
+		repo := newRepos(t).Teams
+		ids := registerTeams(t, repo, "Persija")
+		require.NoError(t, repo.Delete(ctx, strconv.Itoa(ids[0])))
+
+		_, err := repo.GetByKey(ctx, "persija")
+		assert.EqualError(t, err, "team not found")
+		assert.ErrorIs(t, err, apperrors.ErrTeamNotFound)
+		assert.EqualError(t, repo.Delete(ctx, "persija"), "team not found")
+		assert.EqualError(t, repo.Delete(ctx, "arema"), "team not found")
+	})
+
+	t.Run("Restore brings a deleted team back", func(t *testing.T) {
+		repo := newRepos(t).Teams
+		ids := registerTeams(t, repo, "Persija")
+		assert.EqualError(t, repo.Restore(ctx, "persija"), "team not found or not deleted")
+		assert.EqualError(t, repo.Restore(ctx, "arema"), "team not found or not deleted")
+
+		require.NoError(t, repo.Delete(ctx, "persija"))
+		require.NoError(t, repo.Restore(ctx, "persija"))
+		team, err := repo.GetByKey(ctx, strconv.Itoa(ids[0]))
+		require.NoError(t, err)
+		assert.Nil(t, team.DeletedAt)
+		assert.EqualError(t, repo.Restore(ctx, "persija"), "team not found or not deleted")
+	})
+}
+
+// RunPlayerRepositorySuite checks that a PlayerRepository registers, updates, deletes and restores players
+// the way every backend must
+func RunPlayerRepositorySuite(t *testing.T, newRepos NewRepositories) {
+	ctx := context.Background()
+	andi := domain.Player{Name: "Andi", Height: 175, Weight: 70, Position: domain.PositionMidfielder, JerseyNumber: 8, TeamName: "Persija"}
+
+	t.Run("Register assigns an ID and a slug", func(t *testing.T) {
+		repos := newRepos(t)
+		registerTeams(t, repos.Teams, "Persija")
+		id, err := repos.Players.Register(ctx, andi)
+		require.NoError(t, err)
+
+		for _, key := range []string{strconv.Itoa(id), "andi"} {
+			player, err := repos.Players.GetByKey(ctx, key)
+			require.NoError(t, err)
+			assert.Equal(t, id, player.ID)
+			assert.Equal(t, "andi", player.Slug)
+			assert.Equal(t, 175, player.Height)
+			assert.Equal(t, 70, player.Weight)
+			assert.Equal(t, domain.PositionMidfielder, player.Position)
+			assert.Equal(t, 8, player.JerseyNumber)
+			assert.Equal(t, "Persija", player.TeamName)
+			assert.False(t, player.CreatedAt.IsZero())
+			assert.Nil(t, player.DeletedAt)
+		}
+	})
+
+	t.Run("Register needs an active team", func(t *testing.T) {
+		repos := newRepos(t)
+		registerTeams(t, repos.Teams, "Persija")
+		_, err := repos.Players.Register(ctx, withTeam(andi, "Arema"))
+		assert.EqualError(t, err, "team not found")
+		assert.ErrorIs(t, err, apperrors.ErrTeamNotFound)
+
+		require.NoError(t, repos.Teams.Delete(ctx, "persija"))
+		_, err = repos.Players.Register(ctx, andi)
+		assert.EqualError(t, err, "team not found")
+		assert.ErrorIs(t, err, apperrors.ErrTeamNotFound)
+	})
+
+	t.Run("Register rejects a jersey number taken in the team", func(t *testing.T) {
+		repos := newRepos(t)
+		registerTeams(t, repos.Teams, "Persija", "Persib")
+		_, err := repos.Players.Register(ctx, andi)
+		require.NoError(t, err)
+
+		riko := domain.Player{Name: "Riko", JerseyNumber: andi.JerseyNumber, TeamName: "Persija"}
+		_, err = repos.Players.Register(ctx, riko)
+		assert.EqualError(t, err, "jersey number already taken in this team")
+		assert.ErrorIs(t, err, apperrors.ErrJerseyNumberTaken)
+
+		// Other teams may hand out the same number
+		_, err = repos.Players.Register(ctx, withTeam(riko, "Persib"))
+		require.NoError(t, err)
+	})
+
+	t.Run("Register rejects a name in use", func(t *testing.T) {
+		repos := newRepos(t)
+		registerTeams(t, repos.Teams, "Persija", "Persib")
+		_, err := repos.Players.Register(ctx, andi)
+		require.NoError(t, err)
+
+		_, err = repos.Players.Register(ctx, domain.Player{Name: "Andi", JerseyNumber: 11, TeamName: "Persib"})
+		assert.EqualError(t, err, "player already exists")
+		assert.ErrorIs(t, err, apperrors.ErrPlayerAlreadyExists)
+	})
+
+	t.Run("List and ListByTeam return active players in order of registration", func(t *testing.T) {
+		repos := newRepos(t)
+		teamIDs := registerTeams(t, repos.Teams, "Persija", "Persib")
+		for _, player := range []domain.Player{andi, {Name: "Ciro", JerseyNumber: 9, TeamName: "Persib"}, {Name: "Riko", JerseyNumber: 10, TeamName: "Persija"}, {Name: "Witan", JerseyNumber: 11, TeamName: "Persija"}} {
+			_, err := repos.Players.Register(ctx, player)
+			require.NoError(t, err)
+		}
+		require.NoError(t, repos.Players.Delete(ctx, "witan"))
+
+		players, err := repos.Players.List(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Andi", "Ciro", "Riko"}, playerNames(players))
+
+		for _, key := range []string{strconv.Itoa(teamIDs[0]), "persija"} {
+			players, err := repos.Players.ListByTeam(ctx, key)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"Andi", "Riko"}, playerNames(players))
+		}
+	})
+
+	t.Run("Search filters by team, position and jersey number", func(t *testing.T) {
+		repos := newRepos(t)
+		teamIDs := registerTeams(t, repos.Teams, "Persija", "Persib")
+		players := []domain.Player{
+			andi,
+			{Name: "Ciro", Height: 180, Position: domain.PositionForward, JerseyNumber: 9, TeamName: "Persib"},
+			{Name: "Riko", Height: 170, Position: domain.PositionForward, JerseyNumber: 10, TeamName: "Persija"},
+			{Name: "Witan", Height: 168, Position: domain.PositionForward, JerseyNumber: 8, TeamName: "Persib"},
+		}
+		for _, player := range players {
+			_, err := repos.Players.Register(ctx, player)
+			require.NoError(t, err)
+		}
+
+		page, err := repos.Players.Search(ctx, domain.PlayerQuery{PageRequest: domain.PageRequest{Sort: "-height", Limit: 3}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Ciro", "Andi", "Riko"}, playerNames(page.Items))
+		assert.Equal(t, 4, page.Total)
+
+		for _, key := range []string{strconv.Itoa(teamIDs[1]), "persib"} {
+			page, err := repos.Players.Search(ctx, domain.PlayerQuery{Team: key})
+			require.NoError(t, err)
+			assert.Equal(t, []string{"Ciro", "Witan"}, playerNames(page.Items))
+		}
+
+		page, err = repos.Players.Search(ctx, domain.PlayerQuery{Position: domain.PositionForward, PageRequest: domain.PageRequest{Sort: "team"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Ciro", "Witan", "Riko"}, playerNames(page.Items))
+
+		eight := 8
+		page, err = repos.Players.Search(ctx, domain.PlayerQuery{JerseyNumber: &eight})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Andi", "Witan"}, playerNames(page.Items))
+
+		page, err = repos.Players.Search(ctx, domain.PlayerQuery{Team: "arema"})
+		require.NoError(t, err)
+		assert.Empty(t, page.Items)
+		assert.Zero(t, page.Total)
+	})
+
+	t.Run("Players show the current name of their team", func(t *testing.T) {
+		repos := newRepos(t)
+		registerTeams(t, repos.Teams, "Persija")
+		_, err := repos.Players.Register(ctx, andi)
+		require.NoError(t, err)
+		require.NoError(t, repos.Teams.Update(ctx, "persija", domain.Team{Name: "Persija Jakarta"}))
+
+		player, err := repos.Players.GetByKey(ctx, "andi")
+		require.NoError(t, err)
+		assert.Equal(t, "Persija Jakarta", player.TeamName)
+	})
+
+	t.Run("Update changes details and keeps the ID and slug", func(t *testing.T) {
+		repos := newRepos(t)
+		registerTeams(t, repos.Teams, "Persija")
+		id, err := repos.Players.Register(ctx, andi)
+		require.NoError(t, err)
+
+		renamed := andi
+		renamed.Name, renamed.JerseyNumber = "Andi Setiawan", 7
+		require.NoError(t, repos.Players.Update(ctx, strconv.Itoa(id), renamed))
+
+		player, err := repos.Players.GetByKey(ctx, "andi")
+		require.NoError(t, err)
+		assert.Equal(t, id, player.ID)
+		assert.Equal(t, "Andi Setiawan", player.Name)
+		assert.Equal(t, 7, player.JerseyNumber)
+		assert.False(t, player.UpdatedAt.Before(player.CreatedAt))
+
+		// Keeping their own name and number is not a clash
+		require.NoError(t, repos.Players.Update(ctx, "andi", renamed))
+	})
+
+	t.Run("Update writes a new name onto the match sheets", func(t *testing.T) {
+		repos, matchID := playedDerby(t, newRepos)
+		var shootout []domain.ShootoutKick
+		for i, team := range []string{"home", "away", "home", "away", "home", "away"} {
+			taker := map[string]string{"home": "Riko", "away": "Ciro"}[team]
+			shootout = append(shootout, domain.ShootoutKick{Order: i + 1, Team: team, Taker: taker, Scored: team == "home"})
+		}
+		require.NoError(t, repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: matchID, HomeScore: 1, AwayScore: 1, Shootout: shootout, Goals: []domain.Goal{
+			{Scorer: "Riko", GoalTime: "10:00", Team: "home"},
+			{Scorer: "Ciro", GoalTime: "80:00", Team: "away"},
+		}}))
+
+		riko, err := repos.Players.GetByKey(ctx, "riko")
+		require.NoError(t, err)
+		riko.Name = "Riko Simanjuntak"
+		require.NoError(t, repos.Players.Update(ctx, "riko", *riko))
+
+		result, err := repos.MatchResults.GetByMatchID(ctx, matchID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Riko Simanjuntak", "Ciro"}, scorers(result.Goals))
+		require.Len(t, result.Shootout, 6)
+		assert.Equal(t, "Riko Simanjuntak", result.Shootout[0].Taker)
+		assert.Equal(t, "Ciro", result.Shootout[1].Taker)
+
+		// The goals on the timeline still pass the scorer check
+		require.NoError(t, repos.MatchResults.Update(ctx, result.ID, domain.MatchResult{MatchID: matchID, HomeScore: 1, AwayScore: 1, Shootout: shootout}))
+	})
+
+	t.Run("Update keeps the player with their team", func(t *testing.T) {
+		repos := newRepos(t)
+		registerTeams(t, repos.Teams, "Persija", "Persib")
+		_, err := repos.Players.Register(ctx, andi)
+		require.NoError(t, err)
+
+		err = repos.Players.Update(ctx, "andi", withTeam(andi, "Persib"))
+		assert.EqualError(t, err, "player team cannot be changed here. Use a transfer to move the player to another team")
+	})
+
+	t.Run("Update rejects a name or jersey number taken by another player", func(t *testing.T) {
+		repos := newRepos(t)
+		registerTeams(t, repos.Teams, "Persija")
+		_, err := repos.Players.Register(ctx, andi)
+		require.NoError(t, err)
+		riko := domain.Player{Name: "Riko", JerseyNumber: 10, TeamName: "Persija"}
+		_, err = repos.Players.Register(ctx, riko)
+		require.NoError(t, err)
+
+		err = repos.Players.Update(ctx, "riko", domain.Player{Name: "Andi", JerseyNumber: 10, TeamName: "Persija"})
+		assert.EqualError(t, err, "player name already taken")
+		assert.ErrorIs(t, err, apperrors.ErrPlayerNameTaken)
+		err = repos.Players.Update(ctx, "riko", domain.Player{Name: "Riko", JerseyNumber: andi.JerseyNumber, TeamName: "Persija"})
+		assert.EqualError(t, err, "jersey number already taken in this team")
+		assert.ErrorIs(t, err, apperrors.ErrJerseyNumberTaken)
+	})
+
+	t.Run("Update fails for unknown and deleted players", func(t *testing.T) {
+		repos := newRepos(t)
+		registerTeams(t, repos.Teams, "Persija")
+		_, err := repos.Players.Register(ctx, andi)
+		require.NoError(t, err)
+		assert.EqualError(t, repos.Players.Update(ctx, "riko", andi), "player not found")
+
+		require.NoError(t, repos.Players.Delete(ctx, "andi"))
+		assert.EqualError(t, repos.Players.Update(ctx, "andi", andi), "player not found")
+	})
+
+	t.Run("Delete hides the player", func(t *testing.T) {
+		repos := newRepos(t)
+		registerTeams(t, repos.Teams, "Persija")
+		id, err := repos.Players.Register(ctx, andi)
+		require.NoError(t, err)
+		require.NoError(t, repos.Players.Delete(ctx, strconv.Itoa(id)))
+
+		_, err = repos.Players.GetByKey(ctx, "andi")
+		assert.EqualError(t, err, "player not found")
+		assert.ErrorIs(t, err, apperrors.ErrPlayerNotFound)
+		assert.EqualError(t, repos.Players.Delete(ctx, "andi"), "player not found")
+		assert.EqualError(t, repos.Players.Delete(ctx, "riko"), "player not found")
+	})
+
+	t.Run("Restore brings a deleted player back", func(t *testing.T) {
+		repos := newRepos(t)
+		registerTeams(t, repos.Teams, "Persija")
+		id, err := repos.Players.Register(ctx, andi)
+		require.NoError(t, err)
+		assert.EqualError(t, repos.Players.Restore(ctx, "andi"), "player not found or not deleted")
+		assert.EqualError(t, repos.Players.Restore(ctx, "riko"), "player not found or not deleted")
+
+		require.NoError(t, repos.Players.Delete(ctx, "andi"))
+		require.NoError(t, repos.Players.Restore(ctx, "andi"))
+		player, err := repos.Players.GetByKey(ctx, strconv.Itoa(id))
+		require.NoError(t, err)
+		assert.Nil(t, player.DeletedAt)
+		assert.EqualError(t, repos.Players.Restore(ctx, "andi"), "player not found or not deleted")
+	})
+}
+
+// RunMatchRepositorySuite checks that a MatchRepository schedules, updates, deletes and restores matches
+// and moves them through their statuses the way every backend must
+func RunMatchRepositorySuite(t *testing.T, newRepos NewRepositories) {
+	ctx := context.Background()
+	derby := domain.Match{MatchDate: date(2024, 8, 10), MatchTime: "19:00", HomeTeam: "Persija", AwayTeam: "Persib"}
+
+	t.Run("Register schedules the match", func(t *testing.T) {
+		repos := newRepos(t)
+		registerTeams(t, repos.Teams, "Persija", "Persib")
+		id, err := repos.Matches.Register(ctx, derby)
+		require.NoError(t, err)
+
+		match, err := repos.Matches.GetByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, id, match.ID)
+		assert.Equal(t, "2024-08-10", match.MatchDate.Format("2006-01-02"))
+		assert.Equal(t, "19:00", match.MatchTime)
+		assert.Equal(t, "Persija", match.HomeTeam)
+		assert.Equal(t, "Persib", match.AwayTeam)
+		assert.Nil(t, match.SeasonID)
+		assert.Equal(t, domain.MatchScheduled, match.Status)
+		assert.Nil(t, match.DeletedAt)
+	})
+
+	t.Run("Register checks the teams and the kick-off time", func(t *testing.T) {
+		repos := newRepos(t)
+		registerTeams(t, repos.Teams, "Persija", "Persib", "Arema")
+		require.NoError(t, repos.Teams.Delete(ctx, "arema"))
+
+		cases := map[string]domain.Match{
+			"home team not found":                        withTeams(derby, "Arema", "Persib"),
+			"away team not found":                        withTeams(derby, "Persija", "Bali United"),
+			"home team and away team cannot be the same": withTeams(derby, "Persija", "Persija"),
+			"invalid time format. Use HH:MM":             {MatchDate: derby.MatchDate, MatchTime: "7pm", HomeTeam: "Persija", AwayTeam: "Persib"},
+		}
+		for want, match := range cases {
+			_, err := repos.Matches.Register(ctx, match)
+			assert.EqualError(t, err, want)
+		}
+
+		matches, err := repos.Matches.List(ctx, domain.MatchFilter{})
+		require.NoError(t, err)
+		assert.Empty(t, matches)
+	})
+
+	t.Run("Register checks the season", func(t *testing.T) {
+		repos := newRepos(t)
+		registerTeams(t, repos.Teams, "Persija", "Persib")
+		seasonID := registerSeason(t, repos)
+
+		missing := seasonID + 1
+		_, err := repos.Matches.Register(ctx, withSeason(derby, &missing))
+		assert.EqualError(t, err, "season not found")
+
+		outside := withSeason(derby, &seasonID)
+		outside.MatchDate = date(2025, 8, 10)
+		_, err = repos.Matches.Register(ctx, outside)
+		assert.EqualError(t, err, "match date is outside the season")
+
+		id, err := repos.Matches.Register(ctx, withSeason(derby, &seasonID))
+		require.NoError(t, err)
+		match, err := repos.Matches.GetByID(ctx, id)
+		require.NoError(t, err)
+		require.NotNil(t, match.SeasonID)
+		assert.Equal(t, seasonID, *match.SeasonID)
+	})
+
+	t.Run("RegisterBatch saves every match or none", func(t *testing.T) {
+		repos := newRepos(t)
+		registerTeams(t, repos.Teams, "Persija", "Persib")
+
+		_, err := repos.Matches.RegisterBatch(ctx, []domain.Match{derby, withTeams(derby, "Persib", "Arema")})
+		assert.EqualError(t, err, "match 2 (Persib vs Arema): away team not found")
+		assert.ErrorIs(t, err, apperrors.ErrTeamNotFound)
+		matches, err := repos.Matches.List(ctx, domain.MatchFilter{})
+		require.NoError(t, err)
+		assert.Empty(t, matches)
+
+		ids, err := repos.Matches.RegisterBatch(ctx, []domain.Match{derby, withTeams(derby, "Persib", "Persija")})
+		require.NoError(t, err)
+		assert.Len(t, ids, 2)
+	})
+
+	t.Run("List filters by season, competition and date in order of kick-off", func(t *testing.T) {
+		repos := newRepos(t)
+		registerTeams(t, repos.Teams, "Persija", "Persib", "Arema")
+		seasonID := registerSeason(t, repos)
+		season, err := repos.Seasons.GetByID(ctx, seasonID)
+		require.NoError(t, err)
+
+		late := withSeason(derby, &seasonID)
+		late.MatchDate = date(2024, 9, 1)
+		early := withTeams(derby, "Arema", "Persija")
+		early.MatchTime = "15:30"
+		friendly := withTeams(derby, "Persib", "Arema")
+		friendly.MatchDate = date(2024, 7, 1)
+		var ids []int
+		for _, match := range []domain.Match{late, early, friendly, withSeason(derby, &seasonID)} {
+			id, err := repos.Matches.Register(ctx, match)
+			require.NoError(t, err)
+			ids = append(ids, id)
+		}
+		require.NoError(t, repos.Matches.Delete(ctx, ids[3]))
+
+		matches, err := repos.Matches.List(ctx, domain.MatchFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, []int{ids[2], ids[1], ids[0]}, matchIDs(matches))
+
+		matches, err = repos.Matches.List(ctx, domain.MatchFilter{SeasonID: &seasonID})
+		require.NoError(t, err)
+		assert.Equal(t, []int{ids[0]}, matchIDs(matches))
+
+		matches, err = repos.Matches.List(ctx, domain.MatchFilter{CompetitionID: &season.CompetitionID})
+		require.NoError(t, err)
+		assert.Equal(t, []int{ids[0]}, matchIDs(matches))
+
+		from, to := date(2024, 8, 1), date(2024, 8, 31)
+		matches, err = repos.Matches.List(ctx, domain.MatchFilter{From: &from, To: &to})
+		require.NoError(t, err)
+		assert.Equal(t, []int{ids[1]}, matchIDs(matches))
+
+		matches, err = repos.Matches.ListByTeam(ctx, "arema", domain.MatchFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, []int{ids[2], ids[1]}, matchIDs(matches))
+	})
+
+	t.Run("Search filters by team and venue and pages through the matches", func(t *testing.T) {
+		repos := newRepos(t)
+		teamIDs := registerTeams(t, repos.Teams, "Persija", "Persib", "Arema")
+		var ids []int
+		for i, match := range []domain.Match{derby, withTeams(derby, "Arema", "Persija"), withTeams(derby, "Persib", "Arema")} {
+			match.MatchDate = date(2024, 8, 10+i)
+			id, err := repos.Matches.Register(ctx, match)
+			require.NoError(t, err)
+			ids = append(ids, id)
+		}
+
+		page, err := repos.Matches.Search(ctx, domain.MatchQuery{PageRequest: domain.PageRequest{Limit: 2}})
+		require.NoError(t, err)
+		assert.Equal(t, []int{ids[0], ids[1]}, matchIDs(page.Items))
+		assert.Equal(t, 3, page.Total)
+
+		page, err = repos.Matches.Search(ctx, domain.MatchQuery{PageRequest: domain.PageRequest{Sort: "-date"}})
+		require.NoError(t, err)
+		assert.Equal(t, []int{ids[2], ids[1], ids[0]}, matchIDs(page.Items))
+
+		for _, key := range []string{strconv.Itoa(teamIDs[0]), "persija"} {
+			page, err := repos.Matches.Search(ctx, domain.MatchQuery{Team: key})
+			require.NoError(t, err)
+			assert.Equal(t, []int{ids[0], ids[1]}, matchIDs(page.Items))
+
+			page, err = repos.Matches.Search(ctx, domain.MatchQuery{Team: key, Venue: domain.VenueHome})
+			require.NoError(t, err)
+			assert.Equal(t, []int{ids[0]}, matchIDs(page.Items))
+
+			page, err = repos.Matches.Search(ctx, domain.MatchQuery{Team: key, Venue: domain.VenueAway})
+			require.NoError(t, err)
+			assert.Equal(t, []int{ids[1]}, matchIDs(page.Items))
+		}
+
+		from := date(2024, 8, 11)
+		page, err = repos.Matches.Search(ctx, domain.MatchQuery{MatchFilter: domain.MatchFilter{From: &from}, Team: "arema"})
+		require.NoError(t, err)
+		assert.Equal(t, []int{ids[1], ids[2]}, matchIDs(page.Items))
+		assert.Equal(t, 2, page.Total)
+	})
+
+	t.Run("Update reschedules the match", func(t *testing.T) {
+		repos := newRepos(t)
+		registerTeams(t, repos.Teams, "Persija", "Persib", "Arema")
+		id, err := repos.Matches.Register(ctx, derby)
+		require.NoError(t, err)
+
+		moved := withTeams(derby, "Arema", "Persib")
+		moved.MatchTime = "20:30"
+		require.NoError(t, repos.Matches.Update(ctx, id, moved))
+		match, err := repos.Matches.GetByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "Arema", match.HomeTeam)
+		assert.Equal(t, "20:30", match.MatchTime)
+
+		assert.EqualError(t, repos.Matches.Update(ctx, id, withTeams(derby, "Bali United", "Persib")), "home team not found")
+		assert.EqualError(t, repos.Matches.Update(ctx, id, withTeams(derby, "Persib", "Persib")), "home team and away team cannot be the same")
+		assert.EqualError(t, repos.Matches.Update(ctx, id+1, derby), "match not found")
+	})
+
+	t.Run("Matches show the current names of their teams", func(t *testing.T) {
+		repos := newRepos(t)
+		registerTeams(t, repos.Teams, "Persija", "Persib")
+		id, err := repos.Matches.Register(ctx, derby)
+		require.NoError(t, err)
+		require.NoError(t, repos.Teams.Update(ctx, "persija", domain.Team{Name: "Persija Jakarta"}))
+
+		match, err := repos.Matches.GetByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "Persija Jakarta", match.HomeTeam)
+	})
+
+	t.Run("UpdateStatus follows the allowed transitions", func(t *testing.T) {
+		repos := newRepos(t)
+		registerTeams(t, repos.Teams, "Persija", "Persib")
+		id, err := repos.Matches.Register(ctx, derby)
+		require.NoError(t, err)
+
+		_, err = repos.Matches.UpdateStatus(ctx, id, domain.MatchFinished)
+		assert.EqualError(t, err, "cannot change match status from scheduled to finished")
+		_, err = repos.Matches.UpdateStatus(ctx, id, "halftime")
+		assert.EqualError(t, err, `invalid match status "halftime"`)
+
+		for _, status := range []domain.MatchStatus{domain.MatchLive, domain.MatchFinished} {
+			match, err := repos.Matches.UpdateStatus(ctx, id, status)
+			require.NoError(t, err)
+			assert.Equal(t, status, match.Status)
+		}
+		match, err := repos.Matches.GetByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, domain.MatchFinished, match.Status)
+
+		_, err = repos.Matches.UpdateStatus(ctx, id+1, domain.MatchLive)
+		assert.EqualError(t, err, "match not found")
+		assert.ErrorIs(t, err, apperrors.ErrMatchNotFound)
+	})
+
+	t.Run("A match cannot kick off before its date", func(t *testing.T) {
+		repos := newRepos(t)
+		registerTeams(t, repos.Teams, "Persija", "Persib")
+		future := derby
+		future.MatchDate = time.Now().AddDate(0, 0, 7)
+		id, err := repos.Matches.Register(ctx, future)
+		require.NoError(t, err)
+
+		_, err = repos.Matches.UpdateStatus(ctx, id, domain.MatchLive)
+		assert.Error(t, err)
+		match, err := repos.Matches.GetByID(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, domain.MatchScheduled, match.Status)
+	})
+
+	t.Run("Delete hides the match", func(t *testing.T) {
+		repos := newRepos(t)
+		registerTeams(t, repos.Teams, "Persija", "Persib")
+		id, err := repos.Matches.Register(ctx, derby)
+		require.NoError(t, err)
+		require.NoError(t, repos.Matches.Delete(ctx, id))
+
+		_, err = repos.Matches.GetByID(ctx, id)
+		assert.EqualError(t, err, "match not found")
+		assert.ErrorIs(t, err, apperrors.ErrMatchNotFound)
+		assert.EqualError(t, repos.Matches.Update(ctx, id, derby), "match not found")
+		_, err = repos.Matches.UpdateStatus(ctx, id, domain.MatchLive)
+		assert.EqualError(t, err, "match not found")
+		assert.ErrorIs(t, err, apperrors.ErrMatchNotFound)
+		assert.EqualError(t, repos.Matches.Delete(ctx, id), "match not found")
+		assert.EqualError(t, repos.Matches.Delete(ctx, id+1), "match not found")
+	})
+
+	t.Run("Restore brings a deleted match back", func(t *testing.T) {
+		repos := newRepos(t)
+		registerTeams(t, repos.Teams, "Persija", "Persib")
+		id, err := repos.Matches.Register(ctx, derby)
+		require.NoError(t, err)
+		assert.EqualError(t, repos.Matches.Restore(ctx, id), "match not found or not deleted")
+		assert.EqualError(t, repos.Matches.Restore(ctx, id+1), "match not found or not deleted")
+
+		require.NoError(t, repos.Matches.Delete(ctx, id))
+		require.NoError(t, repos.Matches.Restore(ctx, id))
+		match, err := repos.Matches.GetByID(ctx, id)
+		require.NoError(t, err)
+		assert.Nil(t, match.DeletedAt)
+		assert.EqualError(t, repos.Matches.Restore(ctx, id), "match not found or not deleted")
+	})
+}
+
+// RunMatchResultRepositorySuite checks that a MatchResultRepository records, updates, deletes and restores
+// results the way every backend must
+func RunMatchResultRepositorySuite(t *testing.T, newRepos NewRepositories) {
+	ctx := context.Background()
+	rikoScores := domain.Goal{Scorer: "Riko", GoalTime: "10:00", Team: "home"}
+	ciroScores := domain.Goal{Scorer: "Ciro", GoalTime: "80:00", Team: "away"}
+
+	t.Run("Register records the score and goals", func(t *testing.T) {
+		repos, matchID := playedDerby(t, newRepos)
+		require.NoError(t, repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: matchID, HomeScore: 1, AwayScore: 1, Goals: []domain.Goal{ciroScores, rikoScores}}))
+
+		result, err := repos.MatchResults.GetByMatchID(ctx, matchID)
+		require.NoError(t, err)
+		assert.Equal(t, matchID, result.MatchID)
+		assert.Equal(t, 1, result.HomeScore)
+		assert.Equal(t, 1, result.AwayScore)
+		assert.Equal(t, []string{"Riko", "Ciro"}, scorers(result.Goals))
+		assert.Nil(t, result.DeletedAt)
+
+		byID, err := repos.MatchResults.GetByID(ctx, result.ID)
+		require.NoError(t, err)
+		assert.Equal(t, result.MatchID, byID.MatchID)
+		assert.Equal(t, []string{"Riko", "Ciro"}, scorers(byID.Goals))
+	})
+
+	t.Run("Register needs a match that kicked off", func(t *testing.T) {
+		repos, matchID := playedDerby(t, newRepos)
+		err := repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: matchID + 1})
+		assert.EqualError(t, err, "match not found")
+		assert.ErrorIs(t, err, apperrors.ErrMatchNotFound)
+
+		scheduled, err := repos.Matches.Register(ctx, domain.Match{MatchDate: date(2024, 9, 1), MatchTime: "19:00", HomeTeam: "Persib", AwayTeam: "Persija"})
+		require.NoError(t, err)
+		err = repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: scheduled})
+		assert.EqualError(t, err, "match is scheduled, results and events can only be recorded once it is live or finished")
+	})
+
+	t.Run("Register allows one result per match", func(t *testing.T) {
+		repos, matchID := playedDerby(t, newRepos)
+		require.NoError(t, repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: matchID}))
+		err := repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: matchID, HomeScore: 1, Goals: []domain.Goal{rikoScores}})
+		assert.EqualError(t, err, "result already exists for this match")
+		assert.ErrorIs(t, err, apperrors.ErrMatchResultAlreadyExists)
+	})
+
+	t.Run("Register checks the goals", func(t *testing.T) {
+		repos, matchID := playedDerby(t, newRepos)
+		err := repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: matchID, HomeScore: 2, Goals: []domain.Goal{rikoScores}})
+		assert.EqualError(t, err, "home score does not match number of home goals")
+
+		err = repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: matchID, AwayScore: 1, Goals: []domain.Goal{{Scorer: "Riko", GoalTime: "10:00", Team: "away"}}})
+		var invalid *domain.InvalidGoalsError
+		assert.ErrorAs(t, err, &invalid)
+
+		_, err = repos.MatchResults.GetByMatchID(ctx, matchID)
+		assert.EqualError(t, err, "match result not found")
+		assert.ErrorIs(t, err, apperrors.ErrMatchResultNotFound)
+	})
+
+	t.Run("List returns active results", func(t *testing.T) {
+		repos, matchID := playedDerby(t, newRepos)
+		require.NoError(t, repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: matchID, HomeScore: 1, Goals: []domain.Goal{rikoScores}}))
+
+		results, err := repos.MatchResults.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, matchID, results[0].MatchID)
+
+		require.NoError(t, repos.MatchResults.Delete(ctx, results[0].ID))
+		results, err = repos.MatchResults.List(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("ListByMatches returns the active results of the given matches", func(t *testing.T) {
+		repos, matchID := playedDerby(t, newRepos)
+		require.NoError(t, repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: matchID, HomeScore: 1, Goals: []domain.Goal{rikoScores}}))
+
+		results, err := repos.MatchResults.ListByMatches(ctx, []int{matchID + 1, matchID})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, matchID, results[0].MatchID)
+		assert.Equal(t, []string{"Riko"}, scorers(results[0].Goals))
+		resultID := results[0].ID
+
+		results, err = repos.MatchResults.ListByMatches(ctx, []int{matchID + 1})
+		require.NoError(t, err)
+		assert.Empty(t, results)
+
+		require.NoError(t, repos.MatchResults.Delete(ctx, resultID))
+		results, err = repos.MatchResults.ListByMatches(ctx, []int{matchID})
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("Search pages through the results, newest first", func(t *testing.T) {
+		repos, matchID := playedDerby(t, newRepos)
+		require.NoError(t, repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: matchID, HomeScore: 1, Goals: []domain.Goal{rikoScores}}))
+
+		page, err := repos.MatchResults.Search(ctx, domain.MatchResultQuery{})
+		require.NoError(t, err)
+		require.Len(t, page.Items, 1)
+		assert.Equal(t, matchID, page.Items[0].MatchID)
+		assert.Equal(t, []string{"Riko"}, scorers(page.Items[0].Goals))
+		assert.Equal(t, 1, page.Total)
+
+		page, err = repos.MatchResults.Search(ctx, domain.MatchResultQuery{PageRequest: domain.PageRequest{Offset: 1}})
+		require.NoError(t, err)
+		assert.Empty(t, page.Items)
+		assert.Equal(t, 1, page.Total)
+	})
+
+	t.Run("Update replaces the score and goals", func(t *testing.T) {
+		repos, matchID := playedDerby(t, newRepos)
+		require.NoError(t, repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: matchID, HomeScore: 1, Goals: []domain.Goal{rikoScores}}))
+		result, err := repos.MatchResults.GetByMatchID(ctx, matchID)
+		require.NoError(t, err)
+
+		require.NoError(t, repos.MatchResults.Update(ctx, result.ID, domain.MatchResult{MatchID: matchID, AwayScore: 1, Goals: []domain.Goal{ciroScores}}))
+		updated, err := repos.MatchResults.GetByID(ctx, result.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, updated.HomeScore)
+		assert.Equal(t, 1, updated.AwayScore)
+		assert.Equal(t, []string{"Ciro"}, scorers(updated.Goals))
+
+		err = repos.MatchResults.Update(ctx, result.ID, domain.MatchResult{MatchID: matchID, AwayScore: 2, Goals: []domain.Goal{ciroScores}})
+		assert.EqualError(t, err, "away score does not match number of away goals")
+		err = repos.MatchResults.Update(ctx, result.ID+1, domain.MatchResult{MatchID: matchID})
+		assert.EqualError(t, err, "match result not found")
+		assert.ErrorIs(t, err, apperrors.ErrMatchResultNotFound)
+	})
+
+	t.Run("Update without goals keeps the goal events of the timeline", func(t *testing.T) {
+		repos, matchID := playedDerby(t, newRepos)
+		require.NoError(t, repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: matchID, HomeScore: 1, Goals: []domain.Goal{rikoScores}}))
+		result, err := repos.MatchResults.GetByMatchID(ctx, matchID)
+		require.NoError(t, err)
+		events, err := repos.MatchEvents.ListByMatch(ctx, matchID)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+
+		require.NoError(t, repos.MatchResults.Update(ctx, result.ID, domain.MatchResult{MatchID: matchID, HomeScore: 1}))
+		updated, err := repos.MatchResults.GetByID(ctx, result.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Riko"}, scorers(updated.Goals))
+		kept, err := repos.MatchEvents.ListByMatch(ctx, matchID)
+		require.NoError(t, err)
+		require.Len(t, kept, 1)
+		assert.Equal(t, events[0].ID, kept[0].ID)
+
+		err = repos.MatchResults.Update(ctx, result.ID, domain.MatchResult{MatchID: matchID, HomeScore: 2})
+		assert.EqualError(t, err, "home score does not match number of home goals")
+
+		// Goals in the request replace the goal events, which are soft deleted rather than removed
+		require.NoError(t, repos.MatchResults.Update(ctx, result.ID, domain.MatchResult{MatchID: matchID, AwayScore: 1, Goals: []domain.Goal{ciroScores}}))
+		replaced, err := repos.MatchEvents.ListByMatch(ctx, matchID)
+		require.NoError(t, err)
+		require.Len(t, replaced, 1)
+		assert.Equal(t, "Ciro", replaced[0].Player)
+		_, err = repos.MatchEvents.GetByID(ctx, events[0].ID)
+		assert.ErrorIs(t, err, apperrors.ErrMatchEventNotFound)
+	})
+
+	t.Run("Update keeps the result with its match", func(t *testing.T) {
+		repos, matchID := playedDerby(t, newRepos)
+		require.NoError(t, repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: matchID, HomeScore: 1, Goals: []domain.Goal{rikoScores}}))
+		result, err := repos.MatchResults.GetByMatchID(ctx, matchID)
+		require.NoError(t, err)
+		rematch, err := repos.Matches.Register(ctx, domain.Match{MatchDate: date(2024, 9, 1), MatchTime: "19:00", HomeTeam: "Persib", AwayTeam: "Persija"})
+		require.NoError(t, err)
+		_, err = repos.Matches.UpdateStatus(ctx, rematch, domain.MatchLive)
+		require.NoError(t, err)
+
+		err = repos.MatchResults.Update(ctx, result.ID, domain.MatchResult{MatchID: rematch, AwayScore: 1, Goals: []domain.Goal{rikoScores}})
+		assert.EqualError(t, err, "a match result cannot be moved to another match")
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+
+		kept, err := repos.MatchResults.GetByMatchID(ctx, matchID)
+		require.NoError(t, err)
+		assert.Equal(t, result.ID, kept.ID)
+		assert.Equal(t, []string{"Riko"}, scorers(kept.Goals))
+		_, err = repos.MatchResults.GetByMatchID(ctx, rematch)
+		assert.ErrorIs(t, err, apperrors.ErrMatchResultNotFound)
+	})
+
+	t.Run("Delete hides the result", func(t *testing.T) {
+		repos, matchID := playedDerby(t, newRepos)
+		require.NoError(t, repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: matchID}))
+		result, err := repos.MatchResults.GetByMatchID(ctx, matchID)
+		require.NoError(t, err)
+		require.NoError(t, repos.MatchResults.Delete(ctx, result.ID))
+
+		_, err = repos.MatchResults.GetByMatchID(ctx, matchID)
+		assert.EqualError(t, err, "match result not found")
+		assert.ErrorIs(t, err, apperrors.ErrMatchResultNotFound)
+		_, err = repos.MatchResults.GetByID(ctx, result.ID)
+		assert.EqualError(t, err, "match result not found")
+		assert.ErrorIs(t, err, apperrors.ErrMatchResultNotFound)
+		assert.EqualError(t, repos.MatchResults.Update(ctx, result.ID, domain.MatchResult{MatchID: matchID}), "match result not found")
+		assert.EqualError(t, repos.MatchResults.Delete(ctx, result.ID), "match result not found")
+		assert.EqualError(t, repos.MatchResults.Delete(ctx, result.ID+1), "match result not found")
+	})
+
+	t.Run("Restore brings a deleted result back with its goals", func(t *testing.T) {
+		repos, matchID := playedDerby(t, newRepos)
+		require.NoError(t, repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: matchID, HomeScore: 1, Goals: []domain.Goal{rikoScores}}))
+		result, err := repos.MatchResults.GetByMatchID(ctx, matchID)
+		require.NoError(t, err)
+		assert.EqualError(t, repos.MatchResults.Restore(ctx, result.ID), "match result not found or not deleted")
+		assert.EqualError(t, repos.MatchResults.Restore(ctx, result.ID+1), "match result not found or not deleted")
+
+		require.NoError(t, repos.MatchResults.Delete(ctx, result.ID))
+		require.NoError(t, repos.MatchResults.Restore(ctx, result.ID))
+		restored, err := repos.MatchResults.GetByMatchID(ctx, matchID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, restored.HomeScore)
+		assert.Equal(t, []string{"Riko"}, scorers(restored.Goals))
+		assert.EqualError(t, repos.MatchResults.Restore(ctx, result.ID), "match result not found or not deleted")
+	})
+
+	t.Run("ListBetween returns finished matches between the teams", func(t *testing.T) {
+		repos, matchID := playedDerby(t, newRepos)
+		require.NoError(t, repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: matchID, HomeScore: 1, Goals: []domain.Goal{rikoScores}}))
+
+		for _, teams := range [][2]string{{"Persija", "Persib"}, {"Persib", "Persija"}} {
+			matches, err := repos.MatchResults.ListBetween(ctx, teams[0], teams[1])
+			require.NoError(t, err)
+			require.Len(t, matches, 1)
+			assert.Equal(t, matchID, matches[0].MatchID)
+			assert.Equal(t, "2024-08-10", matches[0].MatchDate)
+			assert.Equal(t, "Persija", matches[0].HomeTeam)
+			assert.Equal(t, 1, matches[0].HomeScore)
+			assert.Nil(t, matches[0].HomePenalties)
+		}
+
+		matches, err := repos.MatchResults.ListBetween(ctx, "Persija", "Arema")
+		require.NoError(t, err)
+		assert.Empty(t, matches)
+	})
+}
+
+// RunTransferRepositorySuite runs the TransferRepository contract tests
+func RunTransferRepositorySuite(t *testing.T, newRepos NewRepositories) {
+	ctx := context.Background()
+	transfer := func(player, toTeam string, date time.Time, jerseyNumber int) domain.Transfer {
+		return domain.Transfer{Player: player, ToTeam: toTeam, TransferDate: date, Type: domain.TransferPermanent, JerseyNumber: jerseyNumber}
+	}
+
+	t.Run("Registering a player can date back the first contract", func(t *testing.T) {
+		repos, _ := transferMarket(t, newRepos)
+		contracts, err := repos.Transfers.ListContracts(ctx, "riko")
+		require.NoError(t, err)
+		require.Len(t, contracts, 1)
+		assert.Equal(t, "2024-01-01", contracts[0].StartDate.Format("2006-01-02"))
+	})
+
+	t.Run("Register finds the player by ID or slug", func(t *testing.T) {
+		repos, _ := transferMarket(t, newRepos)
+		riko, err := repos.Players.GetByKey(ctx, "riko")
+		require.NoError(t, err)
+
+		registered, err := repos.Transfers.Register(ctx, transfer("riko", "Persib", date(2024, 7, 15), 11))
+		require.NoError(t, err)
+		assert.Equal(t, "Riko", registered.Player)
+		assert.Equal(t, "Persija", registered.FromTeam)
+
+		registered, err = repos.Transfers.Register(ctx, transfer(strconv.Itoa(riko.ID), "Persija", date(2024, 8, 15), 10))
+		require.NoError(t, err)
+		assert.Equal(t, "Riko", registered.Player)
+		assert.Equal(t, "Persib", registered.FromTeam)
+
+		// Names are not keys
+		_, err = repos.Transfers.Register(ctx, transfer("Riko", "Persib", date(2024, 8, 20), 11))
+		assert.ErrorIs(t, err, apperrors.ErrPlayerNotFound)
+	})
+
+	t.Run("Register only counts windows of the season the transfer date falls in", func(t *testing.T) {
+		repos, seasonID := transferMarket(t, newRepos)
+		season, err := repos.Seasons.GetByID(ctx, seasonID)
+		require.NoError(t, err)
+		nextSeasonID, err := repos.Seasons.Register(ctx, domain.Season{CompetitionID: season.CompetitionID, Name: "2025/26", StartDate: date(2025, 7, 1), EndDate: date(2026, 5, 31)})
+		require.NoError(t, err)
+
+		// Open in January, but for a season that has not started yet
+		_, err = repos.TransferWindows.Register(ctx, domain.TransferWindow{SeasonID: nextSeasonID, Name: "Early", StartDate: date(2025, 1, 1), EndDate: date(2025, 1, 31)})
+		require.NoError(t, err)
+		_, err = repos.Transfers.Register(ctx, transfer("riko", "Persib", date(2025, 1, 15), 11))
+		assert.ErrorIs(t, err, apperrors.ErrInvalidInput)
+
+		_, err = repos.TransferWindows.Register(ctx, domain.TransferWindow{SeasonID: seasonID, Name: "Winter", StartDate: date(2025, 1, 1), EndDate: date(2025, 1, 31)})
+		require.NoError(t, err)
+		_, err = repos.Transfers.Register(ctx, transfer("riko", "Persib", date(2025, 1, 15), 11))
+		require.NoError(t, err)
+	})
+}
+
+// RunCompetitionRepositorySuite checks that competitions, seasons and brackets are looked up the way every
+// backend must
+func RunCompetitionRepositorySuite(t *testing.T, newRepos NewRepositories) {
+	ctx := context.Background()
+
+	t.Run("GetByID reports unknown IDs as not found", func(t *testing.T) {
+		repos := newRepos(t)
+		_, err := repos.Competitions.GetByID(ctx, 1)
+		assert.EqualError(t, err, "competition not found")
+		assert.ErrorIs(t, err, apperrors.ErrCompetitionNotFound)
+		_, err = repos.Seasons.GetByID(ctx, 1)
+		assert.EqualError(t, err, "season not found")
+		assert.ErrorIs(t, err, apperrors.ErrSeasonNotFound)
+		_, err = repos.Brackets.GetByID(ctx, 1)
+		assert.EqualError(t, err, "bracket not found")
+		assert.ErrorIs(t, err, apperrors.ErrBracketNotFound)
+	})
+
+	t.Run("GetByID finds registered records", func(t *testing.T) {
+		repos := newRepos(t)
+		competitionID, err := repos.Competitions.Register(ctx, domain.Competition{Name: "Liga 1", Type: domain.CompetitionLeague})
+		require.NoError(t, err)
+		seasonID, err := repos.Seasons.Register(ctx, domain.Season{CompetitionID: competitionID, Name: "2024/25", StartDate: date(2024, 7, 1), EndDate: date(2025, 5, 31)})
+		require.NoError(t, err)
+
+		competition, err := repos.Competitions.GetByID(ctx, competitionID)
+		require.NoError(t, err)
+		assert.Equal(t, "Liga 1", competition.Name)
+		season, err := repos.Seasons.GetByID(ctx, seasonID)
+		require.NoError(t, err)
+		assert.Equal(t, competitionID, season.CompetitionID)
+	})
+}
+
+// RunTrashRepositorySuite checks that a TrashRepository lists soft-deleted records of every kind and
+// purges them for good the way every backend must
+func RunTrashRepositorySuite(t *testing.T, newRepos NewRepositories) {
+	ctx := context.Background()
+
+	t.Run("List shows deleted records of every kind", func(t *testing.T) {
+		repos, matchID := playedDerby(t, newRepos)
+		require.NoError(t, repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: matchID}))
+		result, err := repos.MatchResults.GetByMatchID(ctx, matchID)
+		require.NoError(t, err)
+		require.NoError(t, repos.MatchResults.Delete(ctx, result.ID))
+		require.NoError(t, repos.Matches.Delete(ctx, matchID))
+		require.NoError(t, repos.Players.Delete(ctx, "riko"))
+		registerTeams(t, repos.Teams, "Arema")
+		require.NoError(t, repos.Teams.Delete(ctx, "arema"))
+
+		page, err := repos.Trash.List(ctx, domain.TrashQuery{PageRequest: domain.PageRequest{Sort: "type"}})
+		require.NoError(t, err)
+		assert.Equal(t, 4, page.Total)
+		assert.Equal(t, []string{"match:Persija vs Persib", "match_result:Persija vs Persib", "player:Riko", "team:Arema"}, trashEntries(page.Items))
+
+		page, err = repos.Trash.List(ctx, domain.TrashQuery{Type: domain.TrashPlayer})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"player:Riko"}, trashEntries(page.Items))
+	})
+
+	t.Run("Purge removes a deleted record for good", func(t *testing.T) {
+		repos := newRepos(t)
+		ids := registerTeams(t, repos.Teams, "Persija", "Arema")
+
+		err := repos.Trash.Purge(ctx, domain.TrashTeam, ids[1])
+		assert.EqualError(t, err, "team not found or not deleted")
+		assert.ErrorIs(t, err, apperrors.ErrTeamNotFound)
+
+		require.NoError(t, repos.Teams.Delete(ctx, "arema"))
+		require.NoError(t, repos.Trash.Purge(ctx, domain.TrashTeam, ids[1]))
+		assert.ErrorIs(t, repos.Teams.Restore(ctx, "arema"), apperrors.ErrTeamNotFound)
+
+		page, err := repos.Trash.List(ctx, domain.TrashQuery{})
+		require.NoError(t, err)
+		assert.Empty(t, page.Items)
+
+		// The name is free again
+		_, err = repos.Teams.Register(ctx, domain.Team{Name: "Arema"})
+		require.NoError(t, err)
+	})
+
+	t.Run("Purge keeps records still referred to", func(t *testing.T) {
+		repos, matchID := playedDerby(t, newRepos)
+		require.NoError(t, repos.Teams.Delete(ctx, "persija"))
+		err := repos.Trash.Purge(ctx, domain.TrashTeam, 1)
+		assert.ErrorIs(t, err, apperrors.ErrReferenceViolation)
+
+		// A deleted match is kept while its result still counts, and goes with it once the result is deleted too
+		require.NoError(t, repos.MatchResults.Register(ctx, domain.MatchResult{MatchID: matchID}))
+		result, err := repos.MatchResults.GetByMatchID(ctx, matchID)
+		require.NoError(t, err)
+		require.NoError(t, repos.Matches.Delete(ctx, matchID))
+		err = repos.Trash.Purge(ctx, domain.TrashMatch, matchID)
+		assert.ErrorIs(t, err, apperrors.ErrReferenceViolation)
+
+		require.NoError(t, repos.MatchResults.Delete(ctx, result.ID))
+		require.NoError(t, repos.Trash.Purge(ctx, domain.TrashMatch, matchID))
+		page, err := repos.Trash.List(ctx, domain.TrashQuery{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"team:Persija"}, trashEntries(page.Items))
+	})
+
+	t.Run("Purge keeps matches with rating history", func(t *testing.T) {
+		repos, matchID := playedDerby(t, newRepos)
+		match, err := repos.Matches.GetByID(ctx, matchID)
+		require.NoError(t, err)
+		require.NoError(t, repos.Ratings.ReplaceFrom(ctx, nil, []domain.RatingChange{
+			{MatchID: matchID, MatchDate: match.MatchDate, MatchTime: match.MatchTime, Team: "Persija", Opponent: "Persib", Venue: "home", Outcome: "D", Before: 1500, After: 1497.2},
+			{MatchID: matchID, MatchDate: match.MatchDate, MatchTime: match.MatchTime, Team: "Persib", Opponent: "Persija", Venue: "away", Outcome: "D", Before: 1500, After: 1502.8},
+		}))
+		require.NoError(t, repos.Matches.Delete(ctx, matchID))
+		assert.ErrorIs(t, repos.Trash.Purge(ctx, domain.TrashMatch, matchID), apperrors.ErrReferenceViolation)
+
+		// Replaying the ratings without the deleted match, as the rating service does, frees it
+		require.NoError(t, repos.Ratings.ReplaceFrom(ctx, match, nil))
+		require.NoError(t, repos.Trash.Purge(ctx, domain.TrashMatch, matchID))
+	})
+
+	t.Run("PurgeDeletedBefore purges what was deleted before the cutoff", func(t *testing.T) {
+		repos, matchID := playedDerby(t, newRepos)
+		require.NoError(t, repos.Matches.Delete(ctx, matchID))
+		require.NoError(t, repos.Players.Delete(ctx, "riko"))
+		require.NoError(t, repos.Teams.Delete(ctx, "persija"))
+
+		report, err := repos.Trash.PurgeDeletedBefore(ctx, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		assert.Empty(t, report.Purged)
+
+		report, err = repos.Trash.PurgeDeletedBefore(ctx, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{domain.TrashMatch: 1, domain.TrashPlayer: 1, domain.TrashTeam: 1}, report.Purged)
+		assert.Empty(t, report.Kept)
+
+		page, err := repos.Trash.List(ctx, domain.TrashQuery{})
+		require.NoError(t, err)
+		assert.Empty(t, page.Items)
+	})
+
+	t.Run("PurgeDeletedBefore keeps teams with active players", func(t *testing.T) {
+		repos, _ := playedDerby(t, newRepos)
+		registerTeams(t, repos.Teams, "Arema")
+		_, err := repos.Players.Register(ctx, domain.Player{Name: "Dendi", JerseyNumber: 7, TeamName: "Arema"})
+		require.NoError(t, err)
+		require.NoError(t, repos.Teams.Delete(ctx, "arema"))
+
+		report, err := repos.Trash.PurgeDeletedBefore(ctx, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.Empty(t, report.Purged)
+		assert.Equal(t, map[string]int{domain.TrashTeam: 1}, report.Kept)
+	})
+}
+
+// registerTeams registers teams with the given names and returns their IDs
+func registerTeams(t *testing.T, repo usecases.TeamRepository, names ...string) []int {
+	var ids []int
+	for _, name := range names {
+		id, err := repo.Register(context.Background(), domain.Team{Name: name})
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// registerSeason registers a league with a season running from July 2024 to May 2025 and returns the season's ID
+func registerSeason(t *testing.T, repos *usecases.Repositories) int {
+	ctx := context.Background()
+	competitionID, err := repos.Competitions.Register(ctx, domain.Competition{Name: "Liga 1", Type: domain.CompetitionLeague})
+	require.NoError(t, err)
+	seasonID, err := repos.Seasons.Register(ctx, domain.Season{CompetitionID: competitionID, Name: "2024/25", StartDate: date(2024, 7, 1), EndDate: date(2025, 5, 31)})
+	require.NoError(t, err)
+	return seasonID
+}
+
+// transferMarket returns new repositories holding the teams Persija and Persib with one player each, both
+// under contract since January 2024, and the season of registerSeason with a summer transfer window
+func transferMarket(t *testing.T, newRepos NewRepositories) (*usecases.Repositories, int) {
+	ctx := context.Background()
+	repos := newRepos(t)
+	registerTeams(t, repos.Teams, "Persija", "Persib")
+	for _, player := range []domain.Player{
+		{Name: "Riko", JerseyNumber: 10, TeamName: "Persija", ContractStart: "2024-01-01"},
+		{Name: "Ciro", JerseyNumber: 9, TeamName: "Persib", ContractStart: "2024-01-01"},
+	} {
+		_, err := repos.Players.Register(ctx, player)
+		require.NoError(t, err)
+	}
+
+	seasonID := registerSeason(t, repos)
+	_, err := repos.TransferWindows.Register(ctx, domain.TransferWindow{SeasonID: seasonID, Name: "Summer", StartDate: date(2024, 7, 1), EndDate: date(2024, 8, 31)})
+	require.NoError(t, err)
+	return repos, seasonID
+}
+
+// playedDerby returns new repositories holding the teams Persija and Persib with one player each and
+// a finished match between them without a result
+func playedDerby(t *testing.T, newRepos NewRepositories) (*usecases.Repositories, int) {
+	ctx := context.Background()
+	repos := newRepos(t)
+	registerTeams(t, repos.Teams, "Persija", "Persib")
+	for _, player := range []domain.Player{{Name: "Riko", JerseyNumber: 10, TeamName: "Persija"}, {Name: "Ciro", JerseyNumber: 9, TeamName: "Persib"}} {
+		_, err := repos.Players.Register(ctx, player)
+		require.NoError(t, err)
+	}
+
+	matchID, err := repos.Matches.Register(ctx, domain.Match{MatchDate: date(2024, 8, 10), MatchTime: "19:00", HomeTeam: "Persija", AwayTeam: "Persib"})
+	require.NoError(t, err)
+	for _, status := range []domain.MatchStatus{domain.MatchLive, domain.MatchFinished} {
+		_, err := repos.Matches.UpdateStatus(ctx, matchID, status)
+		require.NoError(t, err)
+	}
+	return repos, matchID
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func withTeam(player domain.Player, team string) domain.Player {
+	player.TeamName = team
+	return player
+}
+
+func withTeams(match domain.Match, home, away string) domain.Match {
+	match.HomeTeam, match.AwayTeam = home, away
+	return match
+}
+
+func withSeason(match domain.Match, seasonID *int) domain.Match {
+	match.SeasonID = seasonID
+	return match
+}
+
+// trashEntries returns the type and name of trash items as "type:name"
+func trashEntries(items []domain.TrashItem) []string {
+	var entries []string
+	for _, item := range items {
+		entries = append(entries, item.Type+":"+item.Name)
+	}
+	return entries
+}
+
+func teamNames(teams []domain.Team) []string {
+	var names []string
+	for _, team := range teams {
+		names = append(names, team.Name)
+	}
+	return names
+}
+
+func playerNames(players []domain.Player) []string {
+	var names []string
+	for _, player := range players {
+		names = append(names, player.Name)
+	}
+	return names
+}
+
+func matchIDs(matches []domain.Match) []int {
+	var ids []int
+	for _, match := range matches {
+		ids = append(ids, match.ID)
+	}
+	return ids
+}
+
+func scorers(goals []domain.Goal) []string {
+	var names []string
+	for _, goal := range goals {
+		names = append(names, goal.Scorer)
+	}
+	return names
+}
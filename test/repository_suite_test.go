@@ -0,0 +1,57 @@
+package test
+
+import (
+	"context"
+	"football-team-management/internal/migrations"
+	"football-team-management/internal/usecases"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryRepositories(t *testing.T) {
+	RunRepositorySuite(t, func(t *testing.T) *usecases.Repositories {
+		return usecases.NewMemoryRepositories()
+	})
+}
+
+// TestPostgresRepositories runs the suite against the database at TEST_DATABASE_URL. Every table in it
+// is emptied before each case, so never point it at a database holding data you want to keep
+func TestPostgresRepositories(t *testing.T) {
+	pool := testPool(t)
+	RunRepositorySuite(t, func(t *testing.T) *usecases.Repositories {
+		emptyDatabase(t, pool)
+		return usecases.NewPostgresRepositories(pool)
+	})
+}
+
+// testPool connects to the database at TEST_DATABASE_URL and applies the migrations, skipping the test
+// if the variable is not set
+func testPool(tb testing.TB) *pgxpool.Pool {
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		tb.Skip("TEST_DATABASE_URL not set")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, url)
+	require.NoError(tb, err)
+	tb.Cleanup(pool.Close)
+
+	migrator, err := migrations.NewMigrator(pool)
+	require.NoError(tb, err)
+	_, err = migrator.Up(ctx)
+	require.NoError(tb, err)
+	return pool
+}
+
+// emptyDatabase empties every table but the migration history and restarts the IDs
+func emptyDatabase(tb testing.TB, pool *pgxpool.Pool) {
+	_, err := pool.Exec(context.Background(), `DO $$ DECLARE tables text; BEGIN
+		SELECT string_agg(quote_ident(tablename), ', ') INTO tables FROM pg_tables WHERE schemaname = current_schema() AND tablename != 'schema_migrations';
+		EXECUTE 'TRUNCATE ' || tables || ' RESTART IDENTITY CASCADE';
+	END $$`)
+	require.NoError(tb, err)
+}
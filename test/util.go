@@ -1,6 +1,7 @@
 package test
 
 import (
+	"football-team-management/cmd/web/middleware"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -18,6 +19,7 @@ func MakeRequest(router *gin.Engine, method string, URI string, body io.Reader)
 
 func Router(URI string, handler func(c *gin.Context), method string) *gin.Engine {
 	router := gin.New()
+	router.Use(middleware.ErrorHandler())
 	router.Handle(method, URI, handler)
 	return router
 }